@@ -0,0 +1,29 @@
+package cipher
+
+import (
+	"crypto/cipher"
+	"errors"
+)
+
+// ErrKeySize is returned by AEADScheme.New when the key has the wrong size.
+var ErrKeySize = errors.New("cipher: bad key size")
+
+// AEADScheme represents a specific AEAD algorithm.
+type AEADScheme interface {
+	// Name of the scheme.
+	Name() string
+
+	// KeySize returns the size of the keys in bytes.
+	KeySize() int
+
+	// NonceSize returns the size of the nonces in bytes.
+	NonceSize() int
+
+	// TagSize returns the size of the authentication tags in bytes, which
+	// is the Overhead of the AEAD.
+	TagSize() int
+
+	// New returns an AEAD instance with the given key. It fails with
+	// ErrKeySize if the key is not KeySize bytes long.
+	New(key []byte) (cipher.AEAD, error)
+}
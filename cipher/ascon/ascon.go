@@ -9,6 +9,23 @@
 // key search. Since Ascon-128 and Ascon-80pq share the same building blocks
 // and same parameters except the size of the key, it is claimed the same
 // security for Ascon-80pq against classical attacks as for Ascon-128.
+//
+// The final standard, NIST SP 800-232, is implemented by the AsconAEAD128
+// mode as well as by the Ascon-Hash256, Ascon-XOF128 and Ascon-CXOF128
+// functions. They are not compatible with the v1.2 ones, as the standard
+// changed the byte order and the initialization vectors.
+// https://doi.org/10.6028/NIST.SP.800-232
+//
+// The hash function Ascon-Hash and the extendable-output function Ascon-XOF,
+// as well as their variants Ascon-Hasha and Ascon-XOFa, are built on the
+// same permutation. So are the keyed functions Ascon-Mac, Ascon-Prf and
+// Ascon-PrfShort, specified in "Ascon PRF, MAC, and Short-Input MAC" by the
+// same authors.
+// https://eprint.iacr.org/2021/1574
+//
+// Two constructions on top of these are provided for applications whose
+// needs the plain ciphers do not meet: SIV, which resists the reuse of
+// nonces, and Committing, which binds each ciphertext to its key.
 package ascon
 
 import (
@@ -27,10 +44,10 @@ const (
 
 type Mode int
 
-// KeySize is 16 for Ascon128 and Ascon128a, or 20 for Ascon80pq.
+// KeySize is 16 for Ascon128, Ascon128a and AsconAEAD128, or 20 for Ascon80pq.
 func (m Mode) KeySize() int {
 	switch m {
-	case Ascon128, Ascon128a, Ascon80pq:
+	case Ascon128, Ascon128a, Ascon80pq, AsconAEAD128:
 		v := int(m) >> 2
 		return KeySize&^v | KeySize80pq&v
 	default:
@@ -46,6 +63,8 @@ func (m Mode) String() string {
 		return "Ascon128a"
 	case Ascon80pq:
 		return "Ascon80pq"
+	case AsconAEAD128:
+		return "AsconAEAD128"
 	default:
 		panic(ErrMode)
 	}
@@ -55,6 +74,9 @@ const (
 	Ascon128  Mode = 1
 	Ascon128a Mode = 2
 	Ascon80pq Mode = -1
+
+	// AsconAEAD128 is the mode standardized in NIST SP 800-232.
+	AsconAEAD128 Mode = 3
 )
 
 const permA = 12
@@ -66,20 +88,23 @@ type Cipher struct {
 
 // New returns a Cipher struct implementing the crypto/cipher.AEAD interface.
 // The key must be Mode.KeySize() bytes long, and the mode is one of Ascon128,
-// Ascon128a or Ascon80pq.
+// Ascon128a, Ascon80pq or AsconAEAD128.
 func New(key []byte, m Mode) (*Cipher, error) {
-	if (m == Ascon128 || m == Ascon128a) && len(key) != KeySize {
+	if (m == Ascon128 || m == Ascon128a || m == AsconAEAD128) && len(key) != KeySize {
 		return nil, ErrKeySize
 	}
 	if m == Ascon80pq && len(key) != KeySize80pq {
 		return nil, ErrKeySize
 	}
-	if !(m == Ascon128 || m == Ascon128a || m == Ascon80pq) {
+	if !(m == Ascon128 || m == Ascon128a || m == Ascon80pq || m == AsconAEAD128) {
 		return nil, ErrMode
 	}
 	c := new(Cipher)
 	c.mode = m
-	if m == Ascon80pq {
+	if m == AsconAEAD128 {
+		c.key[1] = binary.LittleEndian.Uint64(key[0:8])
+		c.key[2] = binary.LittleEndian.Uint64(key[8:16])
+	} else if m == Ascon80pq {
 		c.key[0] = uint64(binary.BigEndian.Uint32(key[0:4]))
 		c.key[1] = binary.BigEndian.Uint64(key[4:12])
 		c.key[2] = binary.BigEndian.Uint64(key[12:20])
@@ -117,6 +142,14 @@ func (a *Cipher) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
 	ciphertext, tag := out[:ptLen], out[ptLen:]
 
 	var s [5]uint64
+	if a.mode == AsconAEAD128 {
+		a.initialize128(nonce, &s)
+		a.assocData128(additionalData, &s)
+		a.procText128(plaintext, ciphertext, true, &s)
+		a.finalize128(tag, &s)
+		return ret
+	}
+
 	a.initialize(nonce, &s)
 	a.assocData(additionalData, &s)
 	a.procText(plaintext, ciphertext, true, &s)
@@ -151,10 +184,17 @@ func (a *Cipher) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, er
 	tag1 := (&[TagSize]byte{})[:]
 
 	var s [5]uint64
-	a.initialize(nonce, &s)
-	a.assocData(additionalData, &s)
-	a.procText(ciphertext, plaintext, false, &s)
-	a.finalize(tag1, &s)
+	if a.mode == AsconAEAD128 {
+		a.initialize128(nonce, &s)
+		a.assocData128(additionalData, &s)
+		a.procText128(ciphertext, plaintext, false, &s)
+		a.finalize128(tag1, &s)
+	} else {
+		a.initialize(nonce, &s)
+		a.assocData(additionalData, &s)
+		a.procText(ciphertext, plaintext, false, &s)
+		a.finalize(tag1, &s)
+	}
 
 	if subtle.ConstantTimeCompare(tag0, tag1) == 0 {
 		return nil, ErrDecryption
@@ -176,17 +216,17 @@ func (a *Cipher) initialize(nonce []byte, s *[5]uint64) {
 	pB := uint64(a.permB())
 	kS := uint64(a.mode.KeySize())
 
-	s[0] = ((kS * 8) << 56) | ((bcs * 8) << 48) | (permA << 40) | (pB << 32) | a.key[0]
-	s[1] = a.key[1]
-	s[2] = a.key[2]
-	s[3] = binary.BigEndian.Uint64(nonce[0:8])
-	s[4] = binary.BigEndian.Uint64(nonce[8:16])
+	s[0] = toLane(((kS * 8) << 56) | ((bcs * 8) << 48) | (permA << 40) | (pB << 32) | a.key[0])
+	s[1] = toLane(a.key[1])
+	s[2] = toLane(a.key[2])
+	s[3] = toLane(binary.BigEndian.Uint64(nonce[0:8]))
+	s[4] = toLane(binary.BigEndian.Uint64(nonce[8:16]))
 
 	perm(permA, s)
 
-	s[2] ^= a.key[0]
-	s[3] ^= a.key[1]
-	s[4] ^= a.key[2]
+	s[2] ^= toLane(a.key[0])
+	s[3] ^= toLane(a.key[1])
+	s[4] ^= toLane(a.key[2])
 }
 
 func (a *Cipher) assocData(add []byte, s *[5]uint64) {
@@ -195,17 +235,20 @@ func (a *Cipher) assocData(add []byte, s *[5]uint64) {
 	if len(add) > 0 {
 		for ; len(add) >= bcs; add = add[bcs:] {
 			for i := 0; i < bcs; i += 8 {
-				s[i/8] ^= binary.BigEndian.Uint64(add[i : i+8])
+				s[i/8] ^= toLane(binary.BigEndian.Uint64(add[i : i+8]))
 			}
 			perm(pB, s)
 		}
+		var last [2]uint64
 		for i := 0; i < len(add); i++ {
-			s[i/8] ^= uint64(add[i]) << (56 - 8*(i%8))
+			last[i/8] ^= uint64(add[i]) << (56 - 8*(i%8))
 		}
-		s[len(add)/8] ^= uint64(0x80) << (56 - 8*(len(add)%8))
+		last[len(add)/8] ^= uint64(0x80) << (56 - 8*(len(add)%8))
+		s[0] ^= toLane(last[0])
+		s[1] ^= toLane(last[1])
 		perm(pB, s)
 	}
-	s[4] ^= 0x01
+	s[4] ^= toLane(0x01)
 }
 
 func (a *Cipher) procText(in, out []byte, enc bool, s *[5]uint64) {
@@ -219,78 +262,43 @@ func (a *Cipher) procText(in, out []byte, enc bool, s *[5]uint64) {
 	for ; len(in) >= bcs; in, out = in[bcs:], out[bcs:] {
 		for i := 0; i < bcs; i += 8 {
 			inW := binary.BigEndian.Uint64(in[i : i+8])
-			outW := s[i/8] ^ inW
+			outW := fromLane(s[i/8]) ^ inW
 			binary.BigEndian.PutUint64(out[i:i+8], outW)
 
-			s[i/8] = (inW &^ mask) | (outW & mask)
+			s[i/8] = toLane((inW &^ mask) | (outW & mask))
 		}
 		perm(pB, s)
 	}
 
 	mask8 := byte(mask & 0xFF)
+	last := [2]uint64{fromLane(s[0]), fromLane(s[1])}
 	for i := 0; i < len(in); i++ {
 		off := 56 - (8 * (i % 8))
-		si := byte((s[i/8] >> off) & 0xFF)
+		si := byte((last[i/8] >> off) & 0xFF)
 		inB := in[i]
 		outB := si ^ inB
 		out[i] = outB
 		ss := inB&^mask8 | outB&mask8
-		s[i/8] = (s[i/8] &^ (0xFF << off)) | uint64(ss)<<off
+		last[i/8] = (last[i/8] &^ (0xFF << off)) | uint64(ss)<<off
 	}
-	s[len(in)/8] ^= uint64(0x80) << (56 - 8*(len(in)%8))
+	last[len(in)/8] ^= uint64(0x80) << (56 - 8*(len(in)%8))
+	s[0], s[1] = toLane(last[0]), toLane(last[1])
 }
 
 func (a *Cipher) finalize(tag []byte, s *[5]uint64) {
 	bcs := a.blockSize()
 	if a.mode == Ascon80pq {
-		s[bcs/8+0] ^= a.key[0]<<32 | a.key[1]>>32
-		s[bcs/8+1] ^= a.key[1]<<32 | a.key[2]>>32
-		s[bcs/8+2] ^= a.key[2] << 32
+		s[bcs/8+0] ^= toLane(a.key[0]<<32 | a.key[1]>>32)
+		s[bcs/8+1] ^= toLane(a.key[1]<<32 | a.key[2]>>32)
+		s[bcs/8+2] ^= toLane(a.key[2] << 32)
 	} else {
-		s[bcs/8+0] ^= a.key[1]
-		s[bcs/8+1] ^= a.key[2]
+		s[bcs/8+0] ^= toLane(a.key[1])
+		s[bcs/8+1] ^= toLane(a.key[2])
 	}
 
 	perm(permA, s)
-	binary.BigEndian.PutUint64(tag[0:8], s[3]^a.key[1])
-	binary.BigEndian.PutUint64(tag[8:16], s[4]^a.key[2])
-}
-
-func perm(n int, s *[5]uint64) {
-	x0, x1, x2, x3, x4 := s[0], s[1], s[2], s[3], s[4]
-	for i := permA - n; i < permA; i++ {
-		// pC -- addition of constants
-		x2 ^= uint64((0xF-i)<<4 | i)
-
-		// pS -- substitution layer
-		// Figure 6 from Spec [DHVV18,Dae18]
-		// https://ascon.iaik.tugraz.at/files/asconv12-nist.pdf
-		x0 ^= x4
-		x4 ^= x3
-		x2 ^= x1
-		t0 := x0 & (^x4)
-		t1 := x2 & (^x1)
-		x0 ^= t1
-		t1 = x4 & (^x3)
-		x2 ^= t1
-		t1 = x1 & (^x0)
-		x4 ^= t1
-		t1 = x3 & (^x2)
-		x1 ^= t1
-		x3 ^= t0
-		x1 ^= x0
-		x3 ^= x2
-		x0 ^= x4
-		x2 = ^x2
-
-		// pL -- linear diffusion layer
-		x0 ^= bits.RotateLeft64(x0, -19) ^ bits.RotateLeft64(x0, -28)
-		x1 ^= bits.RotateLeft64(x1, -61) ^ bits.RotateLeft64(x1, -39)
-		x2 ^= bits.RotateLeft64(x2, -1) ^ bits.RotateLeft64(x2, -6)
-		x3 ^= bits.RotateLeft64(x3, -10) ^ bits.RotateLeft64(x3, -17)
-		x4 ^= bits.RotateLeft64(x4, -7) ^ bits.RotateLeft64(x4, -41)
-	}
-	s[0], s[1], s[2], s[3], s[4] = x0, x1, x2, x3, x4
+	binary.BigEndian.PutUint64(tag[0:8], fromLane(s[3])^a.key[1])
+	binary.BigEndian.PutUint64(tag[8:16], fromLane(s[4])^a.key[2])
 }
 
 // sliceForAppend takes a slice and a requested number of bytes. It returns a
@@ -313,4 +321,7 @@ var (
 	ErrNonceSize  = errors.New("ascon: bad nonce size")
 	ErrDecryption = errors.New("ascon: invalid ciphertext")
 	ErrMode       = errors.New("ascon: invalid cipher mode")
+
+	ErrCustomizationSize = errors.New("ascon: customization string too long")
+	ErrMessageSize       = errors.New("ascon: message too long")
 )
@@ -39,7 +39,7 @@ func (h *hex) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func readFile(t *testing.T, fileName string) []vector {
+func readFile(t testing.TB, fileName string) []vector {
 	jsonFile, err := os.Open(fileName)
 	if err != nil {
 		t.Fatalf("File %v can not be opened. Error: %v", fileName, err)
@@ -60,7 +60,10 @@ func readFile(t *testing.T, fileName string) []vector {
 func TestAscon(t *testing.T) {
 	// Test vectors generated with pyascon
 	// https://github.com/meichlseder/pyascon/
-	for _, mode := range []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.Ascon80pq} {
+	// The AsconAEAD128 ones follow the LWC_AEAD_KAT_128_128.txt file of the
+	// reference implementation.
+	// https://github.com/ascon/ascon-c
+	for _, mode := range []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.Ascon80pq, ascon.AsconAEAD128} {
 		name := mode.String()
 		t.Run(name, func(t *testing.T) {
 			vectors := readFile(t, "testdata/"+name+".json")
@@ -205,7 +208,7 @@ func TestAPI(t *testing.T) {
 }
 
 func BenchmarkAscon(b *testing.B) {
-	for _, mode := range []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.Ascon80pq} {
+	for _, mode := range []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.Ascon80pq, ascon.AsconAEAD128} {
 		for _, length := range []int{64, 1350, 8 * 1024} {
 			b.Run(mode.String()+"/Open-"+strconv.Itoa(length), func(b *testing.B) { benchmarkOpen(b, make([]byte, length), mode) })
 			b.Run(mode.String()+"/Seal-"+strconv.Itoa(length), func(b *testing.B) { benchmarkSeal(b, make([]byte, length), mode) })
@@ -219,7 +222,7 @@ func benchmarkSeal(b *testing.B, buf []byte, mode ascon.Mode) {
 
 	var key []byte
 	switch mode {
-	case ascon.Ascon128, ascon.Ascon128a:
+	case ascon.Ascon128, ascon.Ascon128a, ascon.AsconAEAD128:
 		key = make([]byte, ascon.KeySize)
 	case ascon.Ascon80pq:
 		key = make([]byte, ascon.KeySize80pq)
@@ -245,7 +248,7 @@ func benchmarkOpen(b *testing.B, buf []byte, mode ascon.Mode) {
 
 	var key []byte
 	switch mode {
-	case ascon.Ascon128, ascon.Ascon128a:
+	case ascon.Ascon128, ascon.Ascon128a, ascon.AsconAEAD128:
 		key = make([]byte, ascon.KeySize)
 	case ascon.Ascon80pq:
 		key = make([]byte, ascon.KeySize80pq)
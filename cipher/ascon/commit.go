@@ -0,0 +1,134 @@
+package ascon
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+)
+
+// CommitmentSize is the size of the key commitment appended by Committing
+// in bytes.
+const CommitmentSize = 32
+
+// commitCustomization is the customization string of the Ascon-CXOF128
+// instance deriving the keys and commitments of Committing.
+const commitCustomization = "Ascon committing AEAD"
+
+// Committing wraps Cipher to make it key committing: a ciphertext can only
+// be opened with the key, and the nonce, it was sealed with.
+//
+// For each nonce, Ascon-CXOF128 derives a fresh key for the underlying
+// Cipher and a commitment to the mode, the key and the nonce, which is
+// appended to the ciphertext:
+//
+//	K' || com = Ascon-CXOF128(uint8(len(mode)) || mode || key || nonce)
+//	ciphertext = Cipher(K').Seal(nonce, plaintext, ad) || com
+//
+// where mode is the name returned by Mode.String, so that the modes sharing
+// a key size derive different keys and commitments from the same key.
+//
+// Open checks the commitment before decrypting. Finding two keys under
+// which a ciphertext is valid requires a collision of the 256-bit output
+// of Ascon-XOF128, which gives 128 bits of security, whereas Cipher alone
+// offers no such guarantee. This is needed, for instance, when a message
+// is encrypted to several recipients who must all get the same plaintext.
+//
+// Confidentiality and authenticity are those of the underlying Cipher.
+// In particular, the nonce must still be unique for a given key.
+type Committing struct {
+	kdf  sponge // Ascon-CXOF128 after absorbing the mode and the key
+	mode Mode
+}
+
+var _ cipher.AEAD = (*Committing)(nil)
+
+// NewCommitting returns a key-committing AEAD using Cipher with mode m.
+// The key must be m.KeySize() bytes long.
+func NewCommitting(key []byte, m Mode) (*Committing, error) {
+	if _, err := New(key, m); err != nil {
+		return nil, err
+	}
+	x, err := NewCXOF128([]byte(commitCustomization))
+	if err != nil {
+		return nil, err
+	}
+	name := m.String()
+	_, _ = x.write([]byte{byte(len(name))})
+	_, _ = x.write([]byte(name))
+	_, _ = x.write(key)
+	return &Committing{kdf: x.sponge, mode: m}, nil
+}
+
+// NonceSize returns the size of the nonce that must be passed to Seal
+// and Open.
+func (c *Committing) NonceSize() int { return NonceSize }
+
+// Overhead returns the maximum difference between the lengths of a
+// plaintext and its ciphertext.
+func (c *Committing) Overhead() int { return TagSize + CommitmentSize }
+
+// derive returns the Cipher to use with nonce and writes the commitment
+// to com.
+func (c *Committing) derive(nonce, com []byte) *Cipher {
+	var key [KeySize80pq]byte
+	d := c.kdf
+	_, _ = d.write(nonce)
+	_, _ = d.read(key[:c.mode.KeySize()])
+	_, _ = d.read(com)
+
+	a, err := New(key[:c.mode.KeySize()], c.mode)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Seal encrypts and authenticates plaintext, authenticates the
+// additional data and appends the result to dst, returning the updated
+// slice. The nonce must be NonceSize() bytes long and unique for all
+// time, for a given key.
+//
+// To reuse plaintext's storage for the encrypted output, use plaintext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+func (c *Committing) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic(ErrNonceSize)
+	}
+
+	var com [CommitmentSize]byte
+	a := c.derive(nonce, com[:])
+
+	ctLen := len(plaintext) + TagSize
+	ret, out := sliceForAppend(dst, ctLen+CommitmentSize)
+	a.Seal(out[:0], nonce, plaintext, additionalData)
+	copy(out[ctLen:], com[:])
+	return ret
+}
+
+// Open checks the commitment, then decrypts and authenticates ciphertext,
+// authenticates the additional data and, if successful, appends the
+// resulting plaintext to dst, returning the updated slice. The nonce must
+// be NonceSize() bytes long and both it and the additional data must match
+// the value passed to Seal.
+//
+// To reuse ciphertext's storage for the decrypted output, use ciphertext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+//
+// Even if the function fails, the contents of dst, up to its capacity,
+// may be overwritten.
+func (c *Committing) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic(ErrNonceSize)
+	}
+	if len(ciphertext) < TagSize+CommitmentSize {
+		return nil, ErrDecryption
+	}
+
+	ctLen := len(ciphertext) - CommitmentSize
+	var com [CommitmentSize]byte
+	a := c.derive(nonce, com[:])
+	if subtle.ConstantTimeCompare(com[:], ciphertext[ctLen:]) == 0 {
+		return nil, ErrDecryption
+	}
+
+	return a.Open(dst, nonce, ciphertext[:ctLen], additionalData)
+}
@@ -0,0 +1,119 @@
+package ascon_test
+
+import (
+	"bytes"
+	"encoding"
+	"io"
+	"testing"
+
+	"github.com/karalef/circl/cipher/ascon"
+)
+
+var fuzzModes = []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.Ascon80pq, ascon.AsconAEAD128}
+
+// resize returns b truncated or padded with zeros to n bytes.
+func resize(b []byte, n int) []byte {
+	out := make([]byte, n)
+	copy(out, b)
+	return out
+}
+
+func FuzzOpen(f *testing.F) {
+	// Seed with the known-answer tests, including tampered ciphertexts.
+	for i, mode := range fuzzModes {
+		for j, v := range readFile(f, "testdata/"+mode.String()+".json") {
+			if j%37 != 0 {
+				continue
+			}
+			f.Add(uint8(i), []byte(v.Key), []byte(v.Nonce), []byte(v.CT), []byte(v.AD))
+			bad := append([]byte{}, v.CT...)
+			bad[j%len(bad)] ^= 1
+			f.Add(uint8(i), []byte(v.Key), []byte(v.Nonce), bad, []byte(v.AD))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, m uint8, key, nonce, ct, ad []byte) {
+		mode := fuzzModes[int(m)%len(fuzzModes)]
+		a, err := ascon.New(resize(key, mode.KeySize()), mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce = resize(nonce, ascon.NonceSize)
+
+		pt, err := a.Open(nil, nonce, ct, ad)
+		if len(ct) < ascon.TagSize && err == nil {
+			t.Fatalf("%s: ciphertext of %d bytes opened", mode, len(ct))
+		}
+		if err != nil {
+			return
+		}
+
+		// The only valid ciphertext of pt is ct.
+		if got := a.Seal(nil, nonce, pt, ad); !bytes.Equal(got, ct) {
+			t.Fatalf("%s: Open(%x) = %x, which seals to %x", mode, ct, pt, got)
+		}
+
+		// Open and Seal work in place.
+		buf := append([]byte{}, ct...)
+		got, err := a.Open(buf[:0], nonce, buf, ad)
+		if err != nil || !bytes.Equal(got, pt) {
+			t.Fatalf("%s: in-place Open differs", mode)
+		}
+	})
+}
+
+type marshalableHash interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	io.Writer
+}
+
+// fuzzHashes returns the instances whose states are fuzzed.
+func fuzzHashes() []marshalableHash {
+	z, _ := ascon.NewCXOF128([]byte("customization"))
+	return []marshalableHash{
+		ascon.NewHash(), ascon.NewHasha(), ascon.NewHash256(),
+		ascon.NewXOF(), ascon.NewXOFa(), ascon.NewXOF128(), z,
+	}
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	msg := make([]byte, 50)
+	out := make([]byte, 50)
+	for _, n := range []int{0, 7, 8, 50} {
+		for i, h := range fuzzHashes() {
+			_, _ = h.Write(msg[:n])
+			if x, ok := h.(*ascon.XOF); ok {
+				_, _ = x.Read(out[:n])
+			}
+			enc, _ := h.MarshalBinary()
+			f.Add(uint8(i), enc)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, i uint8, data []byte) {
+		all := fuzzHashes()
+		h := all[int(i)%len(all)]
+		if h.UnmarshalBinary(data) != nil {
+			return
+		}
+		enc, err := h.MarshalBinary()
+		if err != nil || !bytes.Equal(enc, data) {
+			t.Fatalf("UnmarshalBinary(%x) marshals to %x", data, enc)
+		}
+
+		// The restored state is usable.
+		switch h := h.(type) {
+		case *ascon.Hash:
+			_ = h.Sum(nil)
+			h.Reset()
+			_, _ = h.Write(msg)
+		case *ascon.XOF:
+			y := h.Clone()
+			_, _ = y.Read(out)
+			h.Reset()
+			_, _ = h.Write(msg)
+			_, _ = h.Read(out)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package ascon
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// HashSize is the size of the Ascon-Hash and Ascon-Hasha digests in bytes.
+const HashSize = 32
+
+// hashRate is the number of bytes absorbed or squeezed per permutation.
+const hashRate = 8
+
+// Initialization vectors of the hashing modes as specified in ASCON v1.2:
+// key size (0), rate in bits, permA rounds, permA-permB rounds and output
+// length in bits (0 for the XOFs).
+const (
+	ivHash  = 0x00400c0000000100
+	ivHasha = 0x00400c0400000100
+	ivXof   = 0x00400c0000000000
+	ivXofa  = 0x00400c0400000000
+)
+
+// sponge is the state shared by the hashing modes.
+type sponge struct {
+	s         [5]uint64
+	init      [5]uint64 // state after initialization, restored on Reset
+	buf       [hashRate]byte
+	n         int // bytes used in buf
+	pB        int // rounds between blocks: 12 for Hash and XOF, 8 for Hasha and XOFa
+	squeezing bool
+	le        bool // whether words are little-endian, as in SP 800-232
+}
+
+func newSponge(iv uint64) sponge {
+	var d sponge
+	d.init[0] = toLane(iv)
+	perm(permA, &d.init)
+	d.s = d.init
+	d.pB = permA - int(iv>>32)&0xff
+	return d
+}
+
+// newSponge232 initializes a sponge of one of the SP 800-232 functions,
+// which all use 12 rounds between blocks.
+func newSponge232(iv uint64) sponge {
+	var d sponge
+	d.init[0] = toLane(iv)
+	perm(permA, &d.init)
+	d.s = d.init
+	d.pB = permA
+	d.le = true
+	return d
+}
+
+func (d *sponge) load(b []byte) uint64 {
+	if d.le {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (d *sponge) store(b []byte, x uint64) {
+	if d.le {
+		binary.LittleEndian.PutUint64(b, x)
+	} else {
+		binary.BigEndian.PutUint64(b, x)
+	}
+}
+
+// shift returns the position of the i-th byte in a word.
+func (d *sponge) shift(i int) int {
+	if d.le {
+		return 8 * i
+	}
+	return 56 - 8*i
+}
+
+func (d *sponge) reset() {
+	d.s = d.init
+	d.n = 0
+	d.squeezing = false
+}
+
+func (d *sponge) write(p []byte) (int, error) {
+	if d.squeezing {
+		panic("ascon: write after read")
+	}
+	written := len(p)
+
+	if d.n > 0 {
+		k := copy(d.buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < hashRate {
+			return written, nil
+		}
+		d.s[0] ^= toLane(d.load(d.buf[:]))
+		perm(d.pB, &d.s)
+		d.n = 0
+	}
+
+	for ; len(p) >= hashRate; p = p[hashRate:] {
+		d.s[0] ^= toLane(d.load(p))
+		perm(d.pB, &d.s)
+	}
+
+	d.n = copy(d.buf[:], p)
+	return written, nil
+}
+
+// absorbLast absorbs the last, partial block with its padding.
+func (d *sponge) absorbLast() {
+	var last uint64
+	for i := 0; i < d.n; i++ {
+		last ^= uint64(d.buf[i]) << d.shift(i)
+	}
+	if d.le {
+		last ^= uint64(0x01) << d.shift(d.n)
+	} else {
+		last ^= uint64(0x80) << d.shift(d.n)
+	}
+	d.s[0] ^= toLane(last)
+	perm(permA, &d.s)
+	d.n = 0
+}
+
+// pad absorbs the last, partial block and switches to squeezing.
+func (d *sponge) pad() {
+	d.absorbLast()
+	d.store(d.buf[:], fromLane(d.s[0]))
+	d.squeezing = true
+}
+
+func (d *sponge) read(p []byte) (int, error) {
+	if !d.squeezing {
+		d.pad()
+	}
+	read := len(p)
+
+	for len(p) > 0 {
+		if d.n == hashRate {
+			perm(d.pB, &d.s)
+			d.store(d.buf[:], fromLane(d.s[0]))
+			d.n = 0
+		}
+		k := copy(p, d.buf[d.n:])
+		d.n += k
+		p = p[k:]
+	}
+
+	return read, nil
+}
+
+// Hash is an instance of Ascon-Hash, Ascon-Hasha or Ascon-Hash256.
+type Hash struct{ sponge }
+
+var _ hash.Hash = (*Hash)(nil)
+
+// NewHash returns a new Ascon-Hash instance.
+func NewHash() *Hash { return &Hash{newSponge(ivHash)} }
+
+// NewHasha returns a new Ascon-Hasha instance, which uses fewer rounds of
+// the permutation between blocks than Ascon-Hash.
+func NewHasha() *Hash { return &Hash{newSponge(ivHasha)} }
+
+// Write absorbs more data into the hash's state. It never returns an error.
+func (h *Hash) Write(p []byte) (int, error) { return h.write(p) }
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (h *Hash) Sum(b []byte) []byte {
+	d := h.sponge
+	ret, out := sliceForAppend(b, HashSize)
+	_, _ = d.read(out)
+	return ret
+}
+
+// Reset resets the hash to its initial state.
+func (h *Hash) Reset() { h.reset() }
+
+// Size returns the number of bytes Sum will append.
+func (h *Hash) Size() int { return HashSize }
+
+// BlockSize returns the rate of the sponge underlying the hash function.
+func (h *Hash) BlockSize() int { return hashRate }
+
+// Clone returns a copy of the hash in its current state.
+func (h *Hash) Clone() *Hash { c := *h; return &c }
+
+// XOF is an instance of Ascon-XOF, Ascon-XOFa, Ascon-XOF128 or
+// Ascon-CXOF128.
+type XOF struct{ sponge }
+
+// NewXOF returns a new Ascon-XOF instance.
+func NewXOF() *XOF { return &XOF{newSponge(ivXof)} }
+
+// NewXOFa returns a new Ascon-XOFa instance, which uses fewer rounds of
+// the permutation between blocks than Ascon-XOF.
+func NewXOFa() *XOF { return &XOF{newSponge(ivXofa)} }
+
+// Write absorbs more data into the XOF's state. It panics if called
+// after Read.
+func (x *XOF) Write(p []byte) (int, error) { return x.write(p) }
+
+// Read squeezes more output from the XOF. It never returns an error.
+func (x *XOF) Read(p []byte) (int, error) { return x.read(p) }
+
+// Reset restores the XOF to its initial state.
+func (x *XOF) Reset() { x.reset() }
+
+// Clone returns a copy of the XOF in its current state.
+func (x *XOF) Clone() *XOF { c := *x; return &c }
+
+// HashSum returns the Ascon-Hash digest of data.
+func HashSum(data []byte) [HashSize]byte {
+	var out [HashSize]byte
+	h := newSponge(ivHash)
+	_, _ = h.write(data)
+	_, _ = h.read(out[:])
+	return out
+}
@@ -0,0 +1,199 @@
+package ascon_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash"
+	"os"
+	"testing"
+
+	"github.com/karalef/circl/cipher/ascon"
+	"github.com/karalef/circl/internal/test"
+)
+
+type hashVector struct {
+	Count int `json:"Count"`
+	Msg   hex `json:"Msg"`
+	Z     hex `json:"Z"`
+	MD    hex `json:"MD"`
+}
+
+func readHashFile(t *testing.T, fileName string) []hashVector {
+	input, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("File %v can not be read. Error: %v", fileName, err)
+	}
+	var v []hashVector
+	err = json.Unmarshal(input, &v)
+	if err != nil {
+		t.Fatalf("File %v can not be loaded. Error: %v", fileName, err)
+	}
+	return v
+}
+
+func TestHash(t *testing.T) {
+	// Test vectors in the format of the LWC_HASH_KAT_256.txt files of the
+	// reference implementation.
+	// https://github.com/ascon/ascon-c
+	for _, v := range []struct {
+		name string
+		new  func() *ascon.Hash
+	}{
+		{"AsconHash", ascon.NewHash},
+		{"AsconHasha", ascon.NewHasha},
+		{"AsconHash256", ascon.NewHash256},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			for _, kat := range readHashFile(t, "testdata/"+v.name+".json") {
+				var h hash.Hash = v.new()
+				_, _ = h.Write(kat.Msg)
+				got := h.Sum(nil)
+				if !bytes.Equal(got, kat.MD) {
+					test.ReportError(t, got, kat.MD, v.name, kat.Count)
+				}
+
+				// Byte by byte.
+				h.Reset()
+				for i := range kat.Msg {
+					_, _ = h.Write(kat.Msg[i : i+1])
+				}
+				got = h.Sum(nil)
+				if !bytes.Equal(got, kat.MD) {
+					test.ReportError(t, got, kat.MD, v.name, kat.Count)
+				}
+			}
+		})
+	}
+}
+
+func TestXOF(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		new  func() *ascon.XOF
+	}{
+		{"AsconXof", ascon.NewXOF},
+		{"AsconXofa", ascon.NewXOFa},
+		{"AsconXOF128", ascon.NewXOF128},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			for _, kat := range readHashFile(t, "testdata/"+v.name+".json") {
+				x := v.new()
+				_, _ = x.Write(kat.Msg)
+				c := x.Clone()
+
+				got := make([]byte, len(kat.MD))
+				_, _ = x.Read(got)
+				if !bytes.Equal(got, kat.MD) {
+					test.ReportError(t, got, kat.MD, v.name, kat.Count)
+				}
+
+				// Squeezed in small pieces.
+				for i := 0; i < len(got); i += 3 {
+					end := i + 3
+					if end > len(got) {
+						end = len(got)
+					}
+					_, _ = c.Read(got[i:end])
+				}
+				if !bytes.Equal(got, kat.MD) {
+					test.ReportError(t, got, kat.MD, v.name, kat.Count)
+				}
+			}
+		})
+	}
+}
+
+func TestCXOF(t *testing.T) {
+	for _, kat := range readHashFile(t, "testdata/AsconCXOF128.json") {
+		x, err := ascon.NewCXOF128(kat.Z)
+		test.CheckNoErr(t, err, "failed to create CXOF")
+		for i := 0; i < 2; i++ {
+			_, _ = x.Write(kat.Msg)
+			got := make([]byte, len(kat.MD))
+			_, _ = x.Read(got)
+			if !bytes.Equal(got, kat.MD) {
+				test.ReportError(t, got, kat.MD, kat.Count)
+			}
+
+			// Reset keeps the customization string.
+			x.Reset()
+		}
+	}
+
+	_, err := ascon.NewCXOF128(make([]byte, ascon.MaxCustomizationSize+1))
+	test.CheckIsErr(t, err, "should fail due to long customization string")
+}
+
+func TestHashAPI(t *testing.T) {
+	msg := []byte("ascon")
+	h := ascon.NewHash()
+	_, _ = h.Write(msg)
+	sum := ascon.HashSum(msg)
+	test.CheckOk(bytes.Equal(h.Sum(nil), sum[:]), "HashSum differs", t)
+	test.CheckOk(h.Size() == ascon.HashSize, "bad size", t)
+	h = ascon.NewHash256()
+	_, _ = h.Write(msg)
+	sum = ascon.Hash256Sum(msg)
+	test.CheckOk(bytes.Equal(h.Sum(nil), sum[:]), "Hash256Sum differs", t)
+
+	x := ascon.NewXOF()
+	_, _ = x.Read(make([]byte, 1))
+	err := test.CheckPanic(func() { _, _ = x.Write(msg) })
+	test.CheckNoErr(t, err, "should panic due to Write after Read")
+
+	a, b := make([]byte, 100), make([]byte, 100)
+	x.Reset()
+	_, _ = x.Write(msg)
+	_, _ = x.Read(a)
+	y := ascon.NewXOF()
+	_, _ = y.Write(msg)
+	_, _ = y.Read(b)
+	test.CheckOk(bytes.Equal(a, b), "Reset failed", t)
+
+	hashes := []func() *ascon.Hash{ascon.NewHash, ascon.NewHasha, ascon.NewHash256}
+	for i, newHash := range hashes {
+		h := newHash()
+		_, _ = h.Write(msg)
+		enc, err := h.MarshalBinary()
+		test.CheckNoErr(t, err, "failed to marshal")
+		g := newHash()
+		err = g.UnmarshalBinary(enc)
+		test.CheckNoErr(t, err, "failed to unmarshal")
+		_, _ = h.Write(msg)
+		_, _ = g.Write(msg)
+		test.CheckOk(bytes.Equal(h.Sum(nil), g.Sum(nil)), "restored state differs", t)
+		err = g.UnmarshalBinary(enc[1:])
+		test.CheckIsErr(t, err, "should fail due to bad encoding")
+
+		// The state of another function is rejected.
+		for j, other := range hashes {
+			if i != j {
+				err = other().UnmarshalBinary(enc)
+				test.CheckIsErr(t, err, "should fail due to another function")
+			}
+		}
+		err = ascon.NewXOF().UnmarshalBinary(enc)
+		test.CheckIsErr(t, err, "should fail due to another function")
+	}
+
+	z1, _ := ascon.NewCXOF128([]byte("one"))
+	z2, _ := ascon.NewCXOF128([]byte("two"))
+	enc, _ := z1.MarshalBinary()
+	test.CheckNoErr(t, z1.Clone().UnmarshalBinary(enc), "failed to unmarshal")
+	err = z2.UnmarshalBinary(enc)
+	test.CheckIsErr(t, err, "should fail due to another customization string")
+	err = ascon.NewXOF128().UnmarshalBinary(enc)
+	test.CheckIsErr(t, err, "should fail due to another function")
+}
+
+func BenchmarkHash(b *testing.B) {
+	buf := make([]byte, 1024)
+	b.SetBytes(int64(len(buf)))
+	h := ascon.NewHash()
+	out := make([]byte, 0, ascon.HashSize)
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		_, _ = h.Write(buf)
+		out = h.Sum(out[:0])
+	}
+}
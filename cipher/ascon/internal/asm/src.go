@@ -0,0 +1,86 @@
+//go:generate go run src.go -out ../../perm_amd64.s -stubs ../../perm_stubs_amd64.go -pkg ascon
+
+// Scalar Ascon permutation for amd64 using the BMI1 and BMI2 extensions.
+
+package main
+
+import (
+	"fmt"
+
+	. "github.com/mmcloughlin/avo/build"   // nolint:stylecheck,golint
+	. "github.com/mmcloughlin/avo/operand" // nolint:stylecheck,golint
+	. "github.com/mmcloughlin/avo/reg"     // nolint:stylecheck,golint
+)
+
+const permA = 12
+
+func main() {
+	ConstraintExpr("amd64,!noasm")
+
+	TEXT("permAMD64", NOSPLIT, "func(n int, s *[5]uint64)")
+	Doc("permAMD64 applies the last n rounds of the Ascon permutation to s.", "It requires BMI1 and BMI2.")
+
+	Pragma("noescape")
+
+	sPtr := Load(Param("s"), GP64())
+	x := []GPVirtual{GP64(), GP64(), GP64(), GP64(), GP64()}
+	for i := range x {
+		MOVQ(Mem{Base: sPtr, Disp: 8 * i}, x[i])
+	}
+
+	// The rounds are fully unrolled; jump to the first one to compute.
+	start := GP64()
+	MOVQ(U32(permA), start)
+	SUBQ(Load(Param("n"), GP64()), start)
+	for i := 0; i < permA; i++ {
+		CMPQ(start, U32(i))
+		JEQ(LabelRef(fmt.Sprintf("round%d", i)))
+	}
+	JMP(LabelRef("done"))
+
+	t0, t1 := GP64(), GP64()
+	for i := 0; i < permA; i++ {
+		Label(fmt.Sprintf("round%d", i))
+
+		// pC -- addition of constants
+		XORQ(U32((0xF-i)<<4|i), x[2])
+
+		// pS -- substitution layer
+		XORQ(x[4], x[0])
+		XORQ(x[3], x[4])
+		XORQ(x[1], x[2])
+		andNot := func(a, b, dst GPVirtual) { ANDNQ(a, b, dst) } // dst = a & ^b
+		andNot(x[0], x[4], t0)
+		andNot(x[2], x[1], t1)
+		XORQ(t1, x[0])
+		andNot(x[4], x[3], t1)
+		XORQ(t1, x[2])
+		andNot(x[1], x[0], t1)
+		XORQ(t1, x[4])
+		andNot(x[3], x[2], t1)
+		XORQ(t1, x[1])
+		XORQ(t0, x[3])
+		XORQ(x[0], x[1])
+		XORQ(x[2], x[3])
+		XORQ(x[4], x[0])
+		NOTQ(x[2])
+
+		// pL -- linear diffusion layer, computing
+		// x ^ (x >>> r0) ^ (x >>> r1) as x ^ ((x ^ (x >>> (r1-r0))) >>> r0).
+		rot := [5][2]int{{19, 28}, {61, 39}, {1, 6}, {10, 17}, {7, 41}}
+		for j, r := range rot {
+			RORXQ(U8((r[1]-r[0]+64)%64), x[j], t0)
+			XORQ(x[j], t0)
+			RORQ(U8(r[0]), t0)
+			XORQ(t0, x[j])
+		}
+	}
+
+	Label("done")
+	for i := range x {
+		MOVQ(x[i], Mem{Base: sPtr, Disp: 8 * i})
+	}
+	RET()
+
+	Generate()
+}
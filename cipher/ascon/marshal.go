@@ -0,0 +1,110 @@
+package ascon
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The marshalled state of the hashing modes is
+//
+//	magic ‖ flags ‖ pB ‖ n ‖ s ‖ init ‖ buf
+//
+// where flags, pB and n are single bytes, and the words of s and init are
+// encoded in little-endian order. Bit 0 of flags is set when squeezing and
+// bit 1 for the SP 800-232 functions.
+const (
+	hashMagic = "ascon\x01"
+
+	// MarshaledSize is the size of the marshalled Hash and XOF.
+	MarshaledSize = len(hashMagic) + 3 + 2*5*8 + hashRate
+
+	flagSqueezing = 1 << 0
+	flagLE        = 1 << 1
+)
+
+var (
+	errInvalidState  = errors.New("ascon: invalid hash state")
+	errStateIdentity = errors.New("ascon: invalid hash state identifier")
+)
+
+func (d *sponge) appendBinary(b []byte) []byte {
+	var flags byte
+	if d.squeezing {
+		flags |= flagSqueezing
+	}
+	if d.le {
+		flags |= flagLE
+	}
+
+	b = append(b, hashMagic...)
+	b = append(b, flags, byte(d.pB), byte(d.n))
+	for i := range d.s {
+		b = binary.LittleEndian.AppendUint64(b, fromLane(d.s[i]))
+	}
+	for i := range d.init {
+		b = binary.LittleEndian.AppendUint64(b, fromLane(d.init[i]))
+	}
+	return append(b, d.buf[:]...)
+}
+
+func (d *sponge) unmarshalBinary(b []byte) error {
+	if len(b) < len(hashMagic) || string(b[:len(hashMagic)]) != hashMagic {
+		return errStateIdentity
+	}
+	if len(b) != MarshaledSize {
+		return errInvalidState
+	}
+	b = b[len(hashMagic):]
+
+	flags, pB, n := b[0], int(b[1]), int(b[2])
+	if flags&^(flagSqueezing|flagLE) != 0 || (pB != 8 && pB != permA) || n > hashRate {
+		return errInvalidState
+	}
+	if n == hashRate && flags&flagSqueezing == 0 {
+		return errInvalidState
+	}
+	b = b[3:]
+
+	// The initial state identifies the function, and its customization
+	// string for Ascon-CXOF128.
+	var init [5]uint64
+	for i := range init {
+		init[i] = toLane(binary.LittleEndian.Uint64(b[5*8+8*i:]))
+	}
+	if init != d.init || (flags&flagLE != 0) != d.le || pB != d.pB {
+		return errStateIdentity
+	}
+
+	d.squeezing = flags&flagSqueezing != 0
+	d.n = n
+	for i := range d.s {
+		d.s[i] = toLane(binary.LittleEndian.Uint64(b[8*i:]))
+	}
+	copy(d.buf[:], b[2*5*8:])
+	return nil
+}
+
+// MarshalBinary encodes the state, including buffered input.
+func (h *Hash) MarshalBinary() ([]byte, error) {
+	return h.AppendBinary(make([]byte, 0, MarshaledSize))
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (h *Hash) AppendBinary(b []byte) ([]byte, error) { return h.appendBinary(b), nil }
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary. It fails if the state is that of another function.
+func (h *Hash) UnmarshalBinary(b []byte) error { return h.unmarshalBinary(b) }
+
+// MarshalBinary encodes the state, including buffered input or output.
+func (x *XOF) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(make([]byte, 0, MarshaledSize))
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (x *XOF) AppendBinary(b []byte) ([]byte, error) { return x.appendBinary(b), nil }
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary. It fails if the state is that of another function, or of
+// Ascon-CXOF128 with another customization string.
+func (x *XOF) UnmarshalBinary(b []byte) error { return x.unmarshalBinary(b) }
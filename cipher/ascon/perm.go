@@ -0,0 +1,167 @@
+package ascon
+
+import "math/bits"
+
+// The permutation has three implementations: permGeneric, a direct
+// translation of the specification on 64-bit words; permInterleaved, which
+// works on bit-interleaved 32-bit words and is used on 32-bit ARM and MIPS;
+// and an assembly one on amd64, unless built with the noasm tag. The perm
+// function selected at build time applies the last n rounds of the
+// permutation to s.
+//
+// The words of the state are lanes, in the representation that perm works
+// on, so that they are not converted on every call. The modes convert
+// words to lanes with toLane when setting or absorbing into the state, and
+// back with fromLane when reading from it. Both are the identity, except
+// with permInterleaved.
+
+func permGeneric(n int, s *[5]uint64) {
+	x0, x1, x2, x3, x4 := s[0], s[1], s[2], s[3], s[4]
+	for i := permA - n; i < permA; i++ {
+		// pC -- addition of constants
+		x2 ^= uint64((0xF-i)<<4 | i)
+
+		// pS -- substitution layer
+		// Figure 6 from Spec [DHVV18,Dae18]
+		// https://ascon.iaik.tugraz.at/files/asconv12-nist.pdf
+		x0 ^= x4
+		x4 ^= x3
+		x2 ^= x1
+		t0 := x0 & (^x4)
+		t1 := x2 & (^x1)
+		x0 ^= t1
+		t1 = x4 & (^x3)
+		x2 ^= t1
+		t1 = x1 & (^x0)
+		x4 ^= t1
+		t1 = x3 & (^x2)
+		x1 ^= t1
+		x3 ^= t0
+		x1 ^= x0
+		x3 ^= x2
+		x0 ^= x4
+		x2 = ^x2
+
+		// pL -- linear diffusion layer
+		x0 ^= bits.RotateLeft64(x0, -19) ^ bits.RotateLeft64(x0, -28)
+		x1 ^= bits.RotateLeft64(x1, -61) ^ bits.RotateLeft64(x1, -39)
+		x2 ^= bits.RotateLeft64(x2, -1) ^ bits.RotateLeft64(x2, -6)
+		x3 ^= bits.RotateLeft64(x3, -10) ^ bits.RotateLeft64(x3, -17)
+		x4 ^= bits.RotateLeft64(x4, -7) ^ bits.RotateLeft64(x4, -41)
+	}
+	s[0], s[1], s[2], s[3], s[4] = x0, x1, x2, x3, x4
+}
+
+// roundConstants holds the round constants split into their even and odd
+// bits, as used by permInterleaved.
+var roundConstants = func() (rc [permA][2]uint32) {
+	for i := range rc {
+		rc[i][0], rc[i][1] = interleave(uint64((0xF-i)<<4 | i))
+	}
+	return
+}()
+
+// permInterleaved is like permGeneric, but on a state whose lanes hold the
+// even bits of a word in their lower half and the odd bits in their upper
+// half, as returned by interleavedLane. Then every 64-bit rotation becomes
+// two 32-bit ones, so that a round needs no carries between the halves.
+func permInterleaved(n int, s *[5]uint64) {
+	e0, o0 := uint32(s[0]), uint32(s[0]>>32)
+	e1, o1 := uint32(s[1]), uint32(s[1]>>32)
+	e2, o2 := uint32(s[2]), uint32(s[2]>>32)
+	e3, o3 := uint32(s[3]), uint32(s[3]>>32)
+	e4, o4 := uint32(s[4]), uint32(s[4]>>32)
+	for i := permA - n; i < permA; i++ {
+		// pC -- addition of constants
+		e2 ^= roundConstants[i][0]
+		o2 ^= roundConstants[i][1]
+
+		// pS -- substitution layer, which is bitwise so it is applied to
+		// both halves in the same way
+		e0, e1, e2, e3, e4 = sbox32(e0, e1, e2, e3, e4)
+		o0, o1, o2, o3, o4 = sbox32(o0, o1, o2, o3, o4)
+
+		// pL -- linear diffusion layer. A rotation of the 64-bit word by
+		// 2r bits rotates both halves by r bits, and a rotation by 2r+1
+		// bits also swaps them.
+		rot := bits.RotateLeft32
+		e0, o0 = e0^rot(o0, -9)^rot(e0, -14), o0^rot(e0, -10)^rot(o0, -14)
+		e1, o1 = e1^rot(o1, -30)^rot(o1, -19), o1^rot(e1, -31)^rot(e1, -20)
+		e2, o2 = e2^o2^rot(e2, -3), o2^rot(e2, -1)^rot(o2, -3)
+		e3, o3 = e3^rot(e3, -5)^rot(o3, -8), o3^rot(o3, -5)^rot(e3, -9)
+		e4, o4 = e4^rot(o4, -3)^rot(o4, -20), o4^rot(e4, -4)^rot(e4, -21)
+	}
+	s[0] = uint64(o0)<<32 | uint64(e0)
+	s[1] = uint64(o1)<<32 | uint64(e1)
+	s[2] = uint64(o2)<<32 | uint64(e2)
+	s[3] = uint64(o3)<<32 | uint64(e3)
+	s[4] = uint64(o4)<<32 | uint64(e4)
+}
+
+// interleavedLane returns the lane of permInterleaved holding x.
+func interleavedLane(x uint64) uint64 {
+	e, o := interleave(x)
+	return uint64(o)<<32 | uint64(e)
+}
+
+// interleavedWord is the inverse of interleavedLane.
+func interleavedWord(x uint64) uint64 { return deinterleave(uint32(x), uint32(x>>32)) }
+
+func sbox32(x0, x1, x2, x3, x4 uint32) (uint32, uint32, uint32, uint32, uint32) {
+	x0 ^= x4
+	x4 ^= x3
+	x2 ^= x1
+	t0 := x0 & (^x4)
+	t1 := x2 & (^x1)
+	x0 ^= t1
+	t1 = x4 & (^x3)
+	x2 ^= t1
+	t1 = x1 & (^x0)
+	x4 ^= t1
+	t1 = x3 & (^x2)
+	x1 ^= t1
+	x3 ^= t0
+	x1 ^= x0
+	x3 ^= x2
+	x0 ^= x4
+	x2 = ^x2
+	return x0, x1, x2, x3, x4
+}
+
+// interleave returns the even and the odd bits of x.
+func interleave(x uint64) (e, o uint32) {
+	lo, hi := unshuffle(uint32(x)), unshuffle(uint32(x>>32))
+	return lo&0xffff | hi<<16, lo>>16 | hi&0xffff0000
+}
+
+// deinterleave is the inverse of interleave.
+func deinterleave(e, o uint32) uint64 {
+	lo, hi := shuffle(e&0xffff|o<<16), shuffle(e>>16|o&0xffff0000)
+	return uint64(hi)<<32 | uint64(lo)
+}
+
+// unshuffle moves the even bits of x to its lower half and the odd bits to
+// its upper half.
+func unshuffle(x uint32) uint32 {
+	x = swapBits(x, 0x22222222, 1)
+	x = swapBits(x, 0x0c0c0c0c, 2)
+	x = swapBits(x, 0x00f000f0, 4)
+	x = swapBits(x, 0x0000ff00, 8)
+	return x
+}
+
+// shuffle is the inverse of unshuffle.
+func shuffle(x uint32) uint32 {
+	x = swapBits(x, 0x0000ff00, 8)
+	x = swapBits(x, 0x00f000f0, 4)
+	x = swapBits(x, 0x0c0c0c0c, 2)
+	x = swapBits(x, 0x22222222, 1)
+	return x
+}
+
+// swapBits swaps the bits of x selected by mask with the ones n positions
+// to their left.
+func swapBits(x, mask uint32, n uint) uint32 {
+	t := (x ^ (x >> n)) & mask
+	return x ^ t ^ (t << n)
+}
@@ -0,0 +1,14 @@
+//go:build arm || mips || mipsle
+// +build arm mips mipsle
+
+package ascon
+
+// On 32-bit ARM and MIPS the interleaved permutation avoids the 64-bit
+// rotations, which take four shifts and two ors each, and the state stays
+// interleaved between calls. On 386 it is not consistently faster than the
+// generic one, which is used instead.
+func perm(n int, s *[5]uint64) { permInterleaved(n, s) }
+
+func toLane(x uint64) uint64 { return interleavedLane(x) }
+
+func fromLane(x uint64) uint64 { return interleavedWord(x) }
@@ -0,0 +1,20 @@
+//go:build amd64 && !noasm
+// +build amd64,!noasm
+
+package ascon
+
+import "golang.org/x/sys/cpu"
+
+var hasBMI = cpu.X86.HasBMI1 && cpu.X86.HasBMI2
+
+func perm(n int, s *[5]uint64) {
+	if hasBMI {
+		permAMD64(n, s)
+	} else {
+		permGeneric(n, s)
+	}
+}
+
+func toLane(x uint64) uint64 { return x }
+
+func fromLane(x uint64) uint64 { return x }
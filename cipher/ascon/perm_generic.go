@@ -0,0 +1,13 @@
+//go:build (!amd64 || noasm) && !arm && !mips && !mipsle
+// +build !amd64 noasm
+// +build !arm
+// +build !mips
+// +build !mipsle
+
+package ascon
+
+func perm(n int, s *[5]uint64) { permGeneric(n, s) }
+
+func toLane(x uint64) uint64 { return x }
+
+func fromLane(x uint64) uint64 { return x }
@@ -0,0 +1,80 @@
+package ascon
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/karalef/circl/internal/test"
+)
+
+func randomState(t testing.TB) (s [5]uint64) {
+	var b [40]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		t.Fatal(err)
+	}
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+	return
+}
+
+func TestInterleave(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x := randomState(t)[0]
+		e, o := interleave(x)
+		for j := 0; j < 32; j++ {
+			test.CheckOk(uint64(e>>j&1) == x>>(2*j)&1, "bad even bit", t)
+			test.CheckOk(uint64(o>>j&1) == x>>(2*j+1)&1, "bad odd bit", t)
+		}
+		test.CheckOk(deinterleave(e, o) == x, "deinterleave(interleave(x)) != x", t)
+		test.CheckOk(interleavedWord(interleavedLane(x)) == x, "bad interleaved lane", t)
+		test.CheckOk(fromLane(toLane(x)) == x, "fromLane(toLane(x)) != x", t)
+	}
+}
+
+func TestPerm(t *testing.T) {
+	for n := 0; n <= permA; n++ {
+		for i := 0; i < 20; i++ {
+			want := randomState(t)
+			var got1, got2 [5]uint64
+			for j := range want {
+				got1[j], got2[j] = interleavedLane(want[j]), toLane(want[j])
+			}
+			permGeneric(n, &want)
+			permInterleaved(n, &got1)
+			perm(n, &got2)
+			for j := range want {
+				got1[j], got2[j] = interleavedWord(got1[j]), fromLane(got2[j])
+			}
+			if got1 != want {
+				test.ReportError(t, got1, want, "interleaved", n)
+			}
+			if got2 != want {
+				test.ReportError(t, got2, want, "perm", n)
+			}
+		}
+	}
+}
+
+func BenchmarkPerm(b *testing.B) {
+	impls := []struct {
+		name string
+		f    func(int, *[5]uint64)
+	}{
+		{"generic", permGeneric},
+		{"interleaved", permInterleaved},
+		{"selected", perm},
+	}
+	for _, n := range []int{permA, 8, 6} {
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("%s/%d", impl.name, n), func(b *testing.B) {
+				s := randomState(b)
+				for i := 0; i < b.N; i++ {
+					impl.f(n, &s)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package ascon
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// MacSize is the size of the Ascon-Mac and Ascon-PrfShort tags, and of the
+// output appended by Prf.Sum, in bytes.
+const MacSize = 16
+
+// MaxPrfShortSize is the maximum length of the messages of Ascon-PrfShort
+// in bytes.
+const MaxPrfShortSize = 16
+
+// prfInRate and prfOutRate are the number of bytes absorbed and squeezed
+// per permutation by Ascon-Prf and Ascon-Mac.
+const (
+	prfInRate  = 32
+	prfOutRate = 16
+)
+
+// Initialization vectors of Ascon-Prf and Ascon-Mac: key size, output rate
+// and permA rounds in bits, and output length in bits (0 for Ascon-Prf).
+const (
+	ivPrf = 0x80808c0000000000
+	ivMac = 0x80808c0000000080
+)
+
+// ivPrfShort is the initialization vector of Ascon-PrfShort, into which the
+// message length in bits is inserted as the second byte.
+const ivPrfShort = 0x80004c8000000000
+
+// prf is the keyed sponge of Ascon-Prf and Ascon-Mac, which absorbs into
+// the first four words of the state and squeezes from the first two.
+type prf struct {
+	s         [5]uint64
+	init      [5]uint64 // state after initialization, restored on reset
+	buf       [prfInRate]byte
+	n         int // bytes used in buf
+	squeezing bool
+}
+
+func newPrf(iv uint64, key *[2]uint64) prf {
+	var p prf
+	p.s[0] = toLane(iv)
+	p.s[1] = toLane(key[0])
+	p.s[2] = toLane(key[1])
+	perm(permA, &p.s)
+	p.init = p.s
+	return p
+}
+
+func (p *prf) reset() {
+	p.s = p.init
+	p.n = 0
+	p.squeezing = false
+}
+
+func (p *prf) write(b []byte) {
+	if p.squeezing {
+		panic("ascon: write after read")
+	}
+	if p.n > 0 {
+		k := copy(p.buf[p.n:], b)
+		p.n += k
+		b = b[k:]
+		if p.n < prfInRate {
+			return
+		}
+		p.absorb(p.buf[:])
+		p.n = 0
+	}
+	for ; len(b) >= prfInRate; b = b[prfInRate:] {
+		p.absorb(b)
+	}
+	p.n = copy(p.buf[:], b)
+}
+
+func (p *prf) absorb(b []byte) {
+	for i := 0; i < prfInRate/8; i++ {
+		p.s[i] ^= toLane(binary.BigEndian.Uint64(b[8*i:]))
+	}
+	perm(permA, &p.s)
+}
+
+// pad absorbs the last, partial block with its padding and the domain
+// separation bit, and switches to squeezing.
+func (p *prf) pad() {
+	var last [prfInRate / 8]uint64
+	for i := 0; i < p.n; i++ {
+		last[i/8] ^= uint64(p.buf[i]) << (56 - 8*(i%8))
+	}
+	last[p.n/8] ^= uint64(0x80) << (56 - 8*(p.n%8))
+	for i := range last {
+		p.s[i] ^= toLane(last[i])
+	}
+	p.s[4] ^= toLane(1)
+	perm(permA, &p.s)
+	p.store()
+	p.squeezing = true
+}
+
+func (p *prf) store() {
+	binary.BigEndian.PutUint64(p.buf[0:], fromLane(p.s[0]))
+	binary.BigEndian.PutUint64(p.buf[8:], fromLane(p.s[1]))
+	p.n = 0
+}
+
+func (p *prf) read(out []byte) {
+	if !p.squeezing {
+		p.pad()
+	}
+	for len(out) > 0 {
+		if p.n == prfOutRate {
+			perm(permA, &p.s)
+			p.store()
+		}
+		k := copy(out, p.buf[p.n:prfOutRate])
+		p.n += k
+		out = out[k:]
+	}
+}
+
+// loadKey128 loads a 16-byte key of Ascon-Prf or Ascon-Mac.
+func loadKey128(key []byte) (k [2]uint64) {
+	k[0] = binary.BigEndian.Uint64(key[0:8])
+	k[1] = binary.BigEndian.Uint64(key[8:16])
+	return
+}
+
+// Mac is an instance of Ascon-Mac, which computes tags of MacSize bytes.
+type Mac struct{ prf }
+
+var _ hash.Hash = (*Mac)(nil)
+
+// NewMac returns a new Ascon-Mac instance with the given key, which must be
+// KeySize bytes long.
+func NewMac(key []byte) (*Mac, error) {
+	if len(key) != KeySize {
+		return nil, ErrKeySize
+	}
+	k := loadKey128(key)
+	return &Mac{newPrf(ivMac, &k)}, nil
+}
+
+// Write absorbs more data into the state. It never returns an error.
+func (m *Mac) Write(p []byte) (int, error) { m.write(p); return len(p), nil }
+
+// Sum appends the tag to b and returns the resulting slice. It does not
+// change the underlying state.
+func (m *Mac) Sum(b []byte) []byte {
+	p := m.prf
+	ret, out := sliceForAppend(b, MacSize)
+	p.read(out)
+	return ret
+}
+
+// Reset restores the state right after setting the key.
+func (m *Mac) Reset() { m.reset() }
+
+// Size returns the number of bytes Sum will append.
+func (m *Mac) Size() int { return MacSize }
+
+// BlockSize returns the number of bytes absorbed per permutation.
+func (m *Mac) BlockSize() int { return prfInRate }
+
+// Clone returns a copy of the instance in its current state.
+func (m *Mac) Clone() *Mac { c := *m; return &c }
+
+// Prf is an instance of Ascon-Prf, a pseudorandom function with output of
+// arbitrary length, which can be squeezed with Read.
+type Prf struct{ prf }
+
+var _ hash.Hash = (*Prf)(nil)
+
+// NewPrf returns a new Ascon-Prf instance with the given key, which must be
+// KeySize bytes long.
+func NewPrf(key []byte) (*Prf, error) {
+	if len(key) != KeySize {
+		return nil, ErrKeySize
+	}
+	k := loadKey128(key)
+	return &Prf{newPrf(ivPrf, &k)}, nil
+}
+
+// Write absorbs more data into the state. It panics if called after Read.
+func (p *Prf) Write(b []byte) (int, error) { p.write(b); return len(b), nil }
+
+// Read squeezes more output from the state. It never returns an error.
+func (p *Prf) Read(out []byte) (int, error) { p.read(out); return len(out), nil }
+
+// Sum appends MacSize bytes of output to b and returns the resulting slice.
+// It does not change the underlying state. It panics if called after Read.
+func (p *Prf) Sum(b []byte) []byte {
+	if p.squeezing {
+		panic("ascon: Sum after Read")
+	}
+	c := p.prf
+	ret, out := sliceForAppend(b, MacSize)
+	c.read(out)
+	return ret
+}
+
+// Reset restores the state right after setting the key.
+func (p *Prf) Reset() { p.reset() }
+
+// Size returns the number of bytes Sum will append.
+func (p *Prf) Size() int { return MacSize }
+
+// BlockSize returns the number of bytes absorbed per permutation.
+func (p *Prf) BlockSize() int { return prfInRate }
+
+// Clone returns a copy of the instance in its current state.
+func (p *Prf) Clone() *Prf { c := *p; return &c }
+
+// PrfShort is an instance of Ascon-PrfShort, a pseudorandom function for
+// messages of at most MaxPrfShortSize bytes which only needs a single
+// permutation. Its tags are MacSize bytes long, so it can serve as a MAC
+// for short messages.
+type PrfShort struct {
+	key [2]uint64
+	buf [MaxPrfShortSize]byte
+	n   int
+}
+
+var _ hash.Hash = (*PrfShort)(nil)
+
+// NewPrfShort returns a new Ascon-PrfShort instance with the given key,
+// which must be KeySize bytes long.
+func NewPrfShort(key []byte) (*PrfShort, error) {
+	if len(key) != KeySize {
+		return nil, ErrKeySize
+	}
+	return &PrfShort{key: loadKey128(key)}, nil
+}
+
+// Write appends b to the message. It returns ErrMessageSize, and writes
+// nothing, if the message would exceed MaxPrfShortSize bytes.
+func (p *PrfShort) Write(b []byte) (int, error) {
+	if len(b) > MaxPrfShortSize-p.n {
+		return 0, ErrMessageSize
+	}
+	p.n += copy(p.buf[p.n:], b)
+	return len(b), nil
+}
+
+// Sum appends the tag to b and returns the resulting slice. It does not
+// change the underlying state.
+func (p *PrfShort) Sum(b []byte) []byte {
+	var s [5]uint64
+	s[0] = toLane(ivPrfShort | uint64(8*p.n)<<48)
+	s[1] = toLane(p.key[0])
+	s[2] = toLane(p.key[1])
+	s[3] = toLane(binary.BigEndian.Uint64(p.buf[0:8]))
+	s[4] = toLane(binary.BigEndian.Uint64(p.buf[8:16]))
+	perm(permA, &s)
+
+	ret, out := sliceForAppend(b, MacSize)
+	binary.BigEndian.PutUint64(out[0:8], fromLane(s[3])^p.key[0])
+	binary.BigEndian.PutUint64(out[8:16], fromLane(s[4])^p.key[1])
+	return ret
+}
+
+// Reset clears the message.
+func (p *PrfShort) Reset() {
+	p.buf = [MaxPrfShortSize]byte{}
+	p.n = 0
+}
+
+// Size returns the number of bytes Sum will append.
+func (p *PrfShort) Size() int { return MacSize }
+
+// BlockSize returns MaxPrfShortSize, the size of the only block.
+func (p *PrfShort) BlockSize() int { return MaxPrfShortSize }
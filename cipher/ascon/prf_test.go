@@ -0,0 +1,130 @@
+package ascon_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash"
+	"os"
+	"testing"
+
+	"github.com/karalef/circl/cipher/ascon"
+	"github.com/karalef/circl/internal/test"
+)
+
+type macVector struct {
+	Count int `json:"Count"`
+	Key   hex `json:"Key"`
+	Msg   hex `json:"Msg"`
+	Tag   hex `json:"Tag"`
+}
+
+func readMacFile(t *testing.T, fileName string) []macVector {
+	input, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("File %v can not be read. Error: %v", fileName, err)
+	}
+	var v []macVector
+	err = json.Unmarshal(input, &v)
+	if err != nil {
+		t.Fatalf("File %v can not be loaded. Error: %v", fileName, err)
+	}
+	return v
+}
+
+func TestMac(t *testing.T) {
+	// Test vectors in the format of the LWC_AUTH_KAT_128_128.txt files of
+	// the reference implementation. The Ascon-Prf ones hold 64 bytes of
+	// output.
+	// https://github.com/ascon/ascon-c
+	for _, v := range []struct {
+		name string
+		new  func([]byte) (hash.Hash, error)
+	}{
+		{"AsconMac", func(k []byte) (hash.Hash, error) { return ascon.NewMac(k) }},
+		{"AsconPrf", func(k []byte) (hash.Hash, error) { return ascon.NewPrf(k) }},
+		{"AsconPrfShort", func(k []byte) (hash.Hash, error) { return ascon.NewPrfShort(k) }},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			for _, kat := range readMacFile(t, "testdata/"+v.name+".json") {
+				h, err := v.new(kat.Key)
+				test.CheckNoErr(t, err, "failed to create instance")
+
+				// Byte by byte.
+				for i := range kat.Msg {
+					_, err = h.Write(kat.Msg[i : i+1])
+					test.CheckNoErr(t, err, "failed to write")
+				}
+				got := h.Sum(nil)
+				want := kat.Tag[:h.Size()]
+				if !bytes.Equal(got, want) {
+					test.ReportError(t, got, want, v.name, kat.Count)
+				}
+
+				if p, ok := h.(*ascon.Prf); ok {
+					got = make([]byte, len(kat.Tag))
+					for i := range got {
+						_, _ = p.Read(got[i : i+1])
+					}
+					if !bytes.Equal(got, kat.Tag) {
+						test.ReportError(t, got, kat.Tag, v.name, kat.Count)
+					}
+				}
+
+				h.Reset()
+				_, _ = h.Write(kat.Msg)
+				got = h.Sum(nil)
+				if !bytes.Equal(got, want) {
+					test.ReportError(t, got, want, v.name, kat.Count)
+				}
+			}
+		})
+	}
+}
+
+func TestMacAPI(t *testing.T) {
+	var key [ascon.KeySize]byte
+	_, err := ascon.NewMac(key[:8])
+	test.CheckIsErr(t, err, "should fail due to short key")
+	_, err = ascon.NewPrf(key[:8])
+	test.CheckIsErr(t, err, "should fail due to short key")
+	_, err = ascon.NewPrfShort(key[:8])
+	test.CheckIsErr(t, err, "should fail due to short key")
+
+	m, _ := ascon.NewMac(key[:])
+	_, _ = m.Write([]byte("hello"))
+	c := m.Clone()
+	tag := m.Sum(nil)
+	test.CheckOk(bytes.Equal(m.Sum(nil), tag), "Sum should not change the state", t)
+	_, _ = m.Write([]byte("world"))
+	_, _ = c.Write([]byte("world"))
+	test.CheckOk(bytes.Equal(m.Sum(nil), c.Sum(nil)), "clone should match", t)
+
+	p, _ := ascon.NewPrf(key[:])
+	_, _ = p.Read(tag)
+	err = test.CheckPanic(func() { _, _ = p.Write(nil) })
+	test.CheckNoErr(t, err, "should panic due to write after read")
+	err = test.CheckPanic(func() { _ = p.Sum(nil) })
+	test.CheckNoErr(t, err, "should panic due to sum after read")
+
+	s, _ := ascon.NewPrfShort(key[:])
+	_, err = s.Write(make([]byte, ascon.MaxPrfShortSize))
+	test.CheckNoErr(t, err, "failed to write")
+	tag = s.Sum(nil)
+	n, err := s.Write([]byte{0})
+	test.CheckIsErr(t, err, "should fail due to long message")
+	test.CheckOk(n == 0, "should not write", t)
+	test.CheckOk(bytes.Equal(s.Sum(nil), tag), "failed write should not change the state", t)
+}
+
+func BenchmarkMac(b *testing.B) {
+	var key [ascon.KeySize]byte
+	msg := make([]byte, 1024)
+	m, _ := ascon.NewMac(key[:])
+	var tag [ascon.MacSize]byte
+	b.SetBytes(int64(len(msg)))
+	for i := 0; i < b.N; i++ {
+		m.Reset()
+		_, _ = m.Write(msg)
+		m.Sum(tag[:0])
+	}
+}
@@ -0,0 +1,78 @@
+package ascon
+
+import (
+	"crypto/cipher"
+
+	circlcipher "github.com/karalef/circl/cipher"
+)
+
+type modeScheme Mode
+
+// Scheme returns the AEAD scheme of the mode. It panics if the mode is
+// invalid.
+func (m Mode) Scheme() circlcipher.AEADScheme {
+	_ = m.KeySize()
+	return modeScheme(m)
+}
+
+func (s modeScheme) Name() string   { return Mode(s).String() }
+func (s modeScheme) KeySize() int   { return Mode(s).KeySize() }
+func (s modeScheme) NonceSize() int { return NonceSize }
+func (s modeScheme) TagSize() int   { return TagSize }
+
+func (s modeScheme) New(key []byte) (cipher.AEAD, error) {
+	if len(key) != s.KeySize() {
+		return nil, circlcipher.ErrKeySize
+	}
+	c, err := New(key, Mode(s))
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type sivScheme struct{}
+
+// SIVScheme returns the AEAD scheme of SIV.
+func SIVScheme() circlcipher.AEADScheme { return sivScheme{} }
+
+func (sivScheme) Name() string   { return "Ascon-SIV" }
+func (sivScheme) KeySize() int   { return SIVKeySize }
+func (sivScheme) NonceSize() int { return NonceSize }
+func (sivScheme) TagSize() int   { return TagSize }
+
+func (sivScheme) New(key []byte) (cipher.AEAD, error) {
+	if len(key) != SIVKeySize {
+		return nil, circlcipher.ErrKeySize
+	}
+	s, err := NewSIV(key)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type committingScheme Mode
+
+// CommittingScheme returns the AEAD scheme of Committing with the mode. It
+// panics if the mode is invalid.
+func (m Mode) CommittingScheme() circlcipher.AEADScheme {
+	_ = m.KeySize()
+	return committingScheme(m)
+}
+
+func (s committingScheme) Name() string   { return Mode(s).String() + "-Committing" }
+func (s committingScheme) KeySize() int   { return Mode(s).KeySize() }
+func (s committingScheme) NonceSize() int { return NonceSize }
+func (s committingScheme) TagSize() int   { return TagSize + CommitmentSize }
+
+func (s committingScheme) New(key []byte) (cipher.AEAD, error) {
+	if len(key) != s.KeySize() {
+		return nil, circlcipher.ErrKeySize
+	}
+	c, err := NewCommitting(key, Mode(s))
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
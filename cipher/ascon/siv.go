@@ -0,0 +1,147 @@
+package ascon
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// SIVKeySize is the size of the keys of SIV in bytes.
+const SIVKeySize = 32
+
+// SIV is a deterministic authenticated encryption scheme built on Ascon-Mac
+// and Ascon-Prf following the SIV construction of Rogaway and Shrimpton.
+//
+// The first half of the key is used to compute a tag with Ascon-Mac over
+// the nonce, the additional data and the plaintext. The tag serves as the
+// synthetic IV from which Ascon-Prf, keyed with the second half of the key,
+// derives the keystream encrypting the plaintext:
+//
+//	T = Ascon-Mac(K1, nonce || uint64(len(ad)) || ad || plaintext)
+//	C = plaintext XOR Ascon-Prf(K2, T)
+//
+// and the ciphertext is C || T. The nonce is only one more input to the
+// tag, so reusing it does not break the scheme the way it breaks Cipher:
+// the only information leaked is whether two messages were encrypted with
+// the same nonce, additional data and plaintext. With unique nonces, equal
+// messages still produce different ciphertexts.
+//
+// SIV makes two passes over the plaintext, so it is not an online scheme:
+// Seal needs the whole plaintext before producing any output.
+type SIV struct {
+	mac [2]uint64
+	enc [2]uint64
+}
+
+var _ cipher.AEAD = (*SIV)(nil)
+
+// NewSIV returns an SIV instance with the given key, which must be
+// SIVKeySize bytes long.
+func NewSIV(key []byte) (*SIV, error) {
+	if len(key) != SIVKeySize {
+		return nil, ErrKeySize
+	}
+	return &SIV{
+		mac: loadKey128(key[:16]),
+		enc: loadKey128(key[16:]),
+	}, nil
+}
+
+// NonceSize returns the size of the nonce that must be passed to Seal
+// and Open.
+func (s *SIV) NonceSize() int { return NonceSize }
+
+// Overhead returns the maximum difference between the lengths of a
+// plaintext and its ciphertext.
+func (s *SIV) Overhead() int { return TagSize }
+
+// Seal encrypts and authenticates plaintext, authenticates the
+// additional data and appends the result to dst, returning the updated
+// slice. The nonce must be NonceSize() bytes long and should be unique
+// for a given key, although reusing it only reveals repeated messages.
+//
+// To reuse plaintext's storage for the encrypted output, use plaintext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+func (s *SIV) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic(ErrNonceSize)
+	}
+
+	var tag [TagSize]byte
+	s.tag(tag[:], nonce, plaintext, additionalData)
+
+	ptLen := len(plaintext)
+	ret, out := sliceForAppend(dst, ptLen+TagSize)
+	s.xorKeyStream(out[:ptLen], plaintext, tag[:])
+	copy(out[ptLen:], tag[:])
+	return ret
+}
+
+// Open decrypts and authenticates ciphertext, authenticates the
+// additional data and, if successful, appends the resulting plaintext
+// to dst, returning the updated slice. The nonce must be NonceSize()
+// bytes long and both it and the additional data must match the
+// value passed to Seal.
+//
+// To reuse ciphertext's storage for the decrypted output, use ciphertext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+//
+// Even if the function fails, the contents of dst, up to its capacity,
+// may be overwritten.
+func (s *SIV) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic(ErrNonceSize)
+	}
+	if len(ciphertext) < TagSize {
+		return nil, ErrDecryption
+	}
+
+	ptLen := len(ciphertext) - TagSize
+	var tag0, tag1 [TagSize]byte
+	copy(tag0[:], ciphertext[ptLen:])
+
+	ret, out := sliceForAppend(dst, ptLen)
+	s.xorKeyStream(out, ciphertext[:ptLen], tag0[:])
+	s.tag(tag1[:], nonce, out, additionalData)
+
+	if subtle.ConstantTimeCompare(tag0[:], tag1[:]) == 0 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, ErrDecryption
+	}
+
+	return ret, nil
+}
+
+// tag computes the synthetic IV of the message.
+func (s *SIV) tag(tag, nonce, plaintext, additionalData []byte) {
+	var adLen [8]byte
+	binary.BigEndian.PutUint64(adLen[:], uint64(len(additionalData)))
+
+	p := newPrf(ivMac, &s.mac)
+	p.write(nonce)
+	p.write(adLen[:])
+	p.write(additionalData)
+	p.write(plaintext)
+	p.read(tag)
+}
+
+// xorKeyStream XORs in with the keystream derived from tag into out.
+func (s *SIV) xorKeyStream(out, in, tag []byte) {
+	p := newPrf(ivPrf, &s.enc)
+	p.write(tag)
+
+	var ks [prfOutRate]byte
+	for len(in) > 0 {
+		n := len(ks)
+		if n > len(in) {
+			n = len(in)
+		}
+		p.read(ks[:n])
+		for i := 0; i < n; i++ {
+			out[i] = in[i] ^ ks[i]
+		}
+		in, out = in[n:], out[n:]
+	}
+}
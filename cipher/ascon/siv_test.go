@@ -0,0 +1,196 @@
+package ascon_test
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"testing"
+
+	"github.com/karalef/circl/cipher/ascon"
+	"github.com/karalef/circl/internal/test"
+)
+
+func testVectors(t *testing.T, name string, newAEAD func(key []byte) (cipher.AEAD, error)) {
+	// Test vectors generated with this package, in the format of the
+	// LWC_AEAD_KAT files. They are not independent: they only detect
+	// changes of the output, which is cross-checked against the primitives
+	// it is built on by TestSIVComposition and TestCommittingComposition.
+	vectors := readFile(t, "testdata/"+name+".json")
+	for _, v := range vectors {
+		aead, err := newAEAD(v.Key)
+		test.CheckNoErr(t, err, "failed to create cipher")
+
+		got := aead.Seal(nil, v.Nonce, v.PT, v.AD)
+		want := v.CT
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, name, v.Count)
+		}
+
+		got, err = aead.Open(nil, v.Nonce, v.CT, v.AD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = v.PT
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, name, v.Count)
+		}
+		test.CheckOk(len(v.PT)+aead.Overhead() == len(v.CT), "bad overhead size", t)
+	}
+}
+
+// testTamper checks that modifying any input makes Open fail, and that
+// the in-place variants of Seal and Open work.
+func testTamper(t *testing.T, aead cipher.AEAD) {
+	nonce := make([]byte, aead.NonceSize())
+	pt := []byte("the quick brown fox jumps over the lazy dog")
+	ad := []byte("additional data")
+	ct := aead.Seal(nil, nonce, pt, ad)
+
+	for i := range ct {
+		ct[i] ^= 0x01
+		_, err := aead.Open(nil, nonce, ct, ad)
+		test.CheckIsErr(t, err, "should fail due to bad ciphertext")
+		ct[i] ^= 0x01
+	}
+	nonce[0] ^= 0x01
+	_, err := aead.Open(nil, nonce, ct, ad)
+	test.CheckIsErr(t, err, "should fail due to bad nonce")
+	nonce[0] ^= 0x01
+	_, err = aead.Open(nil, nonce, ct, ad[1:])
+	test.CheckIsErr(t, err, "should fail due to bad additional data")
+	_, err = aead.Open(nil, nonce, ct[:aead.Overhead()-1], ad)
+	test.CheckIsErr(t, err, "should fail due to short ciphertext")
+
+	buf := make([]byte, len(pt), len(ct))
+	copy(buf, pt)
+	got := aead.Seal(buf[:0], nonce, buf, ad)
+	if !bytes.Equal(got, ct) {
+		test.ReportError(t, got, ct)
+	}
+	got, err = aead.Open(got[:0], nonce, got, ad)
+	test.CheckNoErr(t, err, "failed to open in place")
+	if !bytes.Equal(got, pt) {
+		test.ReportError(t, got, pt)
+	}
+}
+
+func TestSIV(t *testing.T) {
+	testVectors(t, "AsconSIV", func(key []byte) (cipher.AEAD, error) { return ascon.NewSIV(key) })
+
+	var key [ascon.SIVKeySize]byte
+	s, err := ascon.NewSIV(key[:])
+	test.CheckNoErr(t, err, "failed to create cipher")
+	testTamper(t, s)
+
+	_, err = ascon.NewSIV(key[:ascon.KeySize])
+	test.CheckIsErr(t, err, "should fail due to short key")
+
+	// Ciphertexts only repeat for the same nonce, data and plaintext.
+	var nonce [ascon.NonceSize]byte
+	pt := []byte("message")
+	ct0 := s.Seal(nil, nonce[:], pt, nil)
+	ct1 := s.Seal(nil, nonce[:], pt, nil)
+	test.CheckOk(bytes.Equal(ct0, ct1), "should be deterministic", t)
+	nonce[0] = 1
+	ct1 = s.Seal(nil, nonce[:], pt, nil)
+	test.CheckOk(!bytes.Equal(ct0[:len(pt)], ct1[:len(pt)]), "keystream should depend on the nonce", t)
+	ct1 = s.Seal(nil, nonce[:], []byte("massage"), nil)
+	test.CheckOk(!bytes.Equal(ct0[len(pt):], ct1[len(pt):]), "tag should depend on the plaintext", t)
+}
+
+func TestSIVComposition(t *testing.T) {
+	// SIV computed from Ascon-Mac and Ascon-Prf, whose outputs are checked
+	// against the reference KATs.
+	for _, v := range readFile(t, "testdata/AsconSIV.json") {
+		mac, err := ascon.NewMac(v.Key[:16])
+		test.CheckNoErr(t, err, "failed to create Mac")
+		var adLen [8]byte
+		binary.BigEndian.PutUint64(adLen[:], uint64(len(v.AD)))
+		_, _ = mac.Write(v.Nonce)
+		_, _ = mac.Write(adLen[:])
+		_, _ = mac.Write(v.AD)
+		_, _ = mac.Write(v.PT)
+		tag := mac.Sum(nil)
+
+		prf, err := ascon.NewPrf(v.Key[16:])
+		test.CheckNoErr(t, err, "failed to create Prf")
+		_, _ = prf.Write(tag)
+		want := make([]byte, len(v.PT))
+		_, _ = prf.Read(want)
+		for i := range want {
+			want[i] ^= v.PT[i]
+		}
+		want = append(want, tag...)
+		if !bytes.Equal(v.CT, want) {
+			test.ReportError(t, v.CT, want, v.Count)
+		}
+	}
+}
+
+func TestCommittingComposition(t *testing.T) {
+	// Committing computed from Ascon-CXOF128 and AsconAEAD128, whose
+	// outputs are checked against the reference KATs.
+	const mode = "AsconAEAD128"
+	for _, v := range readFile(t, "testdata/AsconCommitting.json") {
+		x, err := ascon.NewCXOF128([]byte("Ascon committing AEAD"))
+		test.CheckNoErr(t, err, "failed to create CXOF")
+		_, _ = x.Write([]byte{byte(len(mode))})
+		_, _ = x.Write([]byte(mode))
+		_, _ = x.Write(v.Key)
+		_, _ = x.Write(v.Nonce)
+		key := make([]byte, ascon.KeySize)
+		com := make([]byte, ascon.CommitmentSize)
+		_, _ = x.Read(key)
+		_, _ = x.Read(com)
+
+		a, err := ascon.New(key, ascon.AsconAEAD128)
+		test.CheckNoErr(t, err, "failed to create cipher")
+		want := append(a.Seal(nil, v.Nonce, v.PT, v.AD), com...)
+		if !bytes.Equal(v.CT, want) {
+			test.ReportError(t, v.CT, want, v.Count)
+		}
+	}
+}
+
+func TestCommitting(t *testing.T) {
+	testVectors(t, "AsconCommitting", func(key []byte) (cipher.AEAD, error) {
+		return ascon.NewCommitting(key, ascon.AsconAEAD128)
+	})
+
+	for _, mode := range []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.Ascon80pq, ascon.AsconAEAD128} {
+		t.Run(mode.String(), func(t *testing.T) {
+			key := make([]byte, mode.KeySize())
+			c, err := ascon.NewCommitting(key, mode)
+			test.CheckNoErr(t, err, "failed to create cipher")
+			testTamper(t, c)
+
+			// A ciphertext whose commitment is swapped for the one of
+			// another key must not open under that key.
+			key[0] = 1
+			c1, _ := ascon.NewCommitting(key, mode)
+			var nonce [ascon.NonceSize]byte
+			ct0 := c.Seal(nil, nonce[:], nil, nil)
+			ct1 := c1.Seal(nil, nonce[:], nil, nil)
+			copy(ct0[ascon.TagSize:], ct1[ascon.TagSize:])
+			_, err = c1.Open(nil, nonce[:], ct0, nil)
+			test.CheckIsErr(t, err, "should fail due to other key")
+		})
+	}
+
+	// The modes with the same key size commit to different keys.
+	key := make([]byte, ascon.KeySize)
+	var nonce [ascon.NonceSize]byte
+	seen := make(map[string]ascon.Mode)
+	for _, mode := range []ascon.Mode{ascon.Ascon128, ascon.Ascon128a, ascon.AsconAEAD128} {
+		c, _ := ascon.NewCommitting(key, mode)
+		ct := c.Seal(nil, nonce[:], nil, nil)
+		com := string(ct[ascon.TagSize:])
+		if other, ok := seen[com]; ok {
+			t.Errorf("%v and %v share the commitment", mode, other)
+		}
+		seen[com] = mode
+	}
+
+	_, err := ascon.NewCommitting(make([]byte, ascon.KeySize), ascon.Ascon80pq)
+	test.CheckIsErr(t, err, "should fail due to short key")
+}
@@ -0,0 +1,127 @@
+package ascon
+
+import "encoding/binary"
+
+// This file implements the functions of NIST SP 800-232. Compared to the
+// v1.2 ones, the words of the state are loaded in little-endian order, the
+// padding starts with a 0x01 byte, and for the AEAD the domain separation
+// bit is the most significant bit of the last word.
+
+// MaxCustomizationSize is the maximum length of the customization string of
+// Ascon-CXOF128 in bytes.
+const MaxCustomizationSize = 256
+
+const (
+	ivAEAD128 = 0x00001000808c0001
+	ivHash256 = 0x0000080100cc0002
+	ivXOF128  = 0x0000080000cc0003
+	ivCXOF128 = 0x0000080000cc0004
+
+	rateAEAD128  = 16
+	permBAEAD128 = 8
+)
+
+func (a *Cipher) initialize128(nonce []byte, s *[5]uint64) {
+	s[0] = toLane(ivAEAD128)
+	s[1] = toLane(a.key[1])
+	s[2] = toLane(a.key[2])
+	s[3] = toLane(binary.LittleEndian.Uint64(nonce[0:8]))
+	s[4] = toLane(binary.LittleEndian.Uint64(nonce[8:16]))
+
+	perm(permA, s)
+
+	s[3] ^= toLane(a.key[1])
+	s[4] ^= toLane(a.key[2])
+}
+
+func (a *Cipher) assocData128(add []byte, s *[5]uint64) {
+	if len(add) > 0 {
+		for ; len(add) >= rateAEAD128; add = add[rateAEAD128:] {
+			s[0] ^= toLane(binary.LittleEndian.Uint64(add[0:8]))
+			s[1] ^= toLane(binary.LittleEndian.Uint64(add[8:16]))
+			perm(permBAEAD128, s)
+		}
+		var last [2]uint64
+		for i := 0; i < len(add); i++ {
+			last[i/8] ^= uint64(add[i]) << (8 * (i % 8))
+		}
+		last[len(add)/8] ^= uint64(0x01) << (8 * (len(add) % 8))
+		s[0] ^= toLane(last[0])
+		s[1] ^= toLane(last[1])
+		perm(permBAEAD128, s)
+	}
+	s[4] ^= toLane(1 << 63)
+}
+
+func (a *Cipher) procText128(in, out []byte, enc bool, s *[5]uint64) {
+	mask := uint64(0)
+	if enc {
+		mask -= 1
+	}
+
+	for ; len(in) >= rateAEAD128; in, out = in[rateAEAD128:], out[rateAEAD128:] {
+		for i := 0; i < rateAEAD128; i += 8 {
+			inW := binary.LittleEndian.Uint64(in[i : i+8])
+			outW := fromLane(s[i/8]) ^ inW
+			binary.LittleEndian.PutUint64(out[i:i+8], outW)
+
+			s[i/8] = toLane((inW &^ mask) | (outW & mask))
+		}
+		perm(permBAEAD128, s)
+	}
+
+	mask8 := byte(mask & 0xFF)
+	last := [2]uint64{fromLane(s[0]), fromLane(s[1])}
+	for i := 0; i < len(in); i++ {
+		off := 8 * (i % 8)
+		si := byte((last[i/8] >> off) & 0xFF)
+		inB := in[i]
+		outB := si ^ inB
+		out[i] = outB
+		ss := inB&^mask8 | outB&mask8
+		last[i/8] = (last[i/8] &^ (0xFF << off)) | uint64(ss)<<off
+	}
+	last[len(in)/8] ^= uint64(0x01) << (8 * (len(in) % 8))
+	s[0], s[1] = toLane(last[0]), toLane(last[1])
+}
+
+func (a *Cipher) finalize128(tag []byte, s *[5]uint64) {
+	s[2] ^= toLane(a.key[1])
+	s[3] ^= toLane(a.key[2])
+
+	perm(permA, s)
+	binary.LittleEndian.PutUint64(tag[0:8], fromLane(s[3])^a.key[1])
+	binary.LittleEndian.PutUint64(tag[8:16], fromLane(s[4])^a.key[2])
+}
+
+// NewHash256 returns a new Ascon-Hash256 instance.
+func NewHash256() *Hash { return &Hash{newSponge232(ivHash256)} }
+
+// NewXOF128 returns a new Ascon-XOF128 instance.
+func NewXOF128() *XOF { return &XOF{newSponge232(ivXOF128)} }
+
+// NewCXOF128 returns a new Ascon-CXOF128 instance with customization string
+// z, which must be at most MaxCustomizationSize bytes long. Reset restores
+// the state right after absorbing z.
+func NewCXOF128(z []byte) (*XOF, error) {
+	if len(z) > MaxCustomizationSize {
+		return nil, ErrCustomizationSize
+	}
+	d := newSponge232(ivCXOF128)
+	var l [8]byte
+	binary.LittleEndian.PutUint64(l[:], uint64(len(z))*8)
+	_, _ = d.write(l[:])
+	_, _ = d.write(z)
+	d.absorbLast()
+	d.init = d.s
+	return &XOF{d}, nil
+}
+
+// Hash256Sum returns the Ascon-Hash256 digest of data.
+func Hash256Sum(data []byte) [HashSize]byte {
+	var out [HashSize]byte
+	h := newSponge232(ivHash256)
+	_, _ = h.write(data)
+	_, _ = h.read(out[:])
+	return out
+}
@@ -1,2 +1,8 @@
 // Package cipher provides data encryption algorithms.
+//
+// The AEADScheme interface describes an AEAD algorithm independently of any
+// key, so that protocols can pick one by name or by code point. A register
+// of schemes is available in the package
+//
+//	github.com/karalef/circl/cipher/schemes
 package cipher
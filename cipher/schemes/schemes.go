@@ -0,0 +1,156 @@
+// Package schemes contains a register of AEAD schemes.
+//
+// Besides the lookup by name, every scheme is registered together with the
+// code points assigned to it by the IANA AEAD registry of RFC 5116 and by
+// HPKE (RFC 9180), so that protocol code can negotiate algorithms from the
+// register instead of keeping its own tables.
+//
+// # Schemes Implemented
+//
+//	AES-128-GCM, AES-256-GCM
+//	ChaCha20-Poly1305, XChaCha20-Poly1305
+//	Ascon128, Ascon128a, Ascon80pq, AsconAEAD128, Ascon-SIV
+//	AsconAEAD128-Committing
+//	Xoodyak
+package schemes
+
+import (
+	"crypto/aes"
+	stdcipher "crypto/cipher"
+
+	"github.com/karalef/circl/cipher"
+	"github.com/karalef/circl/cipher/ascon"
+	"github.com/karalef/circl/cipher/xoodyak"
+	"github.com/karalef/circl/internal/registry"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Registry identifies a protocol registry of code points.
+type Registry int
+
+const (
+	// IANA AEAD algorithm numeric identifiers.
+	IANA Registry = iota + 1
+	// HPKE AEAD identifiers.
+	HPKE
+)
+
+// Info holds the metadata of a registered AEAD scheme.
+type Info struct {
+	cipher.AEADScheme
+
+	// IANA is the numeric identifier in the IANA AEAD registry, or 0 if
+	// none is assigned.
+	IANA uint16
+
+	// HPKE is the HPKE AEAD identifier, or 0 if none is assigned.
+	HPKE uint16
+}
+
+// CodePoint returns the code point of the scheme in the registry r and
+// whether one is assigned.
+func (i *Info) CodePoint(r Registry) (uint16, bool) {
+	switch r {
+	case IANA:
+		return i.IANA, i.IANA != 0
+	case HPKE:
+		return i.HPKE, i.HPKE != 0
+	default:
+		return 0, false
+	}
+}
+
+// stdScheme adapts an AEAD constructor of the standard library or of
+// golang.org/x/crypto.
+type stdScheme struct {
+	name    string
+	keySize int
+	nonce   int
+	tag     int
+	new     func(key []byte) (stdcipher.AEAD, error)
+}
+
+func (s *stdScheme) Name() string   { return s.name }
+func (s *stdScheme) KeySize() int   { return s.keySize }
+func (s *stdScheme) NonceSize() int { return s.nonce }
+func (s *stdScheme) TagSize() int   { return s.tag }
+
+func (s *stdScheme) New(key []byte) (stdcipher.AEAD, error) {
+	if len(key) != s.keySize {
+		return nil, cipher.ErrKeySize
+	}
+	return s.new(key)
+}
+
+func newGCM(key []byte) (stdcipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return stdcipher.NewGCM(b)
+}
+
+var (
+	aes128GCM = &stdScheme{"AES-128-GCM", 16, 12, 16, newGCM}
+	aes256GCM = &stdScheme{"AES-256-GCM", 32, 12, 16, newGCM}
+
+	chaCha20Poly1305 = &stdScheme{
+		"ChaCha20-Poly1305",
+		chacha20poly1305.KeySize,
+		chacha20poly1305.NonceSize,
+		chacha20poly1305.Overhead,
+		chacha20poly1305.New,
+	}
+	xChaCha20Poly1305 = &stdScheme{
+		"XChaCha20-Poly1305",
+		chacha20poly1305.KeySize,
+		chacha20poly1305.NonceSizeX,
+		chacha20poly1305.Overhead,
+		chacha20poly1305.NewX,
+	}
+)
+
+var allInfos = [...]Info{
+	{AEADScheme: aes128GCM, IANA: 1, HPKE: 0x0001},
+	{AEADScheme: aes256GCM, IANA: 2, HPKE: 0x0002},
+	{AEADScheme: chaCha20Poly1305, IANA: 29, HPKE: 0x0003},
+	{AEADScheme: xChaCha20Poly1305},
+	{AEADScheme: ascon.Ascon128.Scheme()},
+	{AEADScheme: ascon.Ascon128a.Scheme()},
+	{AEADScheme: ascon.Ascon80pq.Scheme()},
+	{AEADScheme: ascon.AsconAEAD128.Scheme()},
+	{AEADScheme: ascon.SIVScheme()},
+	{AEADScheme: ascon.AsconAEAD128.CommittingScheme()},
+	{AEADScheme: xoodyak.Scheme()},
+}
+
+var table = registry.New(allInfos[:],
+	func(i *Info) cipher.AEADScheme { return i.AEADScheme },
+	func(i *Info) Info { return *i },
+)
+
+// ByName returns the scheme with the given name and nil if it is not
+// supported.
+//
+// Names are case insensitive.
+func ByName(name string) cipher.AEADScheme { return table.ByName(name) }
+
+// ByCodePoint returns the scheme with the given code point in the registry r
+// and nil if it is not supported.
+func ByCodePoint(r Registry, cp uint16) cipher.AEADScheme {
+	return table.Find(func(i *Info) bool {
+		v, ok := i.CodePoint(r)
+		return ok && v == cp
+	})
+}
+
+// InfoOf returns a copy of the metadata of the scheme and nil if it is not
+// registered.
+func InfoOf(scheme cipher.AEADScheme) *Info { return table.InfoOf(scheme) }
+
+// Filter returns, in the order of All, the schemes whose metadata satisfies
+// the predicate, which is given a copy of the metadata.
+func Filter(pred func(*Info) bool) []cipher.AEADScheme { return table.Filter(pred) }
+
+// All returns all AEAD schemes supported.
+func All() []cipher.AEADScheme { return table.All() }
@@ -0,0 +1,91 @@
+package schemes_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/karalef/circl/cipher"
+	"github.com/karalef/circl/cipher/schemes"
+	"github.com/karalef/circl/internal/test"
+)
+
+func TestCaseSensitivity(t *testing.T) {
+	if schemes.ByName("aes-128-gcm") != schemes.ByName("AES-128-GCM") {
+		t.Fatal()
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		info := schemes.InfoOf(scheme)
+		if info == nil || info.AEADScheme != scheme {
+			t.Fatal(scheme.Name())
+		}
+		if schemes.ByName(scheme.Name()) != scheme {
+			t.Fatal(scheme.Name())
+		}
+		for _, r := range []schemes.Registry{schemes.IANA, schemes.HPKE} {
+			if cp, ok := info.CodePoint(r); ok && schemes.ByCodePoint(r, cp) != scheme {
+				t.Fatal(scheme.Name())
+			}
+		}
+	}
+
+	for _, v := range []struct {
+		id   uint16
+		name string
+	}{
+		{0x0001, "AES-128-GCM"},
+		{0x0002, "AES-256-GCM"},
+		{0x0003, "ChaCha20-Poly1305"},
+	} {
+		if schemes.ByCodePoint(schemes.HPKE, v.id) != schemes.ByName(v.name) {
+			t.Fatal(v.name)
+		}
+	}
+	if schemes.ByCodePoint(schemes.HPKE, 0) != nil {
+		t.Fatal()
+	}
+	if schemes.ByName("Kyber512") != nil {
+		t.Fatal()
+	}
+	if schemes.InfoOf(nil) != nil {
+		t.Fatal()
+	}
+
+	hpke := schemes.Filter(func(i *schemes.Info) bool { _, ok := i.CodePoint(schemes.HPKE); return ok })
+	if len(hpke) != 3 || hpke[0] != schemes.ByName("AES-128-GCM") {
+		t.Fatal(len(hpke))
+	}
+}
+
+func TestSchemes(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) {
+			_, err := scheme.New(make([]byte, scheme.KeySize()+1))
+			if err != cipher.ErrKeySize {
+				test.ReportError(t, err, cipher.ErrKeySize)
+			}
+
+			key := make([]byte, scheme.KeySize())
+			_, _ = rand.Read(key)
+			aead, err := scheme.New(key)
+			test.CheckNoErr(t, err, "failed to create AEAD")
+			test.CheckOk(aead.NonceSize() == scheme.NonceSize(), "bad nonce size", t)
+			test.CheckOk(aead.Overhead() == scheme.TagSize(), "bad tag size", t)
+
+			nonce := make([]byte, scheme.NonceSize())
+			pt := []byte("plaintext")
+			ad := []byte("additional data")
+			ct := aead.Seal(nil, nonce, pt, ad)
+			test.CheckOk(len(ct) == len(pt)+scheme.TagSize(), "bad ciphertext size", t)
+			got, err := aead.Open(nil, nonce, ct, ad)
+			test.CheckNoErr(t, err, "failed to open")
+			if !bytes.Equal(got, pt) {
+				test.ReportError(t, got, pt)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+// Package stream provides online authenticated encryption of arbitrarily
+// long messages on top of any AEAD.
+//
+// It implements the STREAM construction by Hoang, Reyhanitabar, Rogaway and
+// Vizár. The plaintext is split into chunks of a fixed size, and each chunk
+// is sealed independently with a nonce made of a prefix chosen by the user,
+// the index of the chunk, and a flag which is set only for the last chunk:
+//
+//	nonce = prefix || uint32(index) || flag
+//
+// The index prevents the reordering of chunks and the flag prevents the
+// truncation of the stream at a chunk boundary. The prefix is
+// NoncePrefixSize(aead) bytes long and must be unique for every stream
+// encrypted with a given key.
+//
+// https://eprint.iacr.org/2015/189
+package stream
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/karalef/circl/cipher/ascon"
+)
+
+// DefaultChunkSize is the size of the plaintext chunks used by NewWriter and
+// NewReader.
+const DefaultChunkSize = 64 << 10
+
+// nonceSuffixSize is the size of the chunk index and the last-chunk flag.
+const nonceSuffixSize = 5
+
+var (
+	ErrNonceSize  = errors.New("stream: bad nonce prefix size")
+	ErrChunkSize  = errors.New("stream: bad chunk size")
+	ErrTooLarge   = errors.New("stream: too many chunks")
+	ErrClosed     = errors.New("stream: write to closed writer")
+	ErrTruncated  = errors.New("stream: truncated stream")
+	ErrDecryption = errors.New("stream: invalid ciphertext")
+)
+
+// NoncePrefixSize returns the size of the nonce prefix for the given AEAD.
+func NoncePrefixSize(aead cipher.AEAD) int { return aead.NonceSize() - nonceSuffixSize }
+
+// state holds what is shared by the Writer and the Reader.
+type state struct {
+	aead  cipher.AEAD
+	nonce []byte
+	ad    []byte
+	index uint64
+	chunk int // plaintext chunk size
+}
+
+func newState(aead cipher.AEAD, prefix, ad []byte, chunkSize int) (state, error) {
+	if aead.NonceSize() < nonceSuffixSize || len(prefix) != NoncePrefixSize(aead) {
+		return state{}, ErrNonceSize
+	}
+	if chunkSize <= 0 {
+		return state{}, ErrChunkSize
+	}
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, prefix)
+	return state{
+		aead:  aead,
+		nonce: nonce,
+		ad:    append([]byte(nil), ad...),
+		chunk: chunkSize,
+	}, nil
+}
+
+// next returns the nonce of the next chunk.
+func (s *state) next(last bool) ([]byte, error) {
+	if s.index > math.MaxUint32 {
+		return nil, ErrTooLarge
+	}
+	n := len(s.nonce)
+	binary.BigEndian.PutUint32(s.nonce[n-nonceSuffixSize:], uint32(s.index))
+	s.nonce[n-1] = 0
+	if last {
+		s.nonce[n-1] = 1
+	}
+	return s.nonce, nil
+}
+
+// Writer encrypts the data written to it.
+type Writer struct {
+	state
+	w   io.Writer
+	buf []byte // pending plaintext, up to one chunk
+	out []byte // sealed chunk
+	err error
+}
+
+// NewWriter returns a Writer encrypting to w with the given AEAD, nonce
+// prefix and additional data, using chunks of DefaultChunkSize bytes.
+func NewWriter(w io.Writer, aead cipher.AEAD, prefix, ad []byte) (*Writer, error) {
+	return NewWriterSize(w, aead, prefix, ad, DefaultChunkSize)
+}
+
+// NewWriterSize is like NewWriter but uses chunks of chunkSize bytes. The
+// Reader must use the same chunk size.
+func NewWriterSize(w io.Writer, aead cipher.AEAD, prefix, ad []byte, chunkSize int) (*Writer, error) {
+	s, err := newState(aead, prefix, ad, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		state: s,
+		w:     w,
+		buf:   make([]byte, 0, chunkSize),
+		out:   make([]byte, 0, chunkSize+aead.Overhead()),
+	}, nil
+}
+
+// Write encrypts p and writes it to the underlying writer as soon as a full
+// chunk is available. The last chunk is only written by Close.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data arrives, as it
+		// could be the last one.
+		if len(w.buf) == w.chunk {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):w.chunk], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close encrypts and writes the last chunk. It does not close the
+// underlying writer. The stream is not valid until Close returns
+// successfully.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		if w.err == ErrClosed {
+			return nil
+		}
+		return w.err
+	}
+	if err := w.flush(true); err != nil {
+		return err
+	}
+	w.err = ErrClosed
+	return nil
+}
+
+func (w *Writer) flush(last bool) error {
+	nonce, err := w.next(last)
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.out = w.aead.Seal(w.out[:0], nonce, w.buf, w.ad)
+	if _, err := w.w.Write(w.out); err != nil {
+		w.err = err
+		return err
+	}
+	w.index++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Reader decrypts the data read from an underlying reader.
+type Reader struct {
+	state
+	r    io.Reader
+	in   []byte // sealed chunk, plus one byte of lookahead
+	pt   []byte // plaintext of the current chunk
+	buf  []byte // plaintext not read yet
+	done bool   // whether the last chunk has been decrypted
+	err  error
+}
+
+// NewReader returns a Reader decrypting from r with the given AEAD, nonce
+// prefix and additional data, using chunks of DefaultChunkSize bytes.
+func NewReader(r io.Reader, aead cipher.AEAD, prefix, ad []byte) (*Reader, error) {
+	return NewReaderSize(r, aead, prefix, ad, DefaultChunkSize)
+}
+
+// NewReaderSize is like NewReader but uses chunks of chunkSize bytes, which
+// must be the size used by the Writer.
+func NewReaderSize(r io.Reader, aead cipher.AEAD, prefix, ad []byte, chunkSize int) (*Reader, error) {
+	s, err := newState(aead, prefix, ad, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		state: s,
+		r:     r,
+		in:    make([]byte, 0, chunkSize+aead.Overhead()+1),
+		pt:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+// Read reads decrypted data into p. It returns io.EOF only once the whole
+// stream has been authenticated. Data is never returned before the chunk
+// containing it has been authenticated.
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			r.err = err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *Reader) readChunk() error {
+	encSize := r.chunk + r.aead.Overhead()
+
+	// Read the chunk plus one byte, which tells whether it is the last one.
+	n, err := io.ReadFull(r.r, r.in[len(r.in):encSize+1])
+	r.in = r.in[:len(r.in)+n]
+	last := false
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		last = true
+	default:
+		return err
+	}
+
+	in := r.in
+	if !last {
+		in = in[:encSize]
+	}
+	if len(in) < r.aead.Overhead() {
+		return ErrTruncated
+	}
+
+	nonce, err := r.next(last)
+	if err != nil {
+		return err
+	}
+	buf, err := r.aead.Open(r.pt[:0], nonce, in, r.ad)
+	if err != nil {
+		if last {
+			// Tell apart a stream cut at a chunk boundary from a
+			// corrupted one.
+			nonce, _ = r.next(false)
+			if _, err := r.aead.Open(r.pt[:0], nonce, in, r.ad); err == nil {
+				return ErrTruncated
+			}
+		}
+		return ErrDecryption
+	}
+	if last && len(buf) == 0 && r.index > 0 {
+		// The Writer only produces an empty last chunk for an empty
+		// stream.
+		return ErrDecryption
+	}
+
+	r.buf = buf
+	r.index++
+	r.done = last
+	if !last {
+		// Keep the lookahead byte at the start of the buffer.
+		r.in = append(r.in[:0], r.in[encSize])
+	}
+	return nil
+}
+
+// NewAsconWriter returns a Writer encrypting to w with the AsconAEAD128
+// mode of package ascon and chunks of DefaultChunkSize bytes.
+func NewAsconWriter(w io.Writer, key, prefix, ad []byte) (*Writer, error) {
+	aead, err := ascon.New(key, ascon.AsconAEAD128)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriter(w, aead, prefix, ad)
+}
+
+// NewAsconReader returns a Reader decrypting a stream produced by
+// NewAsconWriter.
+func NewAsconReader(r io.Reader, key, prefix, ad []byte) (*Reader, error) {
+	aead, err := ascon.New(key, ascon.AsconAEAD128)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(r, aead, prefix, ad)
+}
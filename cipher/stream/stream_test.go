@@ -0,0 +1,177 @@
+package stream_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/karalef/circl/cipher/ascon"
+	"github.com/karalef/circl/cipher/stream"
+	"github.com/karalef/circl/internal/test"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const chunkSize = 64
+
+func aeads(t *testing.T) map[string]cipher.AEAD {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	a, err := ascon.New(key[:ascon.KeySize], ascon.AsconAEAD128)
+	test.CheckNoErr(t, err, "failed to create Ascon")
+	b, err := aes.NewCipher(key)
+	test.CheckNoErr(t, err, "failed to create AES")
+	gcm, err := cipher.NewGCM(b)
+	test.CheckNoErr(t, err, "failed to create GCM")
+	c, err := chacha20poly1305.NewX(key)
+	test.CheckNoErr(t, err, "failed to create XChaCha20-Poly1305")
+	return map[string]cipher.AEAD{"Ascon": a, "AES-GCM": gcm, "XChaCha20-Poly1305": c}
+}
+
+func seal(t *testing.T, aead cipher.AEAD, msg []byte, writeSize int) []byte {
+	var buf bytes.Buffer
+	prefix := make([]byte, stream.NoncePrefixSize(aead))
+	w, err := stream.NewWriterSize(&buf, aead, prefix, []byte("ad"), chunkSize)
+	test.CheckNoErr(t, err, "failed to create writer")
+	for p := msg; len(p) > 0; {
+		n := writeSize
+		if n > len(p) {
+			n = len(p)
+		}
+		_, err = w.Write(p[:n])
+		test.CheckNoErr(t, err, "write failed")
+		p = p[n:]
+	}
+	test.CheckNoErr(t, w.Close(), "close failed")
+	return buf.Bytes()
+}
+
+func open(aead cipher.AEAD, ct []byte, readSize int) ([]byte, error) {
+	prefix := make([]byte, stream.NoncePrefixSize(aead))
+	r, err := stream.NewReaderSize(bytes.NewReader(ct), aead, prefix, []byte("ad"), chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	var out []byte
+	buf := make([]byte, readSize)
+	for {
+		n, err := r.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	msg := make([]byte, 5*chunkSize+1)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	for name, aead := range aeads(t) {
+		for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 2 * chunkSize, 5*chunkSize + 1} {
+			for _, ioSize := range []int{1, 7, chunkSize, 1000} {
+				ct := seal(t, aead, msg[:size], ioSize)
+
+				chunks := (size + chunkSize - 1) / chunkSize
+				if chunks == 0 {
+					chunks = 1
+				}
+				test.CheckOk(len(ct) == size+chunks*aead.Overhead(), "bad ciphertext size", t)
+
+				got, err := open(aead, ct, ioSize)
+				test.CheckNoErr(t, err, "open failed")
+				if !bytes.Equal(got, msg[:size]) {
+					test.ReportError(t, got, msg[:size], name, size, ioSize)
+				}
+			}
+		}
+	}
+}
+
+func TestTampering(t *testing.T) {
+	msg := make([]byte, 3*chunkSize)
+	for name, aead := range aeads(t) {
+		ct := seal(t, aead, msg, len(msg))
+		enc := chunkSize + aead.Overhead()
+
+		check := func(ct []byte, want error, what string) {
+			t.Helper()
+			_, err := open(aead, ct, len(msg))
+			if !errors.Is(err, want) {
+				test.ReportError(t, err, want, name, what)
+			}
+		}
+
+		check(ct[:2*enc], stream.ErrTruncated, "dropped last chunk")
+		check(ct[:enc], stream.ErrTruncated, "dropped two chunks")
+		check(nil, stream.ErrTruncated, "empty stream")
+		check(ct[:len(ct)-1], stream.ErrDecryption, "cut last chunk")
+		check(append(ct[:len(ct):len(ct)], 0), stream.ErrDecryption, "trailing data")
+
+		swapped := append([]byte(nil), ct[enc:2*enc]...)
+		swapped = append(swapped, ct[:enc]...)
+		swapped = append(swapped, ct[2*enc:]...)
+		check(swapped, stream.ErrDecryption, "reordered chunks")
+
+		flipped := append([]byte(nil), ct...)
+		flipped[enc+3] ^= 1
+		check(flipped, stream.ErrDecryption, "modified chunk")
+
+		// No plaintext of a bad chunk is released.
+		got, _ := open(aead, flipped, len(msg))
+		test.CheckOk(len(got) == chunkSize, "released unauthenticated data", t)
+	}
+}
+
+func TestBadInputs(t *testing.T) {
+	aead := aeads(t)["Ascon"]
+	_, err := stream.NewWriter(io.Discard, aead, nil, nil)
+	test.CheckIsErr(t, err, "should fail due to bad prefix size")
+	_, err = stream.NewReaderSize(bytes.NewReader(nil), aead, make([]byte, 11), nil, 0)
+	test.CheckIsErr(t, err, "should fail due to bad chunk size")
+	_, err = stream.NewAsconWriter(io.Discard, nil, nil, nil)
+	test.CheckIsErr(t, err, "should fail due to bad key")
+
+	w, _ := stream.NewWriter(io.Discard, aead, make([]byte, 11), nil)
+	test.CheckNoErr(t, w.Close(), "close failed")
+	test.CheckNoErr(t, w.Close(), "second close failed")
+	_, err = w.Write([]byte{0})
+	test.CheckIsErr(t, err, "should fail due to write after close")
+
+	// An empty last chunk after a full one, which the Writer never
+	// produces, is rejected.
+	nonce := make([]byte, aead.NonceSize())
+	ct := aead.Seal(nil, nonce, make([]byte, chunkSize), nil)
+	nonce[len(nonce)-2] = 1 // index
+	nonce[len(nonce)-1] = 1 // last chunk
+	ct = aead.Seal(ct, nonce, nil, nil)
+	r, _ := stream.NewReaderSize(bytes.NewReader(ct), aead, make([]byte, 11), nil, chunkSize)
+	_, err = io.ReadAll(r)
+	test.CheckIsErr(t, err, "should fail due to empty last chunk")
+}
+
+func Example() {
+	key := make([]byte, ascon.KeySize)
+	prefix := make([]byte, 11) // must be unique for each stream
+	msg := bytes.Repeat([]byte("large payload "), 10000)
+
+	var ct bytes.Buffer
+	w, _ := stream.NewAsconWriter(&ct, key, prefix, nil)
+	_, _ = io.Copy(w, bytes.NewReader(msg))
+	_ = w.Close()
+
+	r, _ := stream.NewAsconReader(&ct, key, prefix, nil)
+	pt, err := io.ReadAll(r)
+	fmt.Println(bytes.Equal(pt, msg), err)
+	// Output: true <nil>
+}
@@ -0,0 +1,150 @@
+package xoodyak
+
+import "encoding/binary"
+
+// Rates of the Cyclist mode in bytes.
+const (
+	rateHash   = 16
+	rateKeyIn  = 44
+	rateKeyOut = 24
+)
+
+// Colors of the Cyclist mode, which provide domain separation.
+const (
+	colorAbsorbKey = 0x02
+	colorAbsorb    = 0x03
+	colorSqueeze   = 0x40
+	colorCrypt     = 0x80
+)
+
+// cyclist is the Cyclist mode of operation on top of Xoodoo.
+type cyclist struct {
+	a        [12]uint32
+	down     bool // whether the last call was to Down
+	keyed    bool
+	rAbsorb  int
+	rSqueeze int
+}
+
+func newHashCyclist() cyclist {
+	return cyclist{rAbsorb: rateHash, rSqueeze: rateHash}
+}
+
+func newKeyedCyclist(key []byte) cyclist {
+	c := cyclist{keyed: true, rAbsorb: rateKeyIn, rSqueeze: rateKeyOut}
+	var k [rateKeyIn]byte
+	n := copy(k[:], key)
+	k[n] = 0 // empty identifier, followed by its length
+	c.absorbAny(k[:n+1], c.rAbsorb, colorAbsorbKey)
+	return c
+}
+
+// xorIn XORs b into the state, starting at its first byte.
+func (c *cyclist) xorIn(b []byte) {
+	i := 0
+	for ; i+4 <= len(b); i += 4 {
+		c.a[i/4] ^= binary.LittleEndian.Uint32(b[i:])
+	}
+	for ; i < len(b); i++ {
+		c.xorByte(i, b[i])
+	}
+}
+
+func (c *cyclist) xorByte(i int, b byte) { c.a[i/4] ^= uint32(b) << (8 * (i % 4)) }
+
+// extract writes the first len(b) bytes of the state to b.
+func (c *cyclist) extract(b []byte) {
+	i := 0
+	for ; i+4 <= len(b); i += 4 {
+		binary.LittleEndian.PutUint32(b[i:], c.a[i/4])
+	}
+	for ; i < len(b); i++ {
+		b[i] = byte(c.a[i/4] >> (8 * (i % 4)))
+	}
+}
+
+func (c *cyclist) doDown(x []byte, cd byte) {
+	c.xorIn(x)
+	c.xorByte(len(x), 0x01)
+	if !c.keyed {
+		cd &= 0x01
+	}
+	c.xorByte(StateSize-1, cd)
+	c.down = true
+}
+
+func (c *cyclist) doUp(y []byte, cu byte) {
+	if c.keyed {
+		c.xorByte(StateSize-1, cu)
+	}
+	Permute(&c.a)
+	c.down = false
+	c.extract(y)
+}
+
+// absorbBlock absorbs a block of at most r bytes.
+func (c *cyclist) absorbBlock(x []byte, cd byte) {
+	if c.down {
+		c.doUp(nil, 0)
+	}
+	c.doDown(x, cd)
+}
+
+func (c *cyclist) absorbAny(x []byte, r int, cd byte) {
+	for {
+		n := r
+		if n > len(x) {
+			n = len(x)
+		}
+		c.absorbBlock(x[:n], cd)
+		cd = 0
+		x = x[n:]
+		if len(x) == 0 {
+			return
+		}
+	}
+}
+
+// crypt encrypts or decrypts in into out, which may be the same slice.
+func (c *cyclist) crypt(out, in []byte, decrypt bool) {
+	var buf [rateKeyOut]byte
+	cu := byte(colorCrypt)
+	for {
+		n := rateKeyOut
+		if n > len(in) {
+			n = len(in)
+		}
+		c.doUp(buf[:n], cu)
+		cu = 0
+		for i := 0; i < n; i++ {
+			buf[i] ^= in[i]
+		}
+		if decrypt {
+			c.doDown(buf[:n], 0)
+		} else {
+			c.doDown(in[:n], 0)
+		}
+		copy(out, buf[:n])
+		in, out = in[n:], out[n:]
+		if len(in) == 0 {
+			return
+		}
+	}
+}
+
+// squeezeAny writes len(y) bytes of output to y.
+func (c *cyclist) squeezeAny(y []byte, cu byte) {
+	n := c.rSqueeze
+	if n > len(y) {
+		n = len(y)
+	}
+	c.doUp(y[:n], cu)
+	for y = y[n:]; len(y) > 0; y = y[n:] {
+		c.doDown(nil, 0)
+		n = c.rSqueeze
+		if n > len(y) {
+			n = len(y)
+		}
+		c.doUp(y[:n], 0)
+	}
+}
@@ -0,0 +1,101 @@
+package xoodyak
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The marshalled state of the hash mode is
+//
+//	magic ‖ flags ‖ n ‖ a ‖ buf
+//
+// where flags and n are single bytes, and the lanes of a are encoded in
+// little-endian order. Bit 0 of flags is set when squeezing, bit 1 before
+// the first block is absorbed and bit 2 after a call to Down.
+const (
+	magic = "xoodyak\x01"
+
+	// MarshaledSize is the size of the marshalled Hash and XOF.
+	MarshaledSize = len(magic) + 2 + StateSize + rateHash
+
+	flagSqueezing = 1 << 0
+	flagFirst     = 1 << 1
+	flagDown      = 1 << 2
+)
+
+var (
+	errInvalidState  = errors.New("xoodyak: invalid hash state")
+	errStateIdentity = errors.New("xoodyak: invalid hash state identifier")
+)
+
+func (d *sponge) appendBinary(b []byte) []byte {
+	var flags byte
+	if d.squeezing {
+		flags |= flagSqueezing
+	}
+	if d.first {
+		flags |= flagFirst
+	}
+	if d.c.down {
+		flags |= flagDown
+	}
+
+	b = append(b, magic...)
+	b = append(b, flags, byte(d.n))
+	for i := range d.c.a {
+		b = binary.LittleEndian.AppendUint32(b, d.c.a[i])
+	}
+	return append(b, d.buf[:]...)
+}
+
+func (d *sponge) unmarshalBinary(b []byte) error {
+	if len(b) < len(magic) || string(b[:len(magic)]) != magic {
+		return errStateIdentity
+	}
+	if len(b) != MarshaledSize {
+		return errInvalidState
+	}
+	b = b[len(magic):]
+
+	flags, n := b[0], int(b[1])
+	if flags&^(flagSqueezing|flagFirst|flagDown) != 0 || n > rateHash {
+		return errInvalidState
+	}
+	b = b[2:]
+
+	t := newSponge()
+	t.squeezing = flags&flagSqueezing != 0
+	t.first = flags&flagFirst != 0
+	t.c.down = flags&flagDown != 0
+	t.n = n
+	for i := range t.c.a {
+		t.c.a[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+	copy(t.buf[:], b[StateSize:])
+	*d = t
+	return nil
+}
+
+// MarshalBinary encodes the state, including buffered input.
+func (h *Hash) MarshalBinary() ([]byte, error) {
+	return h.AppendBinary(make([]byte, 0, MarshaledSize))
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (h *Hash) AppendBinary(b []byte) ([]byte, error) { return h.appendBinary(b), nil }
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary.
+func (h *Hash) UnmarshalBinary(b []byte) error { return h.unmarshalBinary(b) }
+
+// MarshalBinary encodes the state, including buffered input or output.
+func (x *XOF) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(make([]byte, 0, MarshaledSize))
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (x *XOF) AppendBinary(b []byte) ([]byte, error) { return x.appendBinary(b), nil }
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary.
+func (x *XOF) UnmarshalBinary(b []byte) error { return x.unmarshalBinary(b) }
@@ -0,0 +1,28 @@
+package xoodyak
+
+import (
+	"crypto/cipher"
+
+	circlcipher "github.com/karalef/circl/cipher"
+)
+
+type scheme struct{}
+
+// Scheme returns the AEAD scheme of Xoodyak.
+func Scheme() circlcipher.AEADScheme { return scheme{} }
+
+func (scheme) Name() string   { return "Xoodyak" }
+func (scheme) KeySize() int   { return KeySize }
+func (scheme) NonceSize() int { return NonceSize }
+func (scheme) TagSize() int   { return TagSize }
+
+func (scheme) New(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, circlcipher.ErrKeySize
+	}
+	a, err := New(key)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,54 @@
+package xoodyak
+
+import "math/bits"
+
+// StateSize is the size of the Xoodoo state in bytes.
+const StateSize = 48
+
+// roundConstants of the 12 rounds of Xoodoo.
+var roundConstants = [12]uint32{
+	0x00000058, 0x00000038, 0x000003c0, 0x000000d0,
+	0x00000120, 0x00000014, 0x00000060, 0x0000002c,
+	0x00000380, 0x000000f0, 0x000001a0, 0x00000012,
+}
+
+// Permute applies the Xoodoo[12] permutation to the state a. The lane of
+// coordinates (x, y) is a[4*y+x], and the byte representation of the state
+// is the concatenation of the lanes in little-endian order.
+func Permute(a *[12]uint32) {
+	for _, rc := range roundConstants {
+		// θ
+		var e [4]uint32
+		for x := 0; x < 4; x++ {
+			p := a[(x+3)%4] ^ a[4+(x+3)%4] ^ a[8+(x+3)%4]
+			e[x] = bits.RotateLeft32(p, 5) ^ bits.RotateLeft32(p, 14)
+		}
+		for i := range a {
+			a[i] ^= e[i%4]
+		}
+
+		// ρ_west
+		a[4], a[5], a[6], a[7] = a[7], a[4], a[5], a[6]
+		for x := 8; x < 12; x++ {
+			a[x] = bits.RotateLeft32(a[x], 11)
+		}
+
+		// ι
+		a[0] ^= rc
+
+		// χ
+		for x := 0; x < 4; x++ {
+			a0, a1, a2 := a[x], a[4+x], a[8+x]
+			a[x] = a0 ^ (^a1 & a2)
+			a[4+x] = a1 ^ (^a2 & a0)
+			a[8+x] = a2 ^ (^a0 & a1)
+		}
+
+		// ρ_east
+		for x := 4; x < 8; x++ {
+			a[x] = bits.RotateLeft32(a[x], 1)
+		}
+		a[8], a[9], a[10], a[11] = bits.RotateLeft32(a[10], 8), bits.RotateLeft32(a[11], 8),
+			bits.RotateLeft32(a[8], 8), bits.RotateLeft32(a[9], 8)
+	}
+}
@@ -0,0 +1,255 @@
+// Package xoodyak implements the Xoodyak lightweight cryptographic scheme
+// and the Xoodoo permutation it is built on.
+//
+// Xoodyak, by J. Daemen, S. Hoffert, M. Peeters, G. Van Assche and
+// R. Van Keer, was a finalist of the NIST Lightweight Cryptography
+// competition. It uses the Cyclist mode of operation, which provides the
+// Xoodyak AEAD in keyed mode, and the Xoodyak hash function and
+// extendable-output function in hash mode.
+// https://keccak.team/xoodyak.html
+package xoodyak
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"hash"
+)
+
+const (
+	KeySize   = 16
+	NonceSize = 16
+	TagSize   = 16
+	HashSize  = 32
+)
+
+var (
+	ErrKeySize    = errors.New("xoodyak: bad key size")
+	ErrNonceSize  = errors.New("xoodyak: bad nonce size")
+	ErrDecryption = errors.New("xoodyak: invalid ciphertext")
+)
+
+// Cipher is an instance of the Xoodyak AEAD.
+type Cipher struct {
+	init cyclist // state after absorbing the key
+}
+
+var _ cipher.AEAD = (*Cipher)(nil)
+
+// New returns a Cipher with the given key, which must be KeySize bytes
+// long.
+func New(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, ErrKeySize
+	}
+	return &Cipher{newKeyedCyclist(key)}, nil
+}
+
+// NonceSize returns the size of the nonce that must be passed to Seal
+// and Open.
+func (a *Cipher) NonceSize() int { return NonceSize }
+
+// Overhead returns the maximum difference between the lengths of a
+// plaintext and its ciphertext.
+func (a *Cipher) Overhead() int { return TagSize }
+
+// Seal encrypts and authenticates plaintext, authenticates the
+// additional data and appends the result to dst, returning the updated
+// slice. The nonce must be NonceSize() bytes long and unique for all
+// time, for a given key.
+//
+// To reuse plaintext's storage for the encrypted output, use plaintext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+func (a *Cipher) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic(ErrNonceSize)
+	}
+
+	ptLen := len(plaintext)
+	ret, out := sliceForAppend(dst, ptLen+TagSize)
+
+	c := a.init
+	c.absorbAny(nonce, c.rAbsorb, colorAbsorb)
+	c.absorbAny(additionalData, c.rAbsorb, colorAbsorb)
+	c.crypt(out[:ptLen], plaintext, false)
+	c.squeezeAny(out[ptLen:], colorSqueeze)
+	return ret
+}
+
+// Open decrypts and authenticates ciphertext, authenticates the
+// additional data and, if successful, appends the resulting plaintext
+// to dst, returning the updated slice. The nonce must be NonceSize()
+// bytes long and both it and the additional data must match the
+// value passed to Seal.
+//
+// To reuse ciphertext's storage for the decrypted output, use ciphertext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+//
+// Even if the function fails, the contents of dst, up to its capacity,
+// may be overwritten.
+func (a *Cipher) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic(ErrNonceSize)
+	}
+	if len(ciphertext) < TagSize {
+		return nil, ErrDecryption
+	}
+
+	ptLen := len(ciphertext) - TagSize
+	var tag0, tag1 [TagSize]byte
+	copy(tag0[:], ciphertext[ptLen:])
+	ret, out := sliceForAppend(dst, ptLen)
+
+	c := a.init
+	c.absorbAny(nonce, c.rAbsorb, colorAbsorb)
+	c.absorbAny(additionalData, c.rAbsorb, colorAbsorb)
+	c.crypt(out, ciphertext[:ptLen], true)
+	c.squeezeAny(tag1[:], colorSqueeze)
+
+	if subtle.ConstantTimeCompare(tag0[:], tag1[:]) == 0 {
+		return nil, ErrDecryption
+	}
+
+	return ret, nil
+}
+
+// sponge is the streaming interface to the hash mode of Cyclist.
+type sponge struct {
+	c         cyclist
+	buf       [rateHash]byte // pending input or available output
+	n         int            // bytes used in buf
+	first     bool           // whether no block has been absorbed yet
+	squeezing bool
+}
+
+func newSponge() sponge { return sponge{c: newHashCyclist(), first: true} }
+
+func (d *sponge) write(p []byte) (int, error) {
+	if d.squeezing {
+		panic("xoodyak: write after read")
+	}
+	written := len(p)
+
+	// A full block is only absorbed once more data arrives, as the last
+	// block is absorbed even if it is full.
+	for len(p) > 0 {
+		if d.n == rateHash {
+			d.absorbBuffer()
+		}
+		k := copy(d.buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+	}
+
+	return written, nil
+}
+
+func (d *sponge) absorbBuffer() {
+	cd := byte(0)
+	if d.first {
+		cd = colorAbsorb
+	}
+	d.c.absorbBlock(d.buf[:d.n], cd)
+	d.first = false
+	d.n = 0
+}
+
+func (d *sponge) read(p []byte) (int, error) {
+	if !d.squeezing {
+		d.absorbBuffer()
+		d.c.doUp(d.buf[:], colorSqueeze)
+		d.squeezing = true
+	}
+	read := len(p)
+
+	for len(p) > 0 {
+		if d.n == rateHash {
+			d.c.doDown(nil, 0)
+			d.c.doUp(d.buf[:], 0)
+			d.n = 0
+		}
+		k := copy(p, d.buf[d.n:])
+		d.n += k
+		p = p[k:]
+	}
+
+	return read, nil
+}
+
+func (d *sponge) reset() { *d = newSponge() }
+
+// Hash is an instance of the Xoodyak hash function.
+type Hash struct{ sponge }
+
+var _ hash.Hash = (*Hash)(nil)
+
+// NewHash returns a new Xoodyak hash instance.
+func NewHash() *Hash { return &Hash{newSponge()} }
+
+// Write absorbs more data into the hash's state. It never returns an error.
+func (h *Hash) Write(p []byte) (int, error) { return h.write(p) }
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (h *Hash) Sum(b []byte) []byte {
+	d := h.sponge
+	ret, out := sliceForAppend(b, HashSize)
+	_, _ = d.read(out)
+	return ret
+}
+
+// Reset resets the hash to its initial state.
+func (h *Hash) Reset() { h.reset() }
+
+// Size returns the number of bytes Sum will append.
+func (h *Hash) Size() int { return HashSize }
+
+// BlockSize returns the rate of the hash mode of Cyclist.
+func (h *Hash) BlockSize() int { return rateHash }
+
+// Clone returns a copy of the hash in its current state.
+func (h *Hash) Clone() *Hash { c := *h; return &c }
+
+// XOF is an instance of Xoodyak as an extendable-output function. Its
+// output starts with the Xoodyak hash of the same input.
+type XOF struct{ sponge }
+
+// NewXOF returns a new Xoodyak XOF instance.
+func NewXOF() *XOF { return &XOF{newSponge()} }
+
+// Write absorbs more data into the XOF's state. It panics if called
+// after Read.
+func (x *XOF) Write(p []byte) (int, error) { return x.write(p) }
+
+// Read squeezes more output from the XOF. It never returns an error.
+func (x *XOF) Read(p []byte) (int, error) { return x.read(p) }
+
+// Reset restores the XOF to its initial state.
+func (x *XOF) Reset() { x.reset() }
+
+// Clone returns a copy of the XOF in its current state.
+func (x *XOF) Clone() *XOF { c := *x; return &c }
+
+// Sum returns the Xoodyak hash of data.
+func Sum(data []byte) [HashSize]byte {
+	var out [HashSize]byte
+	d := newSponge()
+	_, _ = d.write(data)
+	_, _ = d.read(out[:])
+	return out
+}
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a
+// slice with the contents of the given slice followed by that many bytes and a
+// second slice that aliases into it and contains only the extra bytes. If the
+// original slice has sufficient capacity then no allocation is performed.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,197 @@
+package xoodyak_test
+
+import (
+	"bytes"
+	"crypto/cipher"
+	hexa "encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/karalef/circl/cipher/xoodyak"
+	"github.com/karalef/circl/internal/test"
+)
+
+type hex []byte
+
+func (h *hex) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := hexa.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = hex(decoded)
+	return nil
+}
+
+type aeadVector struct {
+	Count int `json:"Count"`
+	Key   hex `json:"Key"`
+	Nonce hex `json:"Nonce"`
+	PT    hex `json:"PT"`
+	AD    hex `json:"AD"`
+	CT    hex `json:"CT"`
+}
+
+type hashVector struct {
+	Count int `json:"Count"`
+	Msg   hex `json:"Msg"`
+	MD    hex `json:"MD"`
+}
+
+func readFile(t *testing.T, fileName string, v interface{}) {
+	input, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("File %v can not be read. Error: %v", fileName, err)
+	}
+	err = json.Unmarshal(input, v)
+	if err != nil {
+		t.Fatalf("File %v can not be loaded. Error: %v", fileName, err)
+	}
+}
+
+// Test vectors in the format of the LWC_AEAD_KAT_128_128.txt and
+// LWC_HASH_KAT_256.txt files of the Xoodyak submission.
+// https://keccak.team/xoodyak.html
+
+func TestAEAD(t *testing.T) {
+	var vectors []aeadVector
+	readFile(t, "testdata/Xoodyak.json", &vectors)
+	for _, v := range vectors {
+		a, err := xoodyak.New(v.Key)
+		test.CheckNoErr(t, err, "failed to create cipher")
+
+		var aead cipher.AEAD = a
+		got := aead.Seal(nil, v.Nonce, v.PT, v.AD)
+		if !bytes.Equal(got, v.CT) {
+			test.ReportError(t, got, v.CT, v.Count)
+		}
+
+		got, err = aead.Open(nil, v.Nonce, v.CT, v.AD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, v.PT) {
+			test.ReportError(t, got, v.PT, v.Count)
+		}
+
+		if len(v.CT) > 0 {
+			ct := append([]byte{}, v.CT...)
+			ct[0] ^= 0x01
+			_, err = aead.Open(nil, v.Nonce, ct, v.AD)
+			test.CheckIsErr(t, err, "should fail due to bad ciphertext")
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	var vectors []hashVector
+	readFile(t, "testdata/XoodyakHash.json", &vectors)
+	for _, v := range vectors {
+		h := xoodyak.NewHash()
+		_, _ = h.Write(v.Msg)
+		got := h.Sum(nil)
+		if !bytes.Equal(got, v.MD) {
+			test.ReportError(t, got, v.MD, v.Count)
+		}
+
+		// Byte by byte.
+		h.Reset()
+		for i := range v.Msg {
+			_, _ = h.Write(v.Msg[i : i+1])
+		}
+		got = h.Sum(nil)
+		if !bytes.Equal(got, v.MD) {
+			test.ReportError(t, got, v.MD, v.Count)
+		}
+
+		sum := xoodyak.Sum(v.Msg)
+		if !bytes.Equal(sum[:], v.MD) {
+			test.ReportError(t, sum[:], v.MD, v.Count)
+		}
+
+		// The XOF output starts with the hash, and does not depend on
+		// how it is read.
+		x := xoodyak.NewXOF()
+		_, _ = x.Write(v.Msg)
+		y := x.Clone()
+		out0 := make([]byte, 100)
+		_, _ = x.Read(out0)
+		if !bytes.Equal(out0[:xoodyak.HashSize], v.MD) {
+			test.ReportError(t, out0[:xoodyak.HashSize], v.MD, v.Count)
+		}
+		out1 := make([]byte, len(out0))
+		for i := 0; i < len(out1); i += 7 {
+			j := i + 7
+			if j > len(out1) {
+				j = len(out1)
+			}
+			_, _ = y.Read(out1[i:j])
+		}
+		if !bytes.Equal(out0, out1) {
+			test.ReportError(t, out1, out0, v.Count)
+		}
+	}
+}
+
+func TestAPI(t *testing.T) {
+	_, err := xoodyak.New(make([]byte, xoodyak.KeySize-1))
+	test.CheckIsErr(t, err, "should fail due to short key")
+
+	a, _ := xoodyak.New(make([]byte, xoodyak.KeySize))
+	err = test.CheckPanic(func() { _ = a.Seal(nil, nil, nil, nil) })
+	test.CheckNoErr(t, err, "should panic due to bad nonce")
+
+	nonce := make([]byte, xoodyak.NonceSize)
+	_, err = a.Open(nil, nonce, make([]byte, xoodyak.TagSize-1), nil)
+	test.CheckIsErr(t, err, "should fail due to short ciphertext")
+
+	pt := []byte("the quick brown fox jumps over the lazy dog")
+	ct := a.Seal(nil, nonce, pt, nil)
+	buf := append(make([]byte, 0, len(ct)), pt...)
+	got := a.Seal(buf[:0], nonce, buf, nil)
+	test.CheckOk(bytes.Equal(got, ct), "in-place Seal should match", t)
+	got, err = a.Open(got[:0], nonce, got, nil)
+	test.CheckNoErr(t, err, "in-place Open failed")
+	test.CheckOk(bytes.Equal(got, pt), "in-place Open should match", t)
+
+	h := xoodyak.NewHash()
+	_, _ = h.Write(pt)
+	enc, err := h.MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	g := xoodyak.NewHash()
+	err = g.UnmarshalBinary(enc)
+	test.CheckNoErr(t, err, "failed to unmarshal")
+	_, _ = h.Write(pt)
+	_, _ = g.Write(pt)
+	test.CheckOk(bytes.Equal(h.Sum(nil), g.Sum(nil)), "restored state differs", t)
+	err = g.UnmarshalBinary(enc[1:])
+	test.CheckIsErr(t, err, "should fail due to bad encoding")
+
+	x := xoodyak.NewXOF()
+	_, _ = x.Read(nil)
+	err = test.CheckPanic(func() { _, _ = x.Write(nil) })
+	test.CheckNoErr(t, err, "should panic due to write after read")
+}
+
+func BenchmarkXoodyak(b *testing.B) {
+	msg := make([]byte, 1024)
+	b.Run("Seal", func(b *testing.B) {
+		a, _ := xoodyak.New(make([]byte, xoodyak.KeySize))
+		nonce := make([]byte, xoodyak.NonceSize)
+		out := make([]byte, 0, len(msg)+xoodyak.TagSize)
+		b.SetBytes(int64(len(msg)))
+		for i := 0; i < b.N; i++ {
+			a.Seal(out, nonce, msg, nil)
+		}
+	})
+	b.Run("Hash", func(b *testing.B) {
+		b.SetBytes(int64(len(msg)))
+		for i := 0; i < b.N; i++ {
+			xoodyak.Sum(msg)
+		}
+	})
+}
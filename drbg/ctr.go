@@ -0,0 +1,152 @@
+package drbg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+)
+
+const (
+	ctrKeySize  = 32
+	ctrSeedSize = ctrKeySize + aes.BlockSize
+)
+
+// ctrDRBG is the CTR_DRBG of SP 800-90A Section 10.2.1 using AES-256 with
+// the derivation function.
+type ctrDRBG struct {
+	block cipher.Block
+	v     [aes.BlockSize]byte
+}
+
+// NewCTR returns a CTR_DRBG using AES-256 with the derivation function,
+// instantiated with the entropy input, of at least SecurityStrength bytes,
+// the nonce, of at least SecurityStrength/2 bytes unless it is part of the
+// entropy input, and the optional personalization string.
+func NewCTR(entropy, nonce, personalization []byte, opts *Options) (*DRBG, error) {
+	if err := ctrHealth.check(); err != nil {
+		return nil, err
+	}
+	d, err := newDRBG(entropy, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.m = newCTR(entropy, nonce, personalization)
+	return d, nil
+}
+
+func newCTR(entropy, nonce, personalization []byte) *ctrDRBG {
+	c := &ctrDRBG{}
+	c.block, _ = aes.NewCipher(make([]byte, ctrKeySize))
+	seed := ctrDF(entropy, nonce, personalization)
+	c.update(&seed)
+	return c
+}
+
+func (c *ctrDRBG) reseed(entropy, additional []byte) {
+	seed := ctrDF(entropy, additional)
+	c.update(&seed)
+}
+
+func (c *ctrDRBG) generate(out, additional []byte) {
+	var add [ctrSeedSize]byte
+	if len(additional) > 0 {
+		add = ctrDF(additional)
+		c.update(&add)
+	}
+
+	if len(out) > 0 {
+		for i := range out {
+			out[i] = 0
+		}
+		c.incV()
+		cipher.NewCTR(c.block, c.v[:]).XORKeyStream(out, out)
+		c.addV(uint64((len(out)+aes.BlockSize-1)/aes.BlockSize) - 1)
+	}
+
+	c.update(&add)
+}
+
+// incV increments V modulo 2^128.
+func (c *ctrDRBG) incV() { c.addV(1) }
+
+// addV adds n to V modulo 2^128.
+func (c *ctrDRBG) addV(n uint64) {
+	lo := binary.BigEndian.Uint64(c.v[8:])
+	hi := binary.BigEndian.Uint64(c.v[:8])
+	lo += n
+	if lo < n {
+		hi++
+	}
+	binary.BigEndian.PutUint64(c.v[8:], lo)
+	binary.BigEndian.PutUint64(c.v[:8], hi)
+}
+
+// update is the CTR_DRBG_Update function.
+func (c *ctrDRBG) update(provided *[ctrSeedSize]byte) {
+	var temp [ctrSeedSize]byte
+	for i := 0; i < len(temp); i += aes.BlockSize {
+		c.incV()
+		c.block.Encrypt(temp[i:], c.v[:])
+	}
+	for i := range temp {
+		temp[i] ^= provided[i]
+	}
+	c.block, _ = aes.NewCipher(temp[:ctrKeySize])
+	copy(c.v[:], temp[ctrKeySize:])
+}
+
+// ctrDF is the Block_Cipher_df function returning ctrSeedSize bytes of the
+// concatenation of inputs.
+func ctrDF(inputs ...[]byte) [ctrSeedSize]byte {
+	n := 0
+	for _, in := range inputs {
+		n += len(in)
+	}
+
+	// S = L || N || input_string || 0x80, padded with zeros to a multiple
+	// of the block size. It is preceded by the IV of BCC.
+	s := make([]byte, aes.BlockSize, aes.BlockSize+8+n+1+aes.BlockSize)
+	s = binary.BigEndian.AppendUint32(s, uint32(n))
+	s = binary.BigEndian.AppendUint32(s, ctrSeedSize)
+	for _, in := range inputs {
+		s = append(s, in...)
+	}
+	s = append(s, 0x80)
+	for len(s)%aes.BlockSize != 0 {
+		s = append(s, 0)
+	}
+
+	var key [ctrKeySize]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	block, _ := aes.NewCipher(key[:])
+
+	var temp [ctrSeedSize]byte
+	for i := 0; i < len(temp); i += aes.BlockSize {
+		binary.BigEndian.PutUint32(s, uint32(i/aes.BlockSize))
+		bcc(block, temp[i:i+aes.BlockSize], s)
+	}
+
+	block, _ = aes.NewCipher(temp[:ctrKeySize])
+	x := temp[ctrKeySize:]
+	var out [ctrSeedSize]byte
+	for i := 0; i < len(out); i += aes.BlockSize {
+		block.Encrypt(out[i:], x)
+		x = out[i : i+aes.BlockSize]
+	}
+	return out
+}
+
+// bcc sets chain to the CBC-MAC of data, a multiple of the block size.
+func bcc(block cipher.Block, chain, data []byte) {
+	for i := range chain {
+		chain[i] = 0
+	}
+	for ; len(data) > 0; data = data[aes.BlockSize:] {
+		for i := range chain {
+			chain[i] ^= data[i]
+		}
+		block.Encrypt(chain, chain)
+	}
+}
@@ -0,0 +1,173 @@
+// Package drbg provides deterministic random bit generators.
+//
+// It implements the CTR_DRBG using AES-256 with the derivation function and
+// the Hash_DRBG using SHA-512 of NIST SP 800-90A Rev. 1, and a DRBG built on
+// SHAKE256. They are instantiated with an entropy input, a nonce and an
+// optional personalization string, and produce the same output for the same
+// inputs, which makes them suitable for deriving keys from a seed or for
+// reproducing test vectors, as the rand argument of sign.Scheme.GenerateKey
+// for instance.
+//
+// A DRBG must be reseeded with fresh entropy after ReseedInterval requests.
+// If it has been given an entropy source, it does so automatically, and
+// before every request if prediction resistance is enabled. Otherwise,
+// requests fail with ErrReseedRequired until Reseed is called.
+//
+// The known-answer health tests of each mechanism are run before its first
+// instantiation, which fails with ErrHealthTest if they do not pass.
+//
+// https://doi.org/10.6028/NIST.SP.800-90Ar1
+package drbg
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	// SecurityStrength is the security strength of the DRBGs in bytes, which
+	// is the minimum size of their entropy inputs.
+	SecurityStrength = 32
+
+	// MaxRequest is the maximum number of bytes produced by a single call to
+	// Generate. Read splits larger requests.
+	MaxRequest = 1 << 16
+
+	// MaxReseedInterval is the maximum number of requests between reseeds.
+	MaxReseedInterval = 1 << 48
+)
+
+var (
+	// ErrReseedRequired is returned when the DRBG must be reseeded and has
+	// no entropy source.
+	ErrReseedRequired = errors.New("drbg: reseed required")
+
+	// ErrEntropyTooShort is returned when an entropy input is shorter than
+	// SecurityStrength.
+	ErrEntropyTooShort = errors.New("drbg: entropy input too short")
+
+	// ErrHealthTest is returned when the health tests of a mechanism fail.
+	ErrHealthTest = errors.New("drbg: health test failed")
+
+	errRequestTooLarge = errors.New("drbg: request too large")
+	errReseedInterval  = errors.New("drbg: invalid reseed interval")
+	errNoSource        = errors.New("drbg: prediction resistance requires an entropy source")
+)
+
+// Options configure the reseeding of a DRBG. A nil *Options is valid and
+// equivalent to the zero value.
+type Options struct {
+	// Source provides the entropy inputs of the automatic reseeds, which
+	// are disabled if it is nil.
+	Source io.Reader
+
+	// ReseedInterval is the number of requests after which the DRBG must be
+	// reseeded. It defaults to, and must not exceed, MaxReseedInterval.
+	ReseedInterval uint64
+
+	// PredictionResistance makes the DRBG reseed from Source before every
+	// request, which then requires Source to be set.
+	PredictionResistance bool
+}
+
+// mechanism is a DRBG mechanism, which is instantiated by its constructor.
+type mechanism interface {
+	reseed(entropy, additional []byte)
+	generate(out, additional []byte)
+}
+
+// DRBG is a deterministic random bit generator. It is not safe for
+// concurrent use.
+type DRBG struct {
+	m                    mechanism
+	counter              uint64 // number of requests since the last reseed, plus one
+	interval             uint64
+	source               io.Reader
+	predictionResistance bool
+}
+
+// newDRBG checks the entropy input and the options, and returns a DRBG
+// without mechanism, which must then be instantiated.
+func newDRBG(entropy []byte, opts *Options) (*DRBG, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	interval := opts.ReseedInterval
+	if interval == 0 {
+		interval = MaxReseedInterval
+	}
+	if interval > MaxReseedInterval {
+		return nil, errReseedInterval
+	}
+	if opts.PredictionResistance && opts.Source == nil {
+		return nil, errNoSource
+	}
+	if len(entropy) < SecurityStrength {
+		return nil, ErrEntropyTooShort
+	}
+	return &DRBG{
+		counter:              1,
+		interval:             interval,
+		source:               opts.Source,
+		predictionResistance: opts.PredictionResistance,
+	}, nil
+}
+
+// Reseed mixes a fresh entropy input, of at least SecurityStrength bytes,
+// and an optional additional input into the state.
+func (d *DRBG) Reseed(entropy, additional []byte) error {
+	if len(entropy) < SecurityStrength {
+		return ErrEntropyTooShort
+	}
+	d.m.reseed(entropy, additional)
+	d.counter = 1
+	return nil
+}
+
+// reseedFromSource reseeds the DRBG with an entropy input read from its
+// source.
+func (d *DRBG) reseedFromSource(additional []byte) error {
+	var entropy [SecurityStrength]byte
+	if _, err := io.ReadFull(d.source, entropy[:]); err != nil {
+		return err
+	}
+	return d.Reseed(entropy[:], additional)
+}
+
+// Generate fills out, of at most MaxRequest bytes, with pseudorandom bytes
+// also depending on the optional additional input.
+func (d *DRBG) Generate(out, additional []byte) error {
+	if len(out) > MaxRequest {
+		return errRequestTooLarge
+	}
+	if d.predictionResistance || d.counter > d.interval {
+		if d.source == nil {
+			return ErrReseedRequired
+		}
+		if err := d.reseedFromSource(additional); err != nil {
+			return err
+		}
+		additional = nil
+	}
+	d.m.generate(out, additional)
+	d.counter++
+	return nil
+}
+
+// Read fills p with pseudorandom bytes. It implements io.Reader, splitting
+// the requests larger than MaxRequest, and returns an error only if the
+// DRBG needs to be reseeded and can't, in which case n is the number of
+// bytes generated before.
+func (d *DRBG) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		k := len(p) - n
+		if k > MaxRequest {
+			k = MaxRequest
+		}
+		if err = d.Generate(p[n:n+k], nil); err != nil {
+			return n, err
+		}
+		n += k
+	}
+	return n, nil
+}
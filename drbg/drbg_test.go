@@ -0,0 +1,237 @@
+package drbg_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karalef/circl/drbg"
+	"github.com/karalef/circl/internal/test"
+	"github.com/karalef/circl/sign/schemes"
+)
+
+type newFunc func(entropy, nonce, personalization []byte, opts *drbg.Options) (*drbg.DRBG, error)
+
+var mechanisms = []struct {
+	name string
+	new  newFunc
+}{
+	{"CTR", drbg.NewCTR},
+	{"Hash", drbg.NewHash},
+	{"Shake", drbg.NewShake},
+}
+
+func seq(n int, start byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = start + byte(i)
+	}
+	return b
+}
+
+// cavpVector is a vector of the NIST CAVP drbgvectors files, whose repeated
+// fields are kept in order.
+type cavpVector struct {
+	alg    string
+	count  string
+	fields map[string][][]byte
+}
+
+func readCAVP(t *testing.T, name string) []cavpVector {
+	f, err := os.Open(filepath.Join("testdata", name))
+	test.CheckNoErr(t, err, "open")
+	defer f.Close()
+
+	var vs []cavpVector
+	var alg string
+	newGroup := true
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1<<16)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "["):
+			if newGroup {
+				alg = strings.Trim(line, "[]")
+			}
+			newGroup = false
+			continue
+		default:
+			k, v, _ := strings.Cut(line, "=")
+			k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+			if k == "COUNT" {
+				vs = append(vs, cavpVector{alg, v, map[string][][]byte{}})
+				break
+			}
+			b, err := hex.DecodeString(v)
+			test.CheckNoErr(t, err, "hex "+k)
+			fs := vs[len(vs)-1].fields
+			fs[k] = append(fs[k], b)
+		}
+		newGroup = true
+	}
+	test.CheckNoErr(t, s.Err(), "read")
+	return vs
+}
+
+var cavpMechanisms = map[string]newFunc{
+	"AES-256 use df": drbg.NewCTR,
+	"SHA-512":        drbg.NewHash,
+}
+
+func TestCAVP(t *testing.T) {
+	// Without prediction resistance, the DRBG is instantiated, reseeded and
+	// called twice; with it, each call reseeds from EntropyInputPR. Only the
+	// output of the second call is given.
+	for _, pr := range []bool{false, true} {
+		name := "drbgvectors_pr_false.rsp"
+		if pr {
+			name = "drbgvectors_pr_true.rsp"
+		}
+		vs := readCAVP(t, name)
+		test.CheckOk(len(vs) != 0, "no vectors in "+name, t)
+		for _, v := range vs {
+			f := v.fields
+			opts := &drbg.Options{}
+			if pr {
+				opts.PredictionResistance = true
+				opts.Source = bytes.NewReader(bytes.Join(f["EntropyInputPR"], nil))
+			}
+			d, err := cavpMechanisms[v.alg](f["EntropyInput"][0], f["Nonce"][0], f["PersonalizationString"][0], opts)
+			test.CheckNoErr(t, err, "new")
+			if !pr {
+				test.CheckNoErr(t, d.Reseed(f["EntropyInputReseed"][0], f["AdditionalInputReseed"][0]), "reseed")
+			}
+			want := f["ReturnedBits"][0]
+			got := make([]byte, len(want))
+			test.CheckNoErr(t, d.Generate(got, f["AdditionalInput"][0]), "generate")
+			test.CheckNoErr(t, d.Generate(got, f["AdditionalInput"][1]), "generate")
+			if !bytes.Equal(got, want) {
+				test.ReportError(t, hex.EncodeToString(got), hex.EncodeToString(want), name, v.alg, v.count)
+			}
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	for _, m := range mechanisms {
+		t.Run(m.name, func(t *testing.T) {
+			d1, err := m.new(seq(32, 0), seq(16, 0x20), nil, nil)
+			test.CheckNoErr(t, err, "new")
+			d2, err := m.new(seq(32, 0), seq(16, 0x20), nil, nil)
+			test.CheckNoErr(t, err, "new")
+
+			got := make([]byte, 2*drbg.MaxRequest+100)
+			n, err := d1.Read(got)
+			test.CheckNoErr(t, err, "read")
+			test.CheckOk(n == len(got), "short read", t)
+
+			want := make([]byte, len(got))
+			for p := want; len(p) > 0; {
+				k := len(p)
+				if k > drbg.MaxRequest {
+					k = drbg.MaxRequest
+				}
+				test.CheckNoErr(t, d2.Generate(p[:k], nil), "generate")
+				p = p[k:]
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatal("Read differs from Generate")
+			}
+
+			err = d2.Generate(make([]byte, drbg.MaxRequest+1), nil)
+			test.CheckIsErr(t, err, "request larger than MaxRequest")
+		})
+	}
+}
+
+func TestReseed(t *testing.T) {
+	for _, m := range mechanisms {
+		t.Run(m.name, func(t *testing.T) {
+			_, err := m.new(seq(31, 0), seq(16, 0x20), nil, nil)
+			test.CheckOk(errors.Is(err, drbg.ErrEntropyTooShort), "short entropy", t)
+			_, err = m.new(seq(32, 0), nil, nil, &drbg.Options{PredictionResistance: true})
+			test.CheckIsErr(t, err, "prediction resistance without a source")
+			_, err = m.new(seq(32, 0), nil, nil, &drbg.Options{ReseedInterval: drbg.MaxReseedInterval + 1})
+			test.CheckIsErr(t, err, "reseed interval too large")
+
+			var out [16]byte
+			d, err := m.new(seq(32, 0), seq(16, 0x20), nil, &drbg.Options{ReseedInterval: 2})
+			test.CheckNoErr(t, err, "new")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+			err = d.Generate(out[:], nil)
+			test.CheckOk(errors.Is(err, drbg.ErrReseedRequired), "reseed required", t)
+			_, err = d.Read(out[:])
+			test.CheckOk(errors.Is(err, drbg.ErrReseedRequired), "reseed required", t)
+			test.CheckOk(errors.Is(d.Reseed(seq(31, 0), nil), drbg.ErrEntropyTooShort), "short entropy", t)
+			test.CheckNoErr(t, d.Reseed(seq(32, 0x80), []byte("additional")), "reseed")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+
+			// Reseeding changes the output.
+			d1, _ := m.new(seq(32, 0), seq(16, 0x20), nil, nil)
+			d2, _ := m.new(seq(32, 0), seq(16, 0x20), nil, nil)
+			test.CheckNoErr(t, d2.Reseed(seq(32, 0x80), nil), "reseed")
+			var out1, out2 [32]byte
+			_, _ = d1.Read(out1[:])
+			_, _ = d2.Read(out2[:])
+			test.CheckOk(out1 != out2, "same output after reseed", t)
+
+			// The automatic reseeds read the source.
+			src := bytes.NewReader(seq(64, 0x40))
+			d, err = m.new(seq(32, 0), seq(16, 0x20), nil, &drbg.Options{Source: src, ReseedInterval: 1})
+			test.CheckNoErr(t, err, "new")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+			test.CheckOk(src.Len() == 0, "source not read", t)
+			test.CheckIsErr(t, d.Generate(out[:], nil), "empty source")
+
+			src = bytes.NewReader(seq(64, 0x40))
+			d, err = m.new(seq(32, 0), seq(16, 0x20), nil, &drbg.Options{Source: src, PredictionResistance: true})
+			test.CheckNoErr(t, err, "new")
+			test.CheckNoErr(t, d.Generate(out[:], nil), "generate")
+			test.CheckOk(src.Len() == 32, "source not read", t)
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	var seed [48]byte
+	_, _ = rand.Read(seed[:])
+	for _, scheme := range schemes.All() {
+		for _, m := range mechanisms {
+			d1, err := m.new(seed[:32], seed[32:], []byte(scheme.Name()), nil)
+			test.CheckNoErr(t, err, "new")
+			d2, err := m.new(seed[:32], seed[32:], []byte(scheme.Name()), nil)
+			test.CheckNoErr(t, err, "new")
+			pk1, _, err := scheme.GenerateKey(d1)
+			test.CheckNoErr(t, err, "GenerateKey")
+			pk2, _, err := scheme.GenerateKey(d2)
+			test.CheckNoErr(t, err, "GenerateKey")
+			if !pk1.Equal(pk2) {
+				t.Fatalf("%s with %s: different keys from the same seed", scheme.Name(), m.name)
+			}
+		}
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	for _, m := range mechanisms {
+		b.Run(m.name, func(b *testing.B) {
+			d, _ := m.new(seq(32, 0), seq(16, 0x20), nil, nil)
+			buf := make([]byte, 4096)
+			b.SetBytes(int64(len(buf)))
+			for i := 0; i < b.N; i++ {
+				_, _ = d.Read(buf)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package drbg
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+// hashSeedSize is the seedlen of Hash_DRBG with SHA-512 in bytes.
+const hashSeedSize = 888 / 8
+
+// hashDRBG is the Hash_DRBG of SP 800-90A Section 10.1.1 using SHA-512.
+type hashDRBG struct {
+	v, c    [hashSeedSize]byte
+	counter uint64 // reseed counter, which is added to V
+}
+
+// NewHash returns a Hash_DRBG using SHA-512, instantiated with the entropy
+// input, of at least SecurityStrength bytes, the nonce, of at least
+// SecurityStrength/2 bytes unless it is part of the entropy input, and the
+// optional personalization string.
+func NewHash(entropy, nonce, personalization []byte, opts *Options) (*DRBG, error) {
+	if err := hashHealth.check(); err != nil {
+		return nil, err
+	}
+	d, err := newDRBG(entropy, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.m = newHash(entropy, nonce, personalization)
+	return d, nil
+}
+
+func newHash(entropy, nonce, personalization []byte) *hashDRBG {
+	h := &hashDRBG{}
+	h.v = hashDF(entropy, nonce, personalization)
+	h.c = hashDF([]byte{0}, h.v[:])
+	h.counter = 1
+	return h
+}
+
+func (h *hashDRBG) reseed(entropy, additional []byte) {
+	h.v = hashDF([]byte{1}, h.v[:], entropy, additional)
+	h.c = hashDF([]byte{0}, h.v[:])
+	h.counter = 1
+}
+
+func (h *hashDRBG) generate(out, additional []byte) {
+	if len(additional) > 0 {
+		d := sha512.New()
+		d.Write([]byte{2})
+		d.Write(h.v[:])
+		d.Write(additional)
+		addTo(h.v[:], d.Sum(nil))
+	}
+
+	// Hashgen.
+	data := h.v
+	var w [sha512.Size]byte
+	for len(out) > 0 {
+		w = sha512.Sum512(data[:])
+		n := copy(out, w[:])
+		out = out[n:]
+		addTo(data[:], []byte{1})
+	}
+
+	d := sha512.New()
+	d.Write([]byte{3})
+	d.Write(h.v[:])
+	var ctr [8]byte
+	binary.BigEndian.PutUint64(ctr[:], h.counter)
+	addTo(h.v[:], d.Sum(nil))
+	addTo(h.v[:], h.c[:])
+	addTo(h.v[:], ctr[:])
+	h.counter++
+}
+
+// hashDF is the Hash_df function returning hashSeedSize bytes of the
+// concatenation of inputs.
+func hashDF(inputs ...[]byte) (out [hashSeedSize]byte) {
+	d := sha512.New()
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], hashSeedSize*8)
+	var sum [sha512.Size]byte
+	for i := 0; i < len(out); i += sha512.Size {
+		prefix[0] = byte(i/sha512.Size + 1)
+		d.Reset()
+		d.Write(prefix[:])
+		for _, in := range inputs {
+			d.Write(in)
+		}
+		copy(out[i:], d.Sum(sum[:0]))
+	}
+	return out
+}
+
+// addTo sets v to v + x modulo 2^(8·len(v)), both big-endian.
+func addTo(v, x []byte) {
+	var carry uint16
+	for i, j := len(v)-1, len(x)-1; i >= 0; i, j = i-1, j-1 {
+		s := uint16(v[i]) + carry
+		if j >= 0 {
+			s += uint16(x[j])
+		} else if carry == 0 {
+			break
+		}
+		v[i] = byte(s)
+		carry = s >> 8
+	}
+}
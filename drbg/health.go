@@ -0,0 +1,72 @@
+package drbg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+)
+
+// healthTest is the known-answer test of a mechanism, covering its
+// instantiate, reseed and generate functions, as required by SP 800-90A
+// Section 11.3. It is run once, before the first instantiation.
+type healthTest struct {
+	once sync.Once
+	err  error
+	new  func(entropy, nonce, personalization []byte) mechanism
+	want string
+}
+
+var (
+	ctrHealth = &healthTest{
+		new: func(e, n, p []byte) mechanism { return newCTR(e, n, p) },
+		want: "6950217dfb932f46c9e5b4342582c1dd30dbe1675b02a7ad5543420a396229b63883c1de57407255fe604eba4ad486fc3d452a8dda1b09416d48ba28b4e3f4f6" +
+			"c7a444ad0634b244c203e4eec0f121c863fe38c83566e7da9d68681661ab90add5946a6bfe742723beda570d97b3cc8cd58825e9a448b6a80d60841bd5db9755",
+	}
+	hashHealth = &healthTest{
+		new: func(e, n, p []byte) mechanism { return newHash(e, n, p) },
+		want: "1e5e9c811c7e79cb8066b42fc75b52d2662bcfeb315f7b3f8c0ff2861376a54e286804504a2f08394bb485b3fd5e0dd99e27d5f194d48ca48f5f723491312727" +
+			"26a63ba28c90936fdbc908557aa0f285c85572ff73d32ab12d198159f018c8faf35912c7906a8c23188161d4ec2425cc35ba192aa2e5d72a3f7d7b46e946f8ed",
+	}
+	shakeHealth = &healthTest{
+		new: func(e, n, p []byte) mechanism { return newShake(e, n, p) },
+		want: "53a29755f0cba744d30296649d9ba3e143ed61795fa3efb13c4896f4d624a1ca91123ddbd8fdac843e9ddf28ebfcfd94a6807fbf87eb535040e575439d7f1bea" +
+			"1be7de805c33abe2b91bc2e4ce0fb61dfac6663a1c6a1ba4f502050f2d8791bb30c04b0e52c1e97f0f424e78d34014320bd2ce54f894b1907a40df8f1bc73ae5",
+	}
+)
+
+func (h *healthTest) check() error {
+	h.once.Do(func() {
+		if !bytes.Equal(h.run(), h.expected()) {
+			h.err = ErrHealthTest
+		}
+	})
+	return h.err
+}
+
+func (h *healthTest) expected() []byte {
+	want, _ := hex.DecodeString(h.want)
+	return want
+}
+
+// run instantiates the mechanism, generates 64 bytes, reseeds it with an
+// additional input and generates 64 more bytes with the same input.
+func (h *healthTest) run() []byte {
+	entropy := make([]byte, SecurityStrength)
+	nonce := make([]byte, SecurityStrength/2)
+	reseed := make([]byte, SecurityStrength)
+	for i := range entropy {
+		entropy[i] = byte(i)
+		reseed[i] = byte(0x80 + i)
+	}
+	for i := range nonce {
+		nonce[i] = byte(0x20 + i)
+	}
+	additional := []byte("additional input")
+
+	m := h.new(entropy, nonce, []byte("personalization string"))
+	out := make([]byte, 128)
+	m.generate(out[:64], nil)
+	m.reseed(reseed, additional)
+	m.generate(out[64:], additional)
+	return out
+}
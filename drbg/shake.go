@@ -0,0 +1,82 @@
+package drbg
+
+import (
+	"encoding/binary"
+
+	"github.com/karalef/circl/internal/sha3"
+)
+
+const shakeKeySize = 64
+
+// Domain separators of the SHAKE-DRBG operations.
+const (
+	shakeInstantiate = iota
+	shakeReseed
+	shakeGenerate
+)
+
+// shakeDRBG is a DRBG built on SHAKE256, which is not part of SP 800-90A.
+// Its state is a key K of 64 bytes, and its functions are
+//
+//	instantiate: K = SHAKE256(0 ‖ enc(entropy) ‖ enc(nonce) ‖ enc(personalization))
+//	reseed:      K = SHAKE256(1 ‖ K ‖ enc(entropy) ‖ enc(additional))
+//	generate:    K ‖ output = SHAKE256(2 ‖ K ‖ enc(additional))
+//
+// where enc(x) is the length of x in bytes encoded in big-endian order on 8
+// bytes followed by x. Since the next key is squeezed before the output, the
+// previous outputs can't be recovered from the state.
+type shakeDRBG struct {
+	key [shakeKeySize]byte
+}
+
+// NewShake returns a DRBG built on SHAKE256, instantiated with the entropy
+// input, of at least SecurityStrength bytes, the nonce, of at least
+// SecurityStrength/2 bytes unless it is part of the entropy input, and the
+// optional personalization string.
+func NewShake(entropy, nonce, personalization []byte, opts *Options) (*DRBG, error) {
+	if err := shakeHealth.check(); err != nil {
+		return nil, err
+	}
+	d, err := newDRBG(entropy, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.m = newShake(entropy, nonce, personalization)
+	return d, nil
+}
+
+func newShake(entropy, nonce, personalization []byte) *shakeDRBG {
+	s := &shakeDRBG{}
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte{shakeInstantiate})
+	shakeWrite(&h, entropy, nonce, personalization)
+	_, _ = h.Read(s.key[:])
+	return s
+}
+
+func (s *shakeDRBG) reseed(entropy, additional []byte) {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte{shakeReseed})
+	_, _ = h.Write(s.key[:])
+	shakeWrite(&h, entropy, additional)
+	_, _ = h.Read(s.key[:])
+}
+
+func (s *shakeDRBG) generate(out, additional []byte) {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte{shakeGenerate})
+	_, _ = h.Write(s.key[:])
+	shakeWrite(&h, additional)
+	_, _ = h.Read(s.key[:])
+	_, _ = h.Read(out)
+}
+
+// shakeWrite absorbs the length-prefixed inputs.
+func shakeWrite(h *sha3.State, inputs ...[]byte) {
+	var l [8]byte
+	for _, in := range inputs {
+		binary.BigEndian.PutUint64(l[:], uint64(len(in)))
+		_, _ = h.Write(l[:])
+		_, _ = h.Write(in)
+	}
+}
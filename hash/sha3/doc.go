@@ -0,0 +1,24 @@
+// Package sha3 provides the SHA-3 fixed-output-length hash functions, the
+// SHAKE and TurboSHAKE extendable-output functions, and the functions derived
+// from them in NIST SP 800-185.
+//
+// SHA-3 and SHAKE are defined in FIPS 202 [1]; TurboSHAKE is the reduced-round
+// variant of SHAKE defined in RFC 9861 [2] that takes an additional
+// domain-separation byte.
+//
+// SP 800-185 [3] defines cSHAKE, a customizable SHAKE, and on top of it the
+// KMAC message authentication code, TupleHash for hashing sequences of byte
+// strings, and ParallelHash for hashing long inputs in parallel.
+//
+// The SHA-3, SHAKE and cSHAKE instances implement hash.Hash and
+// encoding.BinaryMarshaler, so their state can be saved and restored with
+// MarshalBinary and UnmarshalBinary. The SHAKE instances and the XOF variants
+// of the SP 800-185 functions additionally implement io.Reader to squeeze an
+// arbitrary amount of output.
+//
+// This package shares its Keccak implementation with the rest of CIRCL.
+//
+// [1] https://doi.org/10.6028/NIST.FIPS.202
+// [2] https://www.rfc-editor.org/rfc/rfc9861
+// [3] https://doi.org/10.6028/NIST.SP.800-185
+package sha3
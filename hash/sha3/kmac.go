@@ -0,0 +1,129 @@
+package sha3
+
+import (
+	"hash"
+
+	isha3 "github.com/karalef/circl/internal/sha3"
+)
+
+// KMAC is an instance of KMAC128 or KMAC256, the Keccak message
+// authentication codes defined in NIST SP 800-185.
+//
+// Instances created with NewKMAC128 and NewKMAC256 output a fixed number of
+// bytes with Sum. Instances created with NewKMACXOF128 and NewKMACXOF256
+// are extendable-output functions: they can be squeezed with Read, and
+// their output does not depend on the number of bytes read.
+type KMAC struct {
+	s isha3.State
+
+	// bytepad(encode_string(K), rate), absorbed again on Reset.
+	key []byte
+
+	size int  // output length in bytes
+	xof  bool // whether the output length is encoded as 0
+}
+
+var _ hash.Hash = (*KMAC)(nil)
+
+// NewKMAC128 creates a new KMAC128 instance with the given key, output
+// length size in bytes and customization string S. The key should be at
+// least 16 bytes long to reach the 128-bit security strength.
+func NewKMAC128(key []byte, size int, S []byte) *KMAC {
+	return newKMAC(isha3.NewCShake128([]byte("KMAC"), S), key, size, false)
+}
+
+// NewKMAC256 creates a new KMAC256 instance with the given key, output
+// length size in bytes and customization string S. The key should be at
+// least 32 bytes long to reach the 256-bit security strength.
+func NewKMAC256(key []byte, size int, S []byte) *KMAC {
+	return newKMAC(isha3.NewCShake256([]byte("KMAC"), S), key, size, false)
+}
+
+// NewKMACXOF128 creates a new KMACXOF128 instance with the given key and
+// customization string S. Sum appends 32 bytes of output.
+func NewKMACXOF128(key, S []byte) *KMAC {
+	return newKMAC(isha3.NewCShake128([]byte("KMAC"), S), key, 32, true)
+}
+
+// NewKMACXOF256 creates a new KMACXOF256 instance with the given key and
+// customization string S. Sum appends 64 bytes of output.
+func NewKMACXOF256(key, S []byte) *KMAC {
+	return newKMAC(isha3.NewCShake256([]byte("KMAC"), S), key, 64, true)
+}
+
+func newKMAC(s isha3.State, key []byte, size int, xof bool) *KMAC {
+	if size <= 0 {
+		panic("sha3: non-positive KMAC output length")
+	}
+	k := &KMAC{s: s, size: size, xof: xof}
+	k.key = isha3.Bytepad(isha3.AppendEncodeString(nil, key), s.BlockSize())
+	_, _ = k.s.Write(k.key)
+	return k
+}
+
+// Write absorbs more data into the state. It panics if called after Read.
+func (k *KMAC) Write(p []byte) (int, error) { return k.s.Write(p) }
+
+// finish absorbs the encoded output length.
+func (k *KMAC) finish(s *isha3.State) {
+	var l uint64
+	if !k.xof {
+		l = uint64(k.size) * 8
+	}
+	_, _ = s.Write(isha3.AppendRightEncode(nil, l))
+}
+
+// Sum appends Size() bytes of output to b and returns the resulting slice.
+// It does not change the underlying state. It panics if called after Read.
+func (k *KMAC) Sum(b []byte) []byte {
+	if !k.s.IsAbsorbing() {
+		panic("sha3: Sum after Read")
+	}
+	s := k.s
+	k.finish(&s)
+	ret, out := sliceForAppend(b, k.size)
+	_, _ = s.Read(out)
+	return ret
+}
+
+// Read squeezes more output from the state. It never returns an error, and
+// panics if the instance is not an extendable-output function.
+func (k *KMAC) Read(p []byte) (int, error) {
+	if !k.xof {
+		panic("sha3: Read from fixed-length KMAC")
+	}
+	if k.s.IsAbsorbing() {
+		k.finish(&k.s)
+	}
+	return k.s.Read(p)
+}
+
+// Reset restores the state right after absorbing the key.
+func (k *KMAC) Reset() {
+	k.s.Reset()
+	_, _ = k.s.Write(k.key)
+}
+
+// Size returns the number of bytes Sum will append.
+func (k *KMAC) Size() int { return k.size }
+
+// BlockSize returns the rate of the sponge.
+func (k *KMAC) BlockSize() int { return k.s.BlockSize() }
+
+// Clone returns a copy of the instance in its current state.
+func (k *KMAC) Clone() *KMAC { c := *k; return &c }
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a
+// slice with the contents of the given slice followed by that many bytes and a
+// second slice that aliases into it and contains only the extra bytes. If the
+// original slice has sufficient capacity then no allocation is performed.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,254 @@
+package sha3
+
+import (
+	"encoding/binary"
+	"hash"
+
+	isha3 "github.com/karalef/circl/internal/sha3"
+	"github.com/karalef/circl/simd/keccakf1600"
+)
+
+// ParallelHash is an instance of ParallelHash128 or ParallelHash256 as
+// defined in NIST SP 800-185. The input is split into blocks of a fixed
+// size which are hashed independently, so that on systems supporting it
+// several blocks are hashed at once using a multi-way Keccak-f[1600].
+//
+// Instances created with NewParallelHashXOF128 and NewParallelHashXOF256
+// are extendable-output functions that can be squeezed with Read.
+type ParallelHash struct {
+	s isha3.State // outer cSHAKE, after absorbing left_encode(B)
+
+	blockSize int    // B, the size of the input blocks in bytes
+	leafSize  int    // output length of the SHAKE applied to each block
+	rate      int    // rate of the SHAKE applied to each block
+	buf       []byte // pending input, shorter than lanes*blockSize
+	n         uint64 // number of blocks absorbed into s
+	lanes     int    // number of blocks hashed in parallel
+
+	size int  // output length in bytes
+	xof  bool // whether the output length is encoded as 0
+}
+
+var _ hash.Hash = (*ParallelHash)(nil)
+
+// NewParallelHash128 creates a new ParallelHash128 instance with block size
+// B in bytes, output length size in bytes and customization string S.
+func NewParallelHash128(B, size int, S []byte) *ParallelHash {
+	s := isha3.NewCShake128([]byte("ParallelHash"), S)
+	return newParallelHash(s, B, size, false, 32)
+}
+
+// NewParallelHash256 creates a new ParallelHash256 instance with block size
+// B in bytes, output length size in bytes and customization string S.
+func NewParallelHash256(B, size int, S []byte) *ParallelHash {
+	s := isha3.NewCShake256([]byte("ParallelHash"), S)
+	return newParallelHash(s, B, size, false, 64)
+}
+
+// NewParallelHashXOF128 creates a new ParallelHashXOF128 instance with block
+// size B in bytes and customization string S. Sum appends 32 bytes of output.
+func NewParallelHashXOF128(B int, S []byte) *ParallelHash {
+	s := isha3.NewCShake128([]byte("ParallelHash"), S)
+	return newParallelHash(s, B, 32, true, 32)
+}
+
+// NewParallelHashXOF256 creates a new ParallelHashXOF256 instance with block
+// size B in bytes and customization string S. Sum appends 64 bytes of output.
+func NewParallelHashXOF256(B int, S []byte) *ParallelHash {
+	s := isha3.NewCShake256([]byte("ParallelHash"), S)
+	return newParallelHash(s, B, 64, true, 64)
+}
+
+func newParallelHash(s isha3.State, B, size int, xof bool, leafSize int) *ParallelHash {
+	if B <= 0 {
+		panic("sha3: non-positive ParallelHash block size")
+	}
+	if size <= 0 {
+		panic("sha3: non-positive ParallelHash output length")
+	}
+	lanes := 1
+	if keccakf1600.IsEnabledX4() {
+		lanes = 4
+	} else if keccakf1600.IsEnabledX2() {
+		lanes = 2
+	}
+	p := &ParallelHash{
+		s:         s,
+		blockSize: B,
+		leafSize:  leafSize,
+		rate:      s.BlockSize(),
+		lanes:     lanes,
+		size:      size,
+		xof:       xof,
+	}
+	_, _ = p.s.Write(isha3.AppendLeftEncode(nil, uint64(B)))
+	return p
+}
+
+// Write absorbs more data into the state. It panics if called after Read.
+func (p *ParallelHash) Write(in []byte) (int, error) {
+	if !p.s.IsAbsorbing() {
+		panic("sha3: write to sponge after read")
+	}
+	written := len(in)
+	chunk := p.lanes * p.blockSize
+
+	if len(p.buf) > 0 {
+		todo := chunk - len(p.buf)
+		if todo > len(in) {
+			todo = len(in)
+		}
+		p.buf = append(p.buf, in[:todo]...)
+		in = in[todo:]
+		if len(p.buf) < chunk {
+			return written, nil
+		}
+		p.writeBlocks(&p.s, p.buf)
+		p.buf = p.buf[:0]
+	}
+
+	for len(in) >= chunk {
+		p.writeBlocks(&p.s, in[:chunk])
+		in = in[chunk:]
+	}
+	p.buf = append(p.buf, in...)
+	return written, nil
+}
+
+// writeBlocks hashes lanes full blocks and absorbs their digests into s.
+func (p *ParallelHash) writeBlocks(s *isha3.State, in []byte) {
+	var out [4 * 64]byte
+	switch p.lanes {
+	case 4:
+		var x4 keccakf1600.StateX4
+		a := x4.Initialize(false)
+		p.hashLanes(a, 4, x4.Permute, in, out[:])
+	case 2:
+		var x2 keccakf1600.StateX2
+		a := x2.Initialize(false)
+		p.hashLanes(a, 2, x2.Permute, in, out[:])
+	default:
+		p.hashBlock(in, out[:])
+	}
+	_, _ = s.Write(out[:p.lanes*p.leafSize])
+	p.n += uint64(p.lanes)
+}
+
+// hashLanes computes the SHAKE digests of n blocks of blockSize bytes at once
+// on the n-way interleaved state a, writing them consecutively to out.
+func (p *ParallelHash) hashLanes(a []uint64, n int, permute func(), in, out []byte) {
+	B, rate := p.blockSize, p.rate
+
+	offset := 0
+	for ; offset+rate <= B; offset += rate {
+		for j := 0; j < n; j++ {
+			blk := in[j*B+offset:]
+			for i := 0; i < rate/8; i++ {
+				a[i*n+j] ^= binary.LittleEndian.Uint64(blk[8*i:])
+			}
+		}
+		permute()
+	}
+
+	// Pad the remaining part of each block.
+	var last [168]byte
+	for j := 0; j < n; j++ {
+		for i := range last {
+			last[i] = 0
+		}
+		k := copy(last[:], in[j*B+offset:(j+1)*B])
+		last[k] ^= 0x1f
+		last[rate-1] ^= 0x80
+		for i := 0; i < rate/8; i++ {
+			a[i*n+j] ^= binary.LittleEndian.Uint64(last[8*i:])
+		}
+	}
+	permute()
+
+	for j := 0; j < n; j++ {
+		for i := 0; i < p.leafSize/8; i++ {
+			binary.LittleEndian.PutUint64(out[j*p.leafSize+8*i:], a[i*n+j])
+		}
+	}
+}
+
+// hashBlock writes the SHAKE digest of in to out.
+func (p *ParallelHash) hashBlock(in, out []byte) {
+	var h isha3.State
+	if p.leafSize == 32 {
+		h = isha3.NewShake128()
+	} else {
+		h = isha3.NewShake256()
+	}
+	_, _ = h.Write(in)
+	_, _ = h.Read(out[:p.leafSize])
+}
+
+// finish absorbs the pending blocks, which may be fewer than lanes and the
+// last of which may be partial, followed by the encoded lengths.
+func (p *ParallelHash) finish(s *isha3.State) {
+	var out [64]byte
+	n := p.n
+	for rem := p.buf; len(rem) > 0; n++ {
+		l := p.blockSize
+		if l > len(rem) {
+			l = len(rem)
+		}
+		p.hashBlock(rem[:l], out[:])
+		_, _ = s.Write(out[:p.leafSize])
+		rem = rem[l:]
+	}
+
+	var l uint64
+	if !p.xof {
+		l = uint64(p.size) * 8
+	}
+	_, _ = s.Write(isha3.AppendRightEncode(isha3.AppendRightEncode(nil, n), l))
+}
+
+// Sum appends Size() bytes of output to b and returns the resulting slice.
+// It does not change the underlying state. It panics if called after Read.
+func (p *ParallelHash) Sum(b []byte) []byte {
+	if !p.s.IsAbsorbing() {
+		panic("sha3: Sum after Read")
+	}
+	s := p.s
+	p.finish(&s)
+	ret, out := sliceForAppend(b, p.size)
+	_, _ = s.Read(out)
+	return ret
+}
+
+// Read squeezes more output from the state. It never returns an error, and
+// panics if the instance is not an extendable-output function.
+func (p *ParallelHash) Read(out []byte) (int, error) {
+	if !p.xof {
+		panic("sha3: Read from fixed-length ParallelHash")
+	}
+	if p.s.IsAbsorbing() {
+		p.finish(&p.s)
+		p.buf = p.buf[:0]
+	}
+	return p.s.Read(out)
+}
+
+// Reset restores the state right after absorbing the block size.
+func (p *ParallelHash) Reset() {
+	p.s.Reset()
+	_, _ = p.s.Write(isha3.AppendLeftEncode(nil, uint64(p.blockSize)))
+	p.buf = p.buf[:0]
+	p.n = 0
+}
+
+// Size returns the number of bytes Sum will append.
+func (p *ParallelHash) Size() int { return p.size }
+
+// BlockSize returns the size of the blocks hashed independently, B.
+func (p *ParallelHash) BlockSize() int { return p.blockSize }
+
+// Clone returns a copy of the instance in its current state.
+func (p *ParallelHash) Clone() *ParallelHash {
+	c := *p
+	c.buf = append([]byte(nil), p.buf...)
+	return &c
+}
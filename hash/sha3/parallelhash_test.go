@@ -0,0 +1,50 @@
+package sha3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karalef/circl/internal/test"
+)
+
+func TestParallelHashLanes(t *testing.T) {
+	msg := make([]byte, 5000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	for _, B := range []int{1, 7, 64, 136, 168, 200, 1000} {
+		for _, n := range []int{0, 1, 100, 999, 5000} {
+			var want []byte
+			for _, lanes := range []int{1, 2, 4} {
+				for _, writeSize := range []int{1, 13, 5000} {
+					h := NewParallelHash256(B, 64, nil)
+					h.lanes = lanes
+					for in := msg[:n]; len(in) > 0; {
+						l := writeSize
+						if l > len(in) {
+							l = len(in)
+						}
+						_, _ = h.Write(in[:l])
+						in = in[l:]
+					}
+
+					x := NewParallelHashXOF128(B, nil)
+					x.lanes = lanes
+					_, _ = x.Write(msg[:n])
+					c := x.Clone()
+					got := append(h.Sum(nil), c.Sum(nil)...)
+					out := make([]byte, 32)
+					_, _ = x.Read(out)
+					test.CheckOk(bytes.Equal(out, got[64:]), "Read differs from Sum", t)
+
+					if want == nil {
+						want = got
+					} else if !bytes.Equal(got, want) {
+						test.ReportError(t, got, want, B, n, lanes, writeSize)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package sha3
+
+import (
+	"hash"
+
+	isha3 "github.com/karalef/circl/internal/sha3"
+)
+
+// Sizes of the SHA-3 digests in bytes.
+const (
+	Size224 = 28
+	Size256 = 32
+	Size384 = 48
+	Size512 = 64
+)
+
+// SHA3 is an instance of a SHA-3 hash function.
+type SHA3 struct{ s isha3.State }
+
+var _ hash.Hash = (*SHA3)(nil)
+
+// New224 creates a new SHA3-224 hash.
+// Its generic security strength is 224 bits against preimage attacks,
+// and 112 bits against collision attacks.
+func New224() *SHA3 { return &SHA3{isha3.New224()} }
+
+// New256 creates a new SHA3-256 hash.
+// Its generic security strength is 256 bits against preimage attacks,
+// and 128 bits against collision attacks.
+func New256() *SHA3 { return &SHA3{isha3.New256()} }
+
+// New384 creates a new SHA3-384 hash.
+// Its generic security strength is 384 bits against preimage attacks,
+// and 192 bits against collision attacks.
+func New384() *SHA3 { return &SHA3{isha3.New384()} }
+
+// New512 creates a new SHA3-512 hash.
+// Its generic security strength is 512 bits against preimage attacks,
+// and 256 bits against collision attacks.
+func New512() *SHA3 { return &SHA3{isha3.New512()} }
+
+// Write absorbs more data into the hash's state. It never returns an error.
+func (h *SHA3) Write(p []byte) (int, error) { return h.s.Write(p) }
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (h *SHA3) Sum(b []byte) []byte { return h.s.Sum(b) }
+
+// Reset resets the hash to its initial state.
+func (h *SHA3) Reset() { h.s.Reset() }
+
+// Size returns the number of bytes Sum will append.
+func (h *SHA3) Size() int { return h.s.Size() }
+
+// BlockSize returns the rate of the sponge underlying the hash function.
+func (h *SHA3) BlockSize() int { return h.s.BlockSize() }
+
+// Clone returns a copy of the hash in its current state.
+func (h *SHA3) Clone() *SHA3 { c := *h; return &c }
+
+// MarshalBinary encodes the state of the hash.
+func (h *SHA3) MarshalBinary() ([]byte, error) { return h.s.MarshalBinary() }
+
+// AppendBinary appends the encoding of the state of the hash to b.
+func (h *SHA3) AppendBinary(b []byte) ([]byte, error) { return h.s.AppendBinary(b) }
+
+// UnmarshalBinary restores the state of the hash from the encoding produced
+// by MarshalBinary. It fails if the state is that of another function.
+func (h *SHA3) UnmarshalBinary(data []byte) error { return h.s.UnmarshalBinary(data) }
+
+// Sum224 returns the SHA3-224 digest of the data.
+func Sum224(data []byte) [Size224]byte { return isha3.Sum224(data) }
+
+// Sum256 returns the SHA3-256 digest of the data.
+func Sum256(data []byte) [Size256]byte { return isha3.Sum256(data) }
+
+// Sum384 returns the SHA3-384 digest of the data.
+func Sum384(data []byte) [Size384]byte { return isha3.Sum384(data) }
+
+// Sum512 returns the SHA3-512 digest of the data.
+func Sum512(data []byte) [Size512]byte { return isha3.Sum512(data) }
@@ -0,0 +1,214 @@
+package sha3_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"testing"
+
+	"github.com/karalef/circl/hash/sha3"
+	"github.com/karalef/circl/internal/test"
+)
+
+type marshalable interface {
+	hash.Hash
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+var vectors = []struct {
+	name string
+	new  func() marshalable
+	in   string
+	out  string
+}{
+	{"SHA3-224", func() marshalable { return sha3.New224() }, "", "6b4e03423667dbb73b6e15454f0eb1abd4597f9a1b078e3f5b5a6bc7"},
+	{"SHA3-256", func() marshalable { return sha3.New256() }, "", "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+	{"SHA3-256", func() marshalable { return sha3.New256() }, "abc", "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+	{"SHA3-384", func() marshalable { return sha3.New384() }, "", "0c63a75b845e4f7d01107d852e4c2485c51a50aaaa94fc61995e71bbee983a2ac3713831264adb47fb6bd1e058d5f004"},
+	{"SHA3-512", func() marshalable { return sha3.New512() }, "", "a69f73cca23a9ac5c8b567dc185a756e97c982164fe25859e0d1dcc1475c80a615b2123af1f5f94c11e3e9402c3ac558f500199d95b6d3e301758586281dcd26"},
+	{"SHAKE128", func() marshalable { return sha3.NewShake128() }, "", "7f9c2ba4e88f827d616045507605853ed73b8093f6efbc88eb1a6eacfa66ef26"},
+	{"SHAKE256", func() marshalable { return sha3.NewShake256() }, "", "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762fd75dc4ddd8c0f200cb05019d67b592f6fc821c49479ab48640292eacb3b7c4be"},
+	// RFC 9861 §5
+	{"TurboSHAKE128", func() marshalable { return sha3.NewTurboShake128(0x1f) }, "", "1e415f1c5983aff2169217277d17bb538cd945a397ddec541f1ce41af2c1b74c"},
+	{"TurboSHAKE256", func() marshalable { return sha3.NewTurboShake256(0x1f) }, "", "367a329dafea871c7802ec67f905ae13c57695dc2c6663c61035f59a18f8e7db11edc0e12e91ea60eb6b32df06dd7f002fbafabb6e13ec1cc20d995547600db0"},
+}
+
+func TestVectors(t *testing.T) {
+	for i, v := range vectors {
+		h := v.new()
+		_, _ = h.Write([]byte(v.in))
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != v.out {
+			test.ReportError(t, got, v.out, i, v.name)
+		}
+		test.CheckOk(h.Size() == len(v.out)/2, "bad size", t)
+	}
+}
+
+func TestSum(t *testing.T) {
+	msg := []byte("sum")
+	check := func(got []byte, h hash.Hash) {
+		t.Helper()
+		_, _ = h.Write(msg)
+		want := h.Sum(nil)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want)
+		}
+	}
+	s224, s256, s384, s512 := sha3.Sum224(msg), sha3.Sum256(msg), sha3.Sum384(msg), sha3.Sum512(msg)
+	check(s224[:], sha3.New224())
+	check(s256[:], sha3.New256())
+	check(s384[:], sha3.New384())
+	check(s512[:], sha3.New512())
+
+	out := make([]byte, 32)
+	sha3.ShakeSum128(out, msg)
+	check(out, sha3.NewShake128())
+	out = make([]byte, 64)
+	sha3.ShakeSum256(out, msg)
+	check(out, sha3.NewShake256())
+	out = make([]byte, 32)
+	sha3.TurboShakeSum128(out, msg, 0x0b)
+	check(out, sha3.NewTurboShake128(0x0b))
+	out = make([]byte, 64)
+	sha3.TurboShakeSum256(out, msg, 0x0b)
+	check(out, sha3.NewTurboShake256(0x0b))
+}
+
+func TestMarshal(t *testing.T) {
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	for _, v := range vectors {
+		// Split at various positions relative to the block size.
+		for _, split := range []int{0, 1, 71, 72, 136, 168, 169, 500, 1000} {
+			h := v.new()
+			_, _ = h.Write(msg[:split])
+			state, err := h.MarshalBinary()
+			test.CheckNoErr(t, err, "MarshalBinary failed")
+
+			h2 := v.new()
+			err = h2.UnmarshalBinary(state)
+			test.CheckNoErr(t, err, "UnmarshalBinary failed")
+
+			_, _ = h.Write(msg[split:])
+			_, _ = h2.Write(msg[split:])
+			got, want := h2.Sum(nil), h.Sum(nil)
+			if !bytes.Equal(got, want) {
+				test.ReportError(t, got, want, v.name, split)
+			}
+		}
+	}
+
+	// Squeezing state is restored too.
+	h := sha3.NewShake128()
+	_, _ = h.Write(msg)
+	out := make([]byte, 100)
+	_, _ = h.Read(out)
+	state, _ := h.MarshalBinary()
+	h2 := sha3.NewShake128()
+	err := h2.UnmarshalBinary(state)
+	test.CheckNoErr(t, err, "UnmarshalBinary failed")
+	got, want := make([]byte, 300), make([]byte, 300)
+	_, _ = h.Read(want)
+	_, _ = h2.Read(got)
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+
+	// The state of another function is rejected, even of the same rate.
+	for _, other := range []*sha3.SHAKE{
+		sha3.NewShake256(),
+		sha3.NewTurboShake128(0x1f),
+		sha3.NewCShake128([]byte("N"), nil),
+	} {
+		err = other.UnmarshalBinary(state)
+		test.CheckIsErr(t, err, "should fail due to another function")
+	}
+	s, _ := sha3.New256().MarshalBinary()
+	err = sha3.New512().UnmarshalBinary(s)
+	test.CheckIsErr(t, err, "should fail due to another function")
+
+	err = h2.UnmarshalBinary(state[:len(state)-1])
+	test.CheckIsErr(t, err, "should fail due to short state")
+	err = h2.UnmarshalBinary(append([]byte("xxxx"), state[4:]...))
+	test.CheckIsErr(t, err, "should fail due to bad magic")
+	state[5] = 100 // rate
+	err = h2.UnmarshalBinary(state)
+	test.CheckIsErr(t, err, "should fail due to bad rate")
+}
+
+func TestUnmarshalOffsets(t *testing.T) {
+	// The buffer offsets follow the flags at state[9] and state[10] and
+	// must describe a reachable state, else Write or Read index out of
+	// the buffer.
+	absorbing, _ := sha3.NewShake128().MarshalBinary()
+	h := sha3.NewShake128()
+	_, _ = h.Read(make([]byte, 10))
+	squeezing, _ := h.MarshalBinary()
+
+	for i, v := range []struct {
+		state      []byte
+		bufo, bufe byte
+		ok         bool
+	}{
+		{absorbing, 0, 0, true},
+		{absorbing, 0, 167, true},
+		{absorbing, 0, 168, false},
+		{absorbing, 1, 10, false},
+		{absorbing, 200, 0, false},
+		{squeezing, 0, 168, true},
+		{squeezing, 167, 168, true},
+		{squeezing, 168, 168, false},
+		{squeezing, 10, 100, false},
+		{squeezing, 0, 200, false},
+	} {
+		state := append([]byte(nil), v.state...)
+		state[9], state[10] = v.bufo, v.bufe
+		err := sha3.NewShake128().UnmarshalBinary(state)
+		if (err == nil) != v.ok {
+			test.ReportError(t, err, v.ok, i, v.bufo, v.bufe)
+		}
+	}
+}
+
+func TestShakeAPI(t *testing.T) {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte("clone"))
+	c := h.Clone()
+	a, b := make([]byte, 200), make([]byte, 200)
+	_, _ = h.Read(a)
+	_, _ = c.Read(b)
+	if !bytes.Equal(a, b) {
+		test.ReportError(t, a, b)
+	}
+
+	err := test.CheckPanic(func() { _ = h.Sum(nil) })
+	test.CheckNoErr(t, err, "should panic due to Sum after Read")
+	err = test.CheckPanic(func() { _, _ = h.Write(nil) })
+	test.CheckNoErr(t, err, "should panic due to Write after Read")
+	err = test.CheckPanic(func() { sha3.NewTurboShake128(0) })
+	test.CheckNoErr(t, err, "should panic due to bad domain separation byte")
+
+	h.Reset()
+	_, _ = h.Write([]byte("clone"))
+	_, _ = h.Read(b)
+	if !bytes.Equal(a, b) {
+		test.ReportError(t, a, b)
+	}
+}
+
+func Example_shake() {
+	// A hash needs to be 64 bytes long to have 256-bit collision resistance.
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte("some data to hash"))
+	out := make([]byte, 64)
+	_, _ = h.Read(out)
+	fmt.Printf("%x\n", out)
+	// Output: 0f65fe41fc353e52c55667bb9e2b27bfcc8476f2c413e9437d272ee3194a4e3146d05ec04a25d16b8f577c19b82d16b1424c3e022e783d2b4da98de3658d363d
+}
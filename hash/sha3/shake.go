@@ -0,0 +1,128 @@
+package sha3
+
+import (
+	"hash"
+	"io"
+
+	isha3 "github.com/karalef/circl/internal/sha3"
+)
+
+// SHAKE is an instance of a SHAKE or TurboSHAKE extendable-output function.
+//
+// As a hash.Hash, Sum appends 32 bytes of output for the 128-bit instances
+// and 64 bytes for the 256-bit instances, which is the output length needed
+// to reach their full security strength.
+type SHAKE struct{ s isha3.State }
+
+var (
+	_ hash.Hash = (*SHAKE)(nil)
+	_ io.Reader = (*SHAKE)(nil)
+)
+
+// NewShake128 creates a new SHAKE128 instance.
+// Its generic security strength is 128 bits against all attacks if at
+// least 32 bytes of its output are used.
+func NewShake128() *SHAKE { return &SHAKE{isha3.NewShake128()} }
+
+// NewShake256 creates a new SHAKE256 instance.
+// Its generic security strength is 256 bits against all attacks if
+// at least 64 bytes of its output are used.
+func NewShake256() *SHAKE { return &SHAKE{isha3.NewShake256()} }
+
+// NewTurboShake128 creates a new TurboSHAKE128 instance.
+// Its generic security strength is 128 bits against all attacks if at
+// least 32 bytes of its output are used.
+//
+// D is the domain separation byte and must be between 0x01 and 0x7f
+// inclusive, otherwise it panics.
+func NewTurboShake128(D byte) *SHAKE { return &SHAKE{isha3.NewTurboShake128(D)} }
+
+// NewTurboShake256 creates a new TurboSHAKE256 instance.
+// Its generic security strength is 256 bits against all attacks if
+// at least 64 bytes of its output are used.
+//
+// D is the domain separation byte and must be between 0x01 and 0x7f
+// inclusive, otherwise it panics.
+func NewTurboShake256(D byte) *SHAKE { return &SHAKE{isha3.NewTurboShake256(D)} }
+
+// NewCShake128 creates a new cSHAKE128 instance with function-name N and
+// customization string S as defined in NIST SP 800-185. If both N and S are
+// empty, it is equivalent to SHAKE128. Reset restores the state right after
+// absorbing N and S.
+func NewCShake128(N, S []byte) *SHAKE { return &SHAKE{isha3.NewCShake128(N, S)} }
+
+// NewCShake256 creates a new cSHAKE256 instance with function-name N and
+// customization string S as defined in NIST SP 800-185. If both N and S are
+// empty, it is equivalent to SHAKE256. Reset restores the state right after
+// absorbing N and S.
+func NewCShake256(N, S []byte) *SHAKE { return &SHAKE{isha3.NewCShake256(N, S)} }
+
+// Write absorbs more data into the state. It panics if called after Read.
+func (h *SHAKE) Write(p []byte) (int, error) { return h.s.Write(p) }
+
+// Read squeezes more output from the state. It never returns an error.
+func (h *SHAKE) Read(p []byte) (int, error) { return h.s.Read(p) }
+
+// Sum appends Size() bytes of output to b and returns the resulting slice.
+// It does not change the underlying state. It panics if called after Read.
+func (h *SHAKE) Sum(b []byte) []byte {
+	if !h.s.IsAbsorbing() {
+		panic("sha3: Sum after Read")
+	}
+	return h.s.Sum(b)
+}
+
+// Reset resets the state to its initial value.
+func (h *SHAKE) Reset() { h.s.Reset() }
+
+// Size returns the number of bytes Sum will append.
+func (h *SHAKE) Size() int { return h.s.Size() }
+
+// BlockSize returns the rate of the sponge.
+func (h *SHAKE) BlockSize() int { return h.s.BlockSize() }
+
+// Clone returns a copy of the instance in its current state.
+func (h *SHAKE) Clone() *SHAKE { c := *h; return &c }
+
+// MarshalBinary encodes the state, including the output buffered for
+// subsequent calls to Read.
+func (h *SHAKE) MarshalBinary() ([]byte, error) { return h.s.MarshalBinary() }
+
+// AppendBinary appends the encoding of the state to b.
+func (h *SHAKE) AppendBinary(b []byte) ([]byte, error) { return h.s.AppendBinary(b) }
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary. It fails if the state is that of another function. For
+// cSHAKE, h must have been created with the same N and S for Reset to
+// behave as expected.
+func (h *SHAKE) UnmarshalBinary(data []byte) error { return h.s.UnmarshalBinary(data) }
+
+// ShakeSum128 writes an arbitrary-length digest of data into hash.
+func ShakeSum128(hash, data []byte) { isha3.ShakeSum128(hash, data) }
+
+// ShakeSum256 writes an arbitrary-length digest of data into hash.
+func ShakeSum256(hash, data []byte) { isha3.ShakeSum256(hash, data) }
+
+// TurboShakeSum128 writes an arbitrary-length TurboSHAKE128 digest of data
+// with domain separation byte D into hash.
+func TurboShakeSum128(hash, data []byte, D byte) { isha3.TurboShakeSum128(hash, data, D) }
+
+// TurboShakeSum256 writes an arbitrary-length TurboSHAKE256 digest of data
+// with domain separation byte D into hash.
+func TurboShakeSum256(hash, data []byte, D byte) { isha3.TurboShakeSum256(hash, data, D) }
+
+// CShakeSum128 writes an arbitrary-length cSHAKE128 digest of data with
+// function-name N and customization string S into hash.
+func CShakeSum128(hash, data, N, S []byte) {
+	h := isha3.NewCShake128(N, S)
+	_, _ = h.Write(data)
+	_, _ = h.Read(hash)
+}
+
+// CShakeSum256 writes an arbitrary-length cSHAKE256 digest of data with
+// function-name N and customization string S into hash.
+func CShakeSum256(hash, data, N, S []byte) {
+	h := isha3.NewCShake256(N, S)
+	_, _ = h.Write(data)
+	_, _ = h.Read(hash)
+}
@@ -0,0 +1,152 @@
+package sha3_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/karalef/circl/hash/sha3"
+	"github.com/karalef/circl/internal/test"
+)
+
+// Sample values from NIST SP 800-185.
+// https://csrc.nist.gov/projects/cryptographic-standards-and-guidelines/example-values
+
+func seq(from, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(from + i)
+	}
+	return b
+}
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestCShake(t *testing.T) {
+	for i, v := range []struct {
+		new  func(N, S []byte) *sha3.SHAKE
+		in   []byte
+		S    string
+		want string
+	}{
+		{sha3.NewCShake128, seq(0, 4), "Email Signature", "c1c36925b6409a04f1b504fcbca9d82b4017277cb5ed2b2065fc1d3814d5aaf5"},
+		{sha3.NewCShake128, seq(0, 200), "Email Signature", "c5221d50e4f822d96a2e8881a961420f294b7b24fe3d2094baed2c6524cc166b"},
+		{sha3.NewCShake256, seq(0, 4), "Email Signature", "d008828e2b80ac9d2218ffee1d070c48b8e4c87bff32c9699d5b6896eee0edd164020e2be0560858d9c00c037e34a96937c561a74c412bb4c746469527281c8c"},
+		{sha3.NewCShake256, seq(0, 200), "Email Signature", "07dc27b11e51fbac75bc7b3c1d983e8b4b85fb1defaf218912ac86430273091727f42b17ed1df63e8ec118f04b23633c1dfb1574c8fb55cb45da8e25afb092bb"},
+	} {
+		h := v.new(nil, []byte(v.S))
+		_, _ = h.Write(v.in)
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != v.want {
+			test.ReportError(t, got, v.want, i)
+		}
+
+		// Reset absorbs N and S again.
+		h.Reset()
+		_, _ = h.Write(v.in)
+		got = hex.EncodeToString(h.Sum(nil))
+		if got != v.want {
+			test.ReportError(t, got, v.want, i)
+		}
+	}
+
+	// Empty N and S give plain SHAKE.
+	a, b := make([]byte, 32), make([]byte, 32)
+	sha3.CShakeSum128(a, []byte("abc"), nil, nil)
+	sha3.ShakeSum128(b, []byte("abc"))
+	if !bytes.Equal(a, b) {
+		test.ReportError(t, a, b)
+	}
+}
+
+func TestKMAC(t *testing.T) {
+	key := seq(0x40, 32)
+	for i, v := range []struct {
+		new  func(key []byte, size int, S []byte) *sha3.KMAC
+		in   []byte
+		S    string
+		want string
+	}{
+		{sha3.NewKMAC128, seq(0, 4), "", "e5780b0d3ea6f7d3a429c5706aa43a00fadbd7d49628839e3187243f456ee14e"},
+		{sha3.NewKMAC128, seq(0, 4), "My Tagged Application", "3b1fba963cd8b0b59e8c1a6d71888b7143651af8ba0a7070c0979e2811324aa5"},
+		{sha3.NewKMAC128, seq(0, 200), "My Tagged Application", "1f5b4e6cca02209e0dcb5ca635b89a15e271ecc760071dfd805faa38f9729230"},
+		{sha3.NewKMAC256, seq(0, 4), "My Tagged Application", "20c570c31346f703c9ac36c61c03cb64c3970d0cfc787e9b79599d273a68d2f7f69d4cc3de9d104a351689f27cf6f5951f0103f33f4f24871024d9c27773a8dd"},
+		{sha3.NewKMAC256, seq(0, 200), "", "75358cf39e41494e949707927cee0af20a3ff553904c86b08f21cc414bcfd691589d27cf5e15369cbbff8b9a4c2eb17800855d0235ff635da82533ec6b759b69"},
+		{sha3.NewKMAC256, seq(0, 200), "My Tagged Application", "b58618f71f92e1d56c1b8c55ddd7cd188b97b4ca4d99831eb2699a837da2e4d970fbacfde50033aea585f1a2708510c32d07880801bd182898fe476876fc8965"},
+	} {
+		h := v.new(key, len(v.want)/2, []byte(v.S))
+		_, _ = h.Write(v.in)
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != v.want {
+			test.ReportError(t, got, v.want, i)
+		}
+
+		h.Reset()
+		_, _ = h.Write(v.in)
+		got = hex.EncodeToString(h.Sum(nil))
+		if got != v.want {
+			test.ReportError(t, got, v.want, i)
+		}
+	}
+
+	h := sha3.NewKMACXOF128(key, nil)
+	_, _ = h.Write(seq(0, 4))
+	got := make([]byte, 32)
+	_, _ = h.Read(got)
+	want := fromHex("cd83740bbd92ccc8cf032b1481a0f4460e7ca9dd12b08a0c4031178bacd6ec35")
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+
+	err := test.CheckPanic(func() { _, _ = sha3.NewKMAC128(key, 32, nil).Read(got) })
+	test.CheckNoErr(t, err, "should panic due to Read from fixed-length KMAC")
+	err = test.CheckPanic(func() { _ = h.Sum(nil) })
+	test.CheckNoErr(t, err, "should panic due to Sum after Read")
+}
+
+func TestTupleHash(t *testing.T) {
+	t1, t2, t3 := seq(0, 3), seq(0x10, 6), seq(0x20, 9)
+	for i, v := range []struct {
+		tuple [][]byte
+		S     string
+		want  string
+	}{
+		{[][]byte{t1, t2}, "", "c5d8786c1afb9b82111ab34b65b2c0048fa64e6d48e263264ce1707d3ffc8ed1"},
+		{[][]byte{t1, t2}, "My Tuple App", "75cdb20ff4db1154e841d758e24160c54bae86eb8c13e7f5f40eb35588e96dfb"},
+		{[][]byte{t1, t2, t3}, "My Tuple App", "e60f202c89a2631eda8d4c588ca5fd07f39e5151998deccf973adb3804bb6e84"},
+	} {
+		got := hex.EncodeToString(sha3.TupleHash128(v.tuple, 32, []byte(v.S)))
+		if got != v.want {
+			test.ReportError(t, got, v.want, i)
+		}
+	}
+
+	// The encoding of the elements is unambiguous.
+	a := sha3.TupleHash256([][]byte{[]byte("ab"), []byte("c")}, 64, nil)
+	b := sha3.TupleHash256([][]byte{[]byte("a"), []byte("bc")}, 64, nil)
+	test.CheckOk(!bytes.Equal(a, b), "tuples should have different digests", t)
+}
+
+func TestParallelHash(t *testing.T) {
+	in := append(append(seq(0, 8), seq(0x10, 8)...), seq(0x20, 8)...)
+	for i, v := range []struct {
+		S    string
+		want string
+	}{
+		{"", "ba8dc1d1d979331d3f813603c67f72609ab5e44b94a0b8f9af46514454a2b4f5"},
+		{"Parallel Data", "fc484dcb3f84dceedc353438151bee58157d6efed0445a81f165e495795b7206"},
+	} {
+		h := sha3.NewParallelHash128(8, 32, []byte(v.S))
+		_, _ = h.Write(in)
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != v.want {
+			test.ReportError(t, got, v.want, i)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package sha3
+
+import (
+	isha3 "github.com/karalef/circl/internal/sha3"
+)
+
+// TupleHash is an instance of TupleHash128 or TupleHash256 as defined in
+// NIST SP 800-185. It hashes a sequence of byte strings in an unambiguous
+// way: the tuples ("ab", "c") and ("a", "bc") have different digests.
+//
+// Instances created with NewTupleHashXOF128 and NewTupleHashXOF256 are
+// extendable-output functions that can be squeezed with Read.
+type TupleHash struct {
+	s    isha3.State
+	size int  // output length in bytes
+	xof  bool // whether the output length is encoded as 0
+}
+
+// NewTupleHash128 creates a new TupleHash128 instance with output length
+// size in bytes and customization string S.
+func NewTupleHash128(size int, S []byte) *TupleHash {
+	return newTupleHash(isha3.NewCShake128([]byte("TupleHash"), S), size, false)
+}
+
+// NewTupleHash256 creates a new TupleHash256 instance with output length
+// size in bytes and customization string S.
+func NewTupleHash256(size int, S []byte) *TupleHash {
+	return newTupleHash(isha3.NewCShake256([]byte("TupleHash"), S), size, false)
+}
+
+// NewTupleHashXOF128 creates a new TupleHashXOF128 instance with
+// customization string S. Sum appends 32 bytes of output.
+func NewTupleHashXOF128(S []byte) *TupleHash {
+	return newTupleHash(isha3.NewCShake128([]byte("TupleHash"), S), 32, true)
+}
+
+// NewTupleHashXOF256 creates a new TupleHashXOF256 instance with
+// customization string S. Sum appends 64 bytes of output.
+func NewTupleHashXOF256(S []byte) *TupleHash {
+	return newTupleHash(isha3.NewCShake256([]byte("TupleHash"), S), 64, true)
+}
+
+func newTupleHash(s isha3.State, size int, xof bool) *TupleHash {
+	if size <= 0 {
+		panic("sha3: non-positive TupleHash output length")
+	}
+	return &TupleHash{s: s, size: size, xof: xof}
+}
+
+// WriteElement absorbs the next element of the tuple. It panics if called
+// after Read.
+func (t *TupleHash) WriteElement(x []byte) {
+	_, _ = t.s.Write(isha3.AppendEncodeString(nil, x))
+}
+
+func (t *TupleHash) finish(s *isha3.State) {
+	var l uint64
+	if !t.xof {
+		l = uint64(t.size) * 8
+	}
+	_, _ = s.Write(isha3.AppendRightEncode(nil, l))
+}
+
+// Sum appends Size() bytes of output to b and returns the resulting slice.
+// It does not change the underlying state. It panics if called after Read.
+func (t *TupleHash) Sum(b []byte) []byte {
+	if !t.s.IsAbsorbing() {
+		panic("sha3: Sum after Read")
+	}
+	s := t.s
+	t.finish(&s)
+	ret, out := sliceForAppend(b, t.size)
+	_, _ = s.Read(out)
+	return ret
+}
+
+// Read squeezes more output from the state. It never returns an error, and
+// panics if the instance is not an extendable-output function.
+func (t *TupleHash) Read(p []byte) (int, error) {
+	if !t.xof {
+		panic("sha3: Read from fixed-length TupleHash")
+	}
+	if t.s.IsAbsorbing() {
+		t.finish(&t.s)
+	}
+	return t.s.Read(p)
+}
+
+// Reset discards all the elements written.
+func (t *TupleHash) Reset() { t.s.Reset() }
+
+// Size returns the number of bytes Sum will append.
+func (t *TupleHash) Size() int { return t.size }
+
+// Clone returns a copy of the instance in its current state.
+func (t *TupleHash) Clone() *TupleHash { c := *t; return &c }
+
+// TupleHash128 returns the size-byte TupleHash128 digest of the tuple with
+// customization string S.
+func TupleHash128(tuple [][]byte, size int, S []byte) []byte {
+	t := NewTupleHash128(size, S)
+	for _, x := range tuple {
+		t.WriteElement(x)
+	}
+	return t.Sum(nil)
+}
+
+// TupleHash256 returns the size-byte TupleHash256 digest of the tuple with
+// customization string S.
+func TupleHash256(tuple [][]byte, size int, S []byte) []byte {
+	t := NewTupleHash256(size, S)
+	for _, x := range tuple {
+		t.WriteElement(x)
+	}
+	return t.Sum(nil)
+}
@@ -0,0 +1,238 @@
+// Package acvp runs the test vectors of the NIST Automated Cryptographic
+// Validation Protocol (ACVP) against the implementations of this module.
+//
+// A vector set is made of a prompt file, holding the inputs of the test
+// cases, and of an expected results file. Both are JSON documents in the
+// format of the ACVP server, optionally wrapped in an array after the
+// {"acvVersion": ...} object. The supported algorithms are
+//
+//	SHA3-224, SHA3-256, SHA3-384, SHA3-512: AFT and MCT tests
+//	SHAKE-128, SHAKE-256: AFT, VOT and MCT tests, through xof.ID
+//	Ascon: Hash256, XOF128, CXOF128 and AEAD128 modes
+//	Kyber: keyGen and encapDecap modes, through kem.Scheme
+//	Dilithium: keyGen, sigGen and sigVer modes, through sign.Scheme
+//
+// FIPS 203 (ML-KEM) and FIPS 204 (ML-DSA) are not implemented by this
+// module, so the vector sets of the ML-KEM and ML-DSA algorithms are not
+// supported. Kyber and Dilithium are not ACVP algorithms: they name vector
+// sets in the format of ML-KEM and ML-DSA whose parameter sets are the
+// round 3 ones registered in kem/schemes and sign/schemes, such as Kyber512
+// and Dilithium2, and which are used to detect changes of their results.
+//
+// The test cases with inputs the APIs can't take, such as messages whose
+// length is not a whole number of bytes, are skipped, as are the groups of
+// unregistered parameter sets. A vector set of which no test case is run is
+// reported as skipped, never as passed.
+//
+// https://pages.nist.gov/ACVP/
+package acvp
+
+import (
+	"bytes"
+	"compress/flate"
+	hexa "encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// vectorSet is the part of the prompt and expected results files that is
+// common to all the algorithms.
+type vectorSet struct {
+	VsID       int             `json:"vsId"`
+	Algorithm  string          `json:"algorithm"`
+	Mode       string          `json:"mode"`
+	Revision   string          `json:"revision"`
+	TestGroups json.RawMessage `json:"testGroups"`
+}
+
+// results holds the expected results of the test cases by tcId.
+type results struct {
+	byID map[int]json.RawMessage
+	run  int // test cases whose expected result was read
+}
+
+// get decodes the expected result of the test case into v, and reports
+// an error if it is missing. The handlers call it once they are about to
+// run the test case, which counts it as run.
+func (r *results) get(t *testing.T, tcID int, v interface{}) bool {
+	t.Helper()
+	r.run++
+	raw, ok := r.byID[tcID]
+	if !ok {
+		t.Errorf("tcId %d: no expected result", tcID)
+		return false
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		t.Errorf("tcId %d: %v", tcID, err)
+		return false
+	}
+	return true
+}
+
+// handler runs the test groups of a vector set.
+type handler func(t *testing.T, vs *vectorSet, r *results)
+
+var handlers = map[string]handler{
+	"SHA3-224":         runSHA3,
+	"SHA3-256":         runSHA3,
+	"SHA3-384":         runSHA3,
+	"SHA3-512":         runSHA3,
+	"SHAKE-128":        runSHAKE,
+	"SHAKE-256":        runSHAKE,
+	"Ascon/Hash256":    runAsconHash,
+	"Ascon/XOF128":     runAsconHash,
+	"Ascon/CXOF128":    runAsconHash,
+	"Ascon/AEAD128":    runAsconAEAD,
+	"Kyber/keyGen":     runKEMKeyGen,
+	"Kyber/encapDecap": runKEMEncapDecap,
+	"Dilithium/keyGen": runSignKeyGen,
+	"Dilithium/sigGen": runSignSigGen,
+	"Dilithium/sigVer": runSignSigVer,
+}
+
+// Run runs the vector set of the prompt and checks the results against the
+// expected ones.
+func Run(t *testing.T, prompt, expected io.Reader) {
+	t.Helper()
+	vs, err := decode(prompt)
+	if err != nil {
+		t.Fatalf("prompt: %v", err)
+	}
+	ex, err := decode(expected)
+	if err != nil {
+		t.Fatalf("expected results: %v", err)
+	}
+	if vs.VsID != ex.VsID || vs.Algorithm != ex.Algorithm || vs.Mode != ex.Mode {
+		t.Fatalf("expected results of vector set %d %s, not %d %s",
+			vs.VsID, vs.Algorithm, ex.VsID, ex.Algorithm)
+	}
+
+	name := vs.Algorithm
+	if vs.Mode != "" {
+		name += "/" + vs.Mode
+	}
+	h, ok := handlers[name]
+	if !ok {
+		t.Skipf("unsupported algorithm %s", name)
+	}
+
+	var groups []struct {
+		TgID  int               `json:"tgId"`
+		Tests []json.RawMessage `json:"tests"`
+	}
+	if err := json.Unmarshal(ex.TestGroups, &groups); err != nil {
+		t.Fatalf("expected results: %v", err)
+	}
+	r := &results{byID: make(map[int]json.RawMessage)}
+	for _, g := range groups {
+		for _, raw := range g.Tests {
+			var tc struct {
+				TcID int `json:"tcId"`
+			}
+			if err := json.Unmarshal(raw, &tc); err != nil {
+				t.Fatalf("expected results: %v", err)
+			}
+			r.byID[tc.TcID] = raw
+		}
+	}
+
+	h(t, vs, r)
+	if r.run == 0 {
+		t.Skipf("no test case of vector set %d %s was run", vs.VsID, name)
+	}
+	t.Logf("ran %d of %d test cases", r.run, len(r.byID))
+}
+
+// RunFiles is like Run, but reads the prompt and expected results from the
+// named files, which are decompressed if their names end in ".deflate".
+func RunFiles(t *testing.T, prompt, expected string) {
+	t.Helper()
+	p, err := readFile(prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := readFile(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Run(t, bytes.NewReader(p), bytes.NewReader(e))
+}
+
+func readFile(name string) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(name, ".deflate") {
+		r = flate.NewReader(f)
+	}
+	return io.ReadAll(r)
+}
+
+// decode decodes a vector set, skipping the leading version object if the
+// document is an array.
+func decode(r io.Reader) (*vectorSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var arr []json.RawMessage
+		if err := json.Unmarshal(data, &arr); err != nil {
+			return nil, err
+		}
+		if len(arr) == 0 {
+			return nil, fmt.Errorf("acvp: empty document")
+		}
+		data = arr[len(arr)-1]
+	}
+	vs := new(vectorSet)
+	if err := json.Unmarshal(data, vs); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+// groups decodes the test groups of the vector set into v, a pointer to a
+// slice.
+func groups(t *testing.T, vs *vectorSet, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(vs.TestGroups, v); err != nil {
+		t.Fatalf("prompt: %v", err)
+	}
+}
+
+type hex []byte
+
+func (h *hex) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := hexa.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = hex(decoded)
+	return nil
+}
+
+// wholeBytes returns whether a length in bits is that of the data in bytes.
+// The lengths of ACVP are in bits, and are omitted in some revisions.
+func wholeBytes(bits *int, data []byte) bool {
+	return bits == nil || *bits == 8*len(data)
+}
+
+func check(t *testing.T, tcID int, what string, got, want []byte) {
+	t.Helper()
+	if !bytes.Equal(got, want) {
+		t.Errorf("tcId %d: %s\ngot:  %x\nwant: %x", tcID, what, got, want)
+	}
+}
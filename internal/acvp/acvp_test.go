@@ -0,0 +1,55 @@
+package acvp_test
+
+import (
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karalef/circl/internal/acvp"
+)
+
+// The vectors of testdata are small sets in the format of the ACVP server.
+// The SHA-3 and SHAKE results were computed with the crypto/sha3 package of
+// the Go standard library and the Ascon ones are taken from the reference
+// KATs. The Kyber and Dilithium sets hold results of Kyber512 and Dilithium2
+// computed by this module, so they only check that the results don't
+// change.
+var dir = flag.String("acvp", "testdata", "directory of the `*.prompt.json` and `*.expected.json` files to run")
+
+func TestACVP(t *testing.T) {
+	prompts, err := filepath.Glob(filepath.Join(*dir, "*.prompt.json*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prompts) == 0 {
+		t.Fatalf("no vector sets in %s", *dir)
+	}
+	for _, prompt := range prompts {
+		expected := strings.Replace(prompt, ".prompt.json", ".expected.json", 1)
+		name := strings.SplitN(filepath.Base(prompt), ".", 2)[0]
+		t.Run(name, func(t *testing.T) { acvp.RunFiles(t, prompt, expected) })
+	}
+}
+
+func TestNothingRun(t *testing.T) {
+	// The sets of FIPS 203 and 204, and those of which no parameter set is
+	// registered, must not pass without checking anything.
+	for _, v := range []struct{ algorithm, parameterSet string }{
+		{"ML-KEM", "ML-KEM-512"},
+		{"Kyber", "ML-KEM-768"},
+	} {
+		prompt := `{"vsId": 1, "algorithm": "` + v.algorithm + `", "mode": "keyGen", "testGroups": [
+			{"tgId": 1, "parameterSet": "` + v.parameterSet + `", "tests": [{"tcId": 1, "d": "00", "z": "00"}]}]}`
+		expected := `{"vsId": 1, "algorithm": "` + v.algorithm + `", "mode": "keyGen", "testGroups": [
+			{"tgId": 1, "tests": [{"tcId": 1, "ek": "00", "dk": "00"}]}]}`
+		var skipped bool
+		t.Run(v.algorithm, func(t *testing.T) {
+			defer func() { skipped = t.Skipped() }()
+			acvp.Run(t, strings.NewReader(prompt), strings.NewReader(expected))
+		})
+		if !skipped {
+			t.Errorf("%s %s: vector set not skipped", v.algorithm, v.parameterSet)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package acvp
+
+import (
+	"testing"
+
+	"github.com/karalef/circl/cipher/ascon"
+)
+
+func runAsconHash(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []struct {
+		Tests []struct {
+			TcID   int  `json:"tcId"`
+			Msg    hex  `json:"msg"`
+			Len    *int `json:"len"`
+			CS     hex  `json:"cs"`
+			CSLen  *int `json:"csLen"`
+			OutLen int  `json:"outLen"`
+		} `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		for _, tc := range tg.Tests {
+			if !wholeBytes(tc.Len, tc.Msg) || !wholeBytes(tc.CSLen, tc.CS) || tc.OutLen%8 != 0 {
+				continue
+			}
+			var want struct {
+				MD hex `json:"md"`
+			}
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+
+			var got []byte
+			switch vs.Mode {
+			case "Hash256":
+				md := ascon.Hash256Sum(tc.Msg)
+				got = md[:]
+			case "XOF128", "CXOF128":
+				x := ascon.NewXOF128()
+				if vs.Mode == "CXOF128" {
+					var err error
+					if x, err = ascon.NewCXOF128(tc.CS); err != nil {
+						t.Errorf("tcId %d: %v", tc.TcID, err)
+						continue
+					}
+				}
+				_, _ = x.Write(tc.Msg)
+				got = make([]byte, tc.OutLen/8)
+				_, _ = x.Read(got)
+			}
+			check(t, tc.TcID, "md", got, want.MD)
+		}
+	}
+}
+
+func runAsconAEAD(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []struct {
+		Direction            string `json:"direction"`
+		SupportsNonceMasking bool   `json:"supportsNonceMasking"`
+		TagLen               *int   `json:"tagLen"`
+		Tests                []struct {
+			TcID       int  `json:"tcId"`
+			Key        hex  `json:"key"`
+			Nonce      hex  `json:"nonce"`
+			PT         hex  `json:"pt"`
+			PayloadLen *int `json:"payloadLen"`
+			AD         hex  `json:"ad"`
+			ADLen      *int `json:"adLen"`
+			CT         hex  `json:"ct"`
+			Tag        hex  `json:"tag"`
+			TagLen     *int `json:"tagLen"`
+		} `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		if tg.SupportsNonceMasking {
+			continue
+		}
+		for _, tc := range tg.Tests {
+			tagLen := tc.TagLen
+			if tagLen == nil {
+				tagLen = tg.TagLen
+			}
+			if tagLen != nil && *tagLen != 8*ascon.TagSize ||
+				!wholeBytes(tc.ADLen, tc.AD) ||
+				tg.Direction == "encrypt" && !wholeBytes(tc.PayloadLen, tc.PT) ||
+				tg.Direction == "decrypt" && !wholeBytes(tc.PayloadLen, tc.CT) {
+				continue
+			}
+			a, err := ascon.New(tc.Key, ascon.AsconAEAD128)
+			if err != nil {
+				t.Errorf("tcId %d: %v", tc.TcID, err)
+				continue
+			}
+
+			switch tg.Direction {
+			case "encrypt":
+				var want struct {
+					CT  hex `json:"ct"`
+					Tag hex `json:"tag"`
+				}
+				if !r.get(t, tc.TcID, &want) {
+					continue
+				}
+				ct := a.Seal(nil, tc.Nonce, tc.PT, tc.AD)
+				check(t, tc.TcID, "ct", ct[:len(tc.PT)], want.CT)
+				check(t, tc.TcID, "tag", ct[len(tc.PT):], want.Tag)
+			case "decrypt":
+				var want struct {
+					PT         hex   `json:"pt"`
+					TestPassed *bool `json:"testPassed"`
+				}
+				if !r.get(t, tc.TcID, &want) {
+					continue
+				}
+				pt, err := a.Open(nil, tc.Nonce, append(tc.CT, tc.Tag...), tc.AD)
+				passed := want.TestPassed == nil || *want.TestPassed
+				switch {
+				case passed && err != nil:
+					t.Errorf("tcId %d: %v", tc.TcID, err)
+				case !passed && err == nil:
+					t.Errorf("tcId %d: invalid ciphertext opened", tc.TcID)
+				case passed:
+					check(t, tc.TcID, "pt", pt, want.PT)
+				}
+			default:
+				t.Errorf("tcId %d: unsupported direction %s", tc.TcID, tg.Direction)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package acvp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/karalef/circl/internal/sha3"
+	"github.com/karalef/circl/xof"
+)
+
+var sha3Hashes = map[string]func() sha3.State{
+	"SHA3-224": sha3.New224,
+	"SHA3-256": sha3.New256,
+	"SHA3-384": sha3.New384,
+	"SHA3-512": sha3.New512,
+}
+
+var shakeIDs = map[string]xof.ID{
+	"SHAKE-128": xof.SHAKE128,
+	"SHAKE-256": xof.SHAKE256,
+}
+
+type hashTest struct {
+	TcID   int  `json:"tcId"`
+	Msg    hex  `json:"msg"`
+	Len    *int `json:"len"`
+	OutLen int  `json:"outLen"`
+}
+
+type hashResult struct {
+	MD           hex `json:"md"`
+	ResultsArray []struct {
+		MD     hex `json:"md"`
+		OutLen int `json:"outLen"`
+	} `json:"resultsArray"`
+}
+
+func runSHA3(t *testing.T, vs *vectorSet, r *results) {
+	newHash := sha3Hashes[vs.Algorithm]
+	sum := func(msg []byte) []byte {
+		h := newHash()
+		_, _ = h.Write(msg)
+		return h.Sum(nil)
+	}
+
+	var tgs []struct {
+		TestType string     `json:"testType"`
+		Tests    []hashTest `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		for _, tc := range tg.Tests {
+			if !wholeBytes(tc.Len, tc.Msg) {
+				continue
+			}
+			var want hashResult
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+			switch tg.TestType {
+			case "AFT", "LDT":
+				check(t, tc.TcID, "md", sum(tc.Msg), want.MD)
+			case "MCT":
+				// Standard Monte Carlo test of SHA-3, chaining the digests.
+				md := []byte(tc.Msg)
+				for j := range want.ResultsArray {
+					for i := 0; i < 1000; i++ {
+						md = sum(md)
+					}
+					check(t, tc.TcID, "md", md, want.ResultsArray[j].MD)
+				}
+			default:
+				t.Errorf("tcId %d: unsupported test type %s", tc.TcID, tg.TestType)
+			}
+		}
+	}
+}
+
+func runSHAKE(t *testing.T, vs *vectorSet, r *results) {
+	id := shakeIDs[vs.Algorithm]
+	sum := func(msg []byte, n int) []byte {
+		h := id.New()
+		_, _ = h.Write(msg)
+		out := make([]byte, n)
+		_, _ = h.Read(out)
+		return out
+	}
+
+	var tgs []struct {
+		TestType  string     `json:"testType"`
+		MinOutLen int        `json:"minOutLen"`
+		MaxOutLen int        `json:"maxOutLen"`
+		Tests     []hashTest `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		for _, tc := range tg.Tests {
+			if !wholeBytes(tc.Len, tc.Msg) {
+				continue
+			}
+			var want hashResult
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+			switch tg.TestType {
+			case "AFT", "VOT":
+				if tc.OutLen%8 != 0 {
+					continue
+				}
+				check(t, tc.TcID, "md", sum(tc.Msg, tc.OutLen/8), want.MD)
+			case "MCT":
+				// Monte Carlo test of SHAKE, where each message is the first
+				// 128 bits of the previous output, and the output length is
+				// derived from the last 16 bits of the previous output.
+				if tg.MinOutLen%8 != 0 || tg.MaxOutLen%8 != 0 {
+					continue
+				}
+				minLen, maxLen := tg.MinOutLen/8, tg.MaxOutLen/8
+				outLen := maxLen
+				out := []byte(tc.Msg)
+				for j := range want.ResultsArray {
+					for i := 0; i < 1000; i++ {
+						var msg [16]byte
+						copy(msg[:], out)
+						out = sum(msg[:], outLen)
+						last := binary.BigEndian.Uint16(out[len(out)-2:])
+						outLen = minLen + int(last)%(maxLen-minLen+1)
+					}
+					check(t, tc.TcID, "md", out, want.ResultsArray[j].MD)
+				}
+			default:
+				t.Errorf("tcId %d: unsupported test type %s", tc.TcID, tg.TestType)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package acvp
+
+import (
+	"testing"
+
+	"github.com/karalef/circl/kem"
+	"github.com/karalef/circl/kem/schemes"
+)
+
+// kemScheme returns the scheme of the parameter set, or nil if it is not
+// registered.
+func kemScheme(t *testing.T, name string) kem.Scheme {
+	t.Helper()
+	s := schemes.ByName(name)
+	if s == nil {
+		t.Logf("skipping unsupported parameter set %s", name)
+	}
+	return s
+}
+
+func runKEMKeyGen(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []struct {
+		ParameterSet string `json:"parameterSet"`
+		Tests        []struct {
+			TcID int `json:"tcId"`
+			D    hex `json:"d"`
+			Z    hex `json:"z"`
+		} `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		s := kemScheme(t, tg.ParameterSet)
+		if s == nil {
+			continue
+		}
+		for _, tc := range tg.Tests {
+			var want struct {
+				EK hex `json:"ek"`
+				DK hex `json:"dk"`
+			}
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+			seed := append(append([]byte{}, tc.D...), tc.Z...)
+			if len(seed) != s.SeedSize() {
+				t.Errorf("tcId %d: seed of %d bytes", tc.TcID, len(seed))
+				continue
+			}
+			pk, sk := s.DeriveKeyPair(seed)
+			ek, _ := pk.MarshalBinary()
+			dk, _ := sk.MarshalBinary()
+			check(t, tc.TcID, "ek", ek, want.EK)
+			check(t, tc.TcID, "dk", dk, want.DK)
+		}
+	}
+}
+
+func runKEMEncapDecap(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []struct {
+		ParameterSet string `json:"parameterSet"`
+		Function     string `json:"function"`
+		DK           hex    `json:"dk"`
+		Tests        []struct {
+			TcID int `json:"tcId"`
+			EK   hex `json:"ek"`
+			DK   hex `json:"dk"`
+			M    hex `json:"m"`
+			C    hex `json:"c"`
+		} `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		s := kemScheme(t, tg.ParameterSet)
+		if s == nil {
+			continue
+		}
+		for _, tc := range tg.Tests {
+			var want struct {
+				C hex `json:"c"`
+				K hex `json:"k"`
+			}
+			switch tg.Function {
+			case "encapsulation":
+				if !r.get(t, tc.TcID, &want) {
+					continue
+				}
+				pk, err := s.UnmarshalBinaryPublicKey(tc.EK)
+				if err != nil {
+					t.Errorf("tcId %d: %v", tc.TcID, err)
+					continue
+				}
+				c, k, err := s.Encapsulate(pk, tc.M)
+				if err != nil {
+					t.Errorf("tcId %d: %v", tc.TcID, err)
+					continue
+				}
+				check(t, tc.TcID, "c", c, want.C)
+				check(t, tc.TcID, "k", k, want.K)
+			case "decapsulation":
+				if !r.get(t, tc.TcID, &want) {
+					continue
+				}
+				dk := tc.DK
+				if dk == nil {
+					dk = tg.DK
+				}
+				sk, err := s.UnmarshalBinaryPrivateKey(dk)
+				if err != nil {
+					t.Errorf("tcId %d: %v", tc.TcID, err)
+					continue
+				}
+				k, err := s.Decapsulate(sk, tc.C)
+				if err != nil {
+					t.Errorf("tcId %d: %v", tc.TcID, err)
+					continue
+				}
+				check(t, tc.TcID, "k", k, want.K)
+			default:
+				// The key checks of the later revisions are not supported.
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package acvp
+
+import (
+	"testing"
+
+	"github.com/karalef/circl/sign"
+	"github.com/karalef/circl/sign/schemes"
+)
+
+// signScheme returns the scheme of the parameter set, or nil if it is not
+// registered.
+func signScheme(t *testing.T, name string) sign.Scheme {
+	t.Helper()
+	s := schemes.ByName(name)
+	if s == nil {
+		t.Logf("skipping unsupported parameter set %s", name)
+	}
+	return s
+}
+
+// signGroup holds the fields of the sigGen and sigVer test groups. The
+// schemes only sign messages directly, so the groups of pre-hash signatures
+// and of the external interface with a context are skipped, as are the
+// hedged signatures.
+type signGroup struct {
+	ParameterSet       string `json:"parameterSet"`
+	Deterministic      *bool  `json:"deterministic"`
+	SignatureInterface string `json:"signatureInterface"`
+	PreHash            string `json:"preHash"`
+	PK                 hex    `json:"pk"`
+	SK                 hex    `json:"sk"`
+	Tests              []struct {
+		TcID      int `json:"tcId"`
+		PK        hex `json:"pk"`
+		SK        hex `json:"sk"`
+		Message   hex `json:"message"`
+		Context   hex `json:"context"`
+		Signature hex `json:"signature"`
+	} `json:"tests"`
+}
+
+func (tg *signGroup) supported() bool {
+	return (tg.Deterministic == nil || *tg.Deterministic) &&
+		(tg.SignatureInterface == "" || tg.SignatureInterface == "internal") &&
+		(tg.PreHash == "" || tg.PreHash == "pure")
+}
+
+func runSignKeyGen(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []struct {
+		ParameterSet string `json:"parameterSet"`
+		Tests        []struct {
+			TcID int `json:"tcId"`
+			Seed hex `json:"seed"`
+		} `json:"tests"`
+	}
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		s := signScheme(t, tg.ParameterSet)
+		if s == nil {
+			continue
+		}
+		for _, tc := range tg.Tests {
+			var want struct {
+				PK hex `json:"pk"`
+				SK hex `json:"sk"`
+			}
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+			if len(tc.Seed) != s.SeedSize() {
+				t.Errorf("tcId %d: seed of %d bytes", tc.TcID, len(tc.Seed))
+				continue
+			}
+			pk, sk := s.DeriveKey(tc.Seed)
+			pkb, _ := pk.MarshalBinary()
+			skb, _ := sk.MarshalBinary()
+			check(t, tc.TcID, "pk", pkb, want.PK)
+			check(t, tc.TcID, "sk", skb, want.SK)
+		}
+	}
+}
+
+func runSignSigGen(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []signGroup
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		if !tg.supported() {
+			continue
+		}
+		s := signScheme(t, tg.ParameterSet)
+		if s == nil {
+			continue
+		}
+		for _, tc := range tg.Tests {
+			if len(tc.Context) != 0 {
+				continue
+			}
+			var want struct {
+				Signature hex `json:"signature"`
+			}
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+			skb := tc.SK
+			if skb == nil {
+				skb = tg.SK
+			}
+			sk, err := s.UnmarshalBinaryPrivateKey(skb)
+			if err != nil {
+				t.Errorf("tcId %d: %v", tc.TcID, err)
+				continue
+			}
+			check(t, tc.TcID, "signature", s.Sign(sk, tc.Message), want.Signature)
+		}
+	}
+}
+
+func runSignSigVer(t *testing.T, vs *vectorSet, r *results) {
+	var tgs []signGroup
+	groups(t, vs, &tgs)
+	for _, tg := range tgs {
+		if !tg.supported() {
+			continue
+		}
+		s := signScheme(t, tg.ParameterSet)
+		if s == nil {
+			continue
+		}
+		for _, tc := range tg.Tests {
+			if len(tc.Context) != 0 {
+				continue
+			}
+			var want struct {
+				TestPassed bool `json:"testPassed"`
+			}
+			if !r.get(t, tc.TcID, &want) {
+				continue
+			}
+			pkb := tc.PK
+			if pkb == nil {
+				pkb = tg.PK
+			}
+			pk, err := s.UnmarshalBinaryPublicKey(pkb)
+			if err != nil {
+				if want.TestPassed {
+					t.Errorf("tcId %d: %v", tc.TcID, err)
+				}
+				continue
+			}
+			if got := s.Verify(pk, tc.Message, tc.Signature); got != want.TestPassed {
+				t.Errorf("tcId %d: verification %v, want %v", tc.TcID, got, want.TestPassed)
+			}
+		}
+	}
+}
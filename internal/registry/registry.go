@@ -0,0 +1,122 @@
+// Package registry implements the part of the registers of cipher/schemes,
+// kem/schemes and sign/schemes that doesn't depend on the kind of scheme: the
+// status of standardization and the lookups of the metadata of the schemes.
+package registry
+
+import "strings"
+
+// Status describes how far a scheme is in its standardization process.
+type Status int
+
+const (
+	// Experimental schemes are not submitted to any standardization process.
+	Experimental Status = iota
+	// Candidate schemes are submissions to a standardization process, such
+	// as the rounds of the NIST PQC competition, or Internet-Drafts.
+	Candidate
+	// Standard schemes are published as a final standard.
+	Standard
+)
+
+func (s Status) String() string {
+	switch s {
+	case Experimental:
+		return "Experimental"
+	case Candidate:
+		return "Candidate"
+	case Standard:
+		return "Standard"
+	default:
+		return "Unknown"
+	}
+}
+
+// Scheme is the part of cipher.AEADScheme, kem.Scheme and sign.Scheme used
+// by Table.
+type Scheme interface {
+	Name() string
+}
+
+// Table indexes the metadata I of schemes S, in the order they are given.
+// The metadata is only handed out as copies, so that callers can't change
+// the registered one.
+type Table[S Scheme, I any] struct {
+	infos   []I
+	schemes []S
+	clone   func(*I) I
+	names   map[string]int
+	byValue map[interface{}]int
+}
+
+// New returns the table of infos, of which scheme returns the scheme and
+// clone a deep copy.
+func New[S Scheme, I any](infos []I, scheme func(*I) S, clone func(*I) I) *Table[S, I] {
+	t := &Table[S, I]{
+		infos:   infos,
+		schemes: make([]S, len(infos)),
+		clone:   clone,
+		names:   make(map[string]int),
+		byValue: make(map[interface{}]int),
+	}
+	for i := range infos {
+		s := scheme(&infos[i])
+		t.schemes[i] = s
+		t.names[strings.ToLower(s.Name())] = i
+		t.byValue[s] = i
+	}
+	return t
+}
+
+// ByName returns the scheme with the given case insensitive name, or the
+// zero S if it is not registered.
+func (t *Table[S, I]) ByName(name string) S {
+	if i, ok := t.names[strings.ToLower(name)]; ok {
+		return t.schemes[i]
+	}
+	var zero S
+	return zero
+}
+
+// InfoOf returns a copy of the metadata of the scheme and nil if it is not
+// registered.
+func (t *Table[S, I]) InfoOf(s S) *I {
+	if i, ok := t.byValue[s]; ok {
+		return t.info(i)
+	}
+	return nil
+}
+
+// Find returns the first scheme whose metadata satisfies the predicate, or
+// the zero S if there is none.
+func (t *Table[S, I]) Find(pred func(*I) bool) S {
+	for i := range t.infos {
+		if pred(t.info(i)) {
+			return t.schemes[i]
+		}
+	}
+	var zero S
+	return zero
+}
+
+// Filter returns, in the order of All, the schemes whose metadata satisfies
+// the predicate.
+func (t *Table[S, I]) Filter(pred func(*I) bool) []S {
+	var ret []S
+	for i := range t.infos {
+		if pred(t.info(i)) {
+			ret = append(ret, t.schemes[i])
+		}
+	}
+	return ret
+}
+
+// All returns a copy of the list of all the schemes.
+func (t *Table[S, I]) All() []S {
+	return append([]S(nil), t.schemes...)
+}
+
+// info returns a copy of the i-th metadata.
+func (t *Table[S, I]) info(i int) *I {
+	c := t.clone(&t.infos[i])
+	return &c
+}
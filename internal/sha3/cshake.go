@@ -0,0 +1,76 @@
+package sha3
+
+// This file provides cSHAKE as well as the encoding functions defined in
+// NIST SP 800-185 [1].
+//
+// [1] https://doi.org/10.6028/NIST.SP.800-185
+
+import "encoding/binary"
+
+const dsbyteCShake = 0x04
+
+// NewCShake128 creates a new cSHAKE128 instance with function-name N and
+// customization string S. If both are empty, it is equivalent to SHAKE128.
+// Reset restores the state right after absorbing N and S.
+func NewCShake128(N, S []byte) State {
+	return newCShake(NewShake128(), N, S)
+}
+
+// NewCShake256 creates a new cSHAKE256 instance with function-name N and
+// customization string S. If both are empty, it is equivalent to SHAKE256.
+// Reset restores the state right after absorbing N and S.
+func NewCShake256(N, S []byte) State {
+	return newCShake(NewShake256(), N, S)
+}
+
+func newCShake(d State, N, S []byte) State {
+	if len(N) == 0 && len(S) == 0 {
+		return d
+	}
+	d.dsbyte = dsbyteCShake
+	d.initBlock = AppendEncodeString(AppendEncodeString(nil, N), S)
+	d.initBlock = Bytepad(d.initBlock, d.rate)
+	_, _ = d.Write(d.initBlock)
+	return d
+}
+
+// AppendLeftEncode appends left_encode(x) to b.
+func AppendLeftEncode(b []byte, x uint64) []byte {
+	var buf [9]byte
+	binary.BigEndian.PutUint64(buf[1:], x)
+	i := 1
+	for i < 8 && buf[i] == 0 {
+		i++
+	}
+	buf[i-1] = byte(9 - i)
+	return append(b, buf[i-1:]...)
+}
+
+// AppendRightEncode appends right_encode(x) to b.
+func AppendRightEncode(b []byte, x uint64) []byte {
+	var buf [9]byte
+	binary.BigEndian.PutUint64(buf[:8], x)
+	i := 0
+	for i < 7 && buf[i] == 0 {
+		i++
+	}
+	buf[8] = byte(8 - i)
+	return append(b, buf[i:]...)
+}
+
+// AppendEncodeString appends encode_string(s) to b.
+func AppendEncodeString(b, s []byte) []byte {
+	b = AppendLeftEncode(b, uint64(len(s))*8)
+	return append(b, s...)
+}
+
+// Bytepad returns bytepad(x, w): left_encode(w) ‖ x followed by zeros up to
+// a multiple of w bytes.
+func Bytepad(x []byte, w int) []byte {
+	b := AppendLeftEncode(make([]byte, 0, len(x)+w+9), uint64(w))
+	b = append(b, x...)
+	if r := len(b) % w; r != 0 {
+		b = append(b, make([]byte, w-r)...)
+	}
+	return b
+}
@@ -0,0 +1,8 @@
+module github.com/karalef/circl/internal/sha3/internal/asm
+
+go 1.12
+
+require (
+	github.com/mmcloughlin/avo v0.5.0
+	golang.org/x/tools v0.8.0 // indirect
+)
@@ -4,12 +4,9 @@
 
 package sha3
 
-// KeccakF1600 applies the Keccak permutation to a 1600b-wide
-// state represented as a slice of 25 uint64s.
-// If turbo is true, applies the 12-round variant instead of the
-// regular 24-round variant.
+// keccakF1600Generic is the portable implementation of KeccakF1600.
 // nolint:funlen
-func KeccakF1600(a *[25]uint64, turbo bool) {
+func keccakF1600Generic(a *[25]uint64, turbo bool) {
 	// Implementation translated from Keccak-inplace.c
 	// in the keccak reference code.
 	var t, bc0, bc1, bc2, bc3, bc4, d0, d1, d2, d3, d4 uint64
@@ -0,0 +1,25 @@
+//go:build arm64 && !noasm
+// +build arm64,!noasm
+
+package sha3
+
+import "golang.org/x/sys/cpu"
+
+// useSHA3 tells whether to use the SHA3 instructions, which are detected
+// by golang.org/x/sys/cpu.
+var useSHA3 = cpu.ARM64.HasSHA3
+
+// KeccakF1600 applies the Keccak permutation to a 1600b-wide
+// state represented as a slice of 25 uint64s.
+// If turbo is true, applies the 12-round variant instead of the
+// regular 24-round variant.
+func KeccakF1600(a *[25]uint64, turbo bool) {
+	if useSHA3 {
+		keccakF1600SHA3(a, turbo)
+	} else {
+		keccakF1600Generic(a, turbo)
+	}
+}
+
+//go:noescape
+func keccakF1600SHA3(a *[25]uint64, turbo bool)
@@ -0,0 +1,10 @@
+//go:build (!amd64 && !arm64) || noasm
+// +build !amd64,!arm64 noasm
+
+package sha3
+
+// KeccakF1600 applies the Keccak permutation to a 1600b-wide
+// state represented as a slice of 25 uint64s.
+// If turbo is true, applies the 12-round variant instead of the
+// regular 24-round variant.
+func KeccakF1600(a *[25]uint64, turbo bool) { keccakF1600Generic(a, turbo) }
@@ -0,0 +1,106 @@
+package sha3
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The marshalled state is
+//
+//	magic ‖ rate ‖ dsbyte ‖ outputLen ‖ flags ‖ bufo ‖ bufe ‖ a ‖ storage
+//
+// where every field except a and storage is a single byte. The lanes of a
+// are encoded in little-endian order. Bit 0 of flags is set when squeezing
+// and bit 1 when using the 12-round variant.
+const (
+	magic = "sha3\x01"
+
+	// MarshaledSize is the size of the marshalled State.
+	MarshaledSize = len(magic) + 6 + 25*8 + maxRate
+
+	flagSqueezing = 1 << 0
+	flagTurbo     = 1 << 1
+)
+
+var (
+	errInvalidState  = errors.New("sha3: invalid hash state")
+	errStateIdentity = errors.New("sha3: invalid hash state identifier")
+)
+
+// MarshalBinary encodes the state, including buffered input or output.
+func (d *State) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, MarshaledSize))
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (d *State) AppendBinary(b []byte) ([]byte, error) {
+	var flags byte
+	if d.state == spongeSqueezing {
+		flags |= flagSqueezing
+	}
+	if d.turbo {
+		flags |= flagTurbo
+	}
+
+	b = append(b, magic...)
+	b = append(b,
+		byte(d.rate),
+		d.dsbyte,
+		byte(d.outputLen),
+		flags,
+		byte(d.bufo),
+		byte(d.bufe),
+	)
+	for i := range d.a {
+		b = binary.LittleEndian.AppendUint64(b, d.a[i])
+	}
+	b = append(b, d.storage.asBytes()[:]...)
+	return b, nil
+}
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary. The state must be that of the same function as d, with the
+// same rate, domain separation byte and number of rounds.
+func (d *State) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic) || string(b[:len(magic)]) != magic {
+		return errStateIdentity
+	}
+	if len(b) != MarshaledSize {
+		return errInvalidState
+	}
+	b = b[len(magic):]
+
+	rate, dsbyte, outputLen, flags := int(b[0]), b[1], int(b[2]), b[3]
+	bufo, bufe := int(b[4]), int(b[5])
+	switch rate {
+	case 72, 104, 136, 144, 168:
+	default:
+		return errInvalidState
+	}
+	if flags&^(flagSqueezing|flagTurbo) != 0 || dsbyte == 0 {
+		return errInvalidState
+	}
+	// The input is buffered from the start of storage and absorbed as soon
+	// as a block is full, and the output is a full block squeezed up to bufo.
+	if flags&flagSqueezing == 0 && (bufo != 0 || bufe >= rate) ||
+		flags&flagSqueezing != 0 && (bufe != rate || bufo >= rate) {
+		return errInvalidState
+	}
+	if rate != d.rate || dsbyte != d.dsbyte || (flags&flagTurbo != 0) != d.turbo {
+		return errStateIdentity
+	}
+	b = b[6:]
+
+	d.outputLen = outputLen
+	d.state = spongeAbsorbing
+	if flags&flagSqueezing != 0 {
+		d.state = spongeSqueezing
+	}
+	d.bufo = bufo
+	d.bufe = bufe
+	for i := range d.a {
+		d.a[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+	copy(d.storage.asBytes()[:], b[25*8:])
+	return nil
+}
@@ -52,6 +52,10 @@ type State struct {
 	outputLen int             // the default output size in bytes
 	state     spongeDirection // whether the sponge is absorbing or squeezing
 	turbo     bool            // Whether we're using 12 rounds instead of 24
+
+	// Specific to cSHAKE: the padded function name and customization string
+	// which are absorbed again on Reset.
+	initBlock []byte
 }
 
 // BlockSize returns the rate of sponge underlying this hash function.
@@ -70,6 +74,9 @@ func (d *State) Reset() {
 	d.state = spongeAbsorbing
 	d.bufo = 0
 	d.bufe = 0
+	if d.initBlock != nil {
+		_, _ = d.Write(d.initBlock)
+	}
 }
 
 func (d *State) clone() *State {
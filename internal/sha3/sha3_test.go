@@ -161,6 +161,26 @@ func sequentialBytes(size int) []byte {
 	return result
 }
 
+// TestPermutationFunction checks that the permutation used on this platform
+// matches the generic one.
+func TestPermutationFunction(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, turbo := range []bool{false, true} {
+		for i := 0; i < 100; i++ {
+			var want, got [25]uint64
+			for j := range want {
+				want[j] = r.Uint64()
+			}
+			got = want
+			keccakF1600Generic(&want, turbo)
+			KeccakF1600(&got, turbo)
+			if got != want {
+				t.Fatalf("turbo=%v: got %x, want %x", turbo, got, want)
+			}
+		}
+	}
+}
+
 // BenchmarkPermutationFunctionTurbo measures the speed of the permutation
 // function with no input data.
 func BenchmarkPermutationFunctionTurbo(b *testing.B) {
@@ -181,6 +201,16 @@ func BenchmarkPermutationFunction(b *testing.B) {
 	}
 }
 
+// BenchmarkPermutationFunctionGeneric measures the speed of the portable
+// permutation function.
+func BenchmarkPermutationFunctionGeneric(b *testing.B) {
+	b.SetBytes(int64(200))
+	var lanes [25]uint64
+	for i := 0; i < b.N; i++ {
+		keccakF1600Generic(&lanes, false)
+	}
+}
+
 // benchmarkHash tests the speed to hash num buffers of buflen each.
 func benchmarkHash(b *testing.B, h State, size, num int) {
 	b.StopTimer()
@@ -54,7 +54,7 @@ func (d *State) Clone() ShakeHash {
 // Its generic security strength is 128 bits against all attacks if at
 // least 32 bytes of its output are used.
 func NewShake128() State {
-	return State{rate: rate128, dsbyte: dsbyteShake}
+	return State{rate: rate128, outputLen: 32, dsbyte: dsbyteShake}
 }
 
 // NewTurboShake128 creates a new TurboSHAKE128 variable-output-length ShakeHash.
@@ -65,14 +65,14 @@ func NewTurboShake128(D byte) State {
 	if D == 0 || D > 0x7f {
 		panic("turboshake: D out of range")
 	}
-	return State{rate: rate128, dsbyte: D, turbo: true}
+	return State{rate: rate128, outputLen: 32, dsbyte: D, turbo: true}
 }
 
 // NewShake256 creates a new SHAKE256 variable-output-length ShakeHash.
 // Its generic security strength is 256 bits against all attacks if
 // at least 64 bytes of its output are used.
 func NewShake256() State {
-	return State{rate: rate256, dsbyte: dsbyteShake}
+	return State{rate: rate256, outputLen: 64, dsbyte: dsbyteShake}
 }
 
 // NewTurboShake256 creates a new TurboSHAKE256 variable-output-length ShakeHash.
@@ -83,7 +83,7 @@ func NewTurboShake256(D byte) State {
 	if D == 0 || D > 0x7f {
 		panic("turboshake: D out of range")
 	}
-	return State{rate: rate256, dsbyte: D, turbo: true}
+	return State{rate: rate256, outputLen: 64, dsbyte: D, turbo: true}
 }
 
 // ShakeSum128 writes an arbitrary-length digest of data into hash.
@@ -0,0 +1,26 @@
+package frodo640shake
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecapsulateTo(t *testing.T) {
+	// The shared key is written to ss and the ciphertext is left intact.
+	s := Scheme()
+	pk, sk := s.DeriveKeyPair(make([]byte, s.SeedSize()))
+	ct, want, err := s.Encapsulate(pk, make([]byte, s.EncapsulationSeedSize()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct0 := append([]byte(nil), ct...)
+
+	ss := make([]byte, s.SharedKeySize())
+	s.DecapsulateTo(sk, ss, ct)
+	if !bytes.Equal(ss, want) {
+		t.Fatalf("shared key %x, want %x", ss, want)
+	}
+	if !bytes.Equal(ct, ct0) {
+		t.Fatal("ciphertext overwritten")
+	}
+}
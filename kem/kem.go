@@ -8,6 +8,7 @@ package kem
 import (
 	"encoding"
 	"errors"
+	"io"
 )
 
 // A KEM public key
@@ -37,6 +38,10 @@ type Scheme interface {
 	// GenerateKeyPair creates a new key pair.
 	GenerateKeyPair() (PublicKey, PrivateKey, error)
 
+	// GenerateKeyPairFrom creates a new key pair using randomness from rand.
+	// If rand is nil, crypto/rand.Reader is used.
+	GenerateKeyPairFrom(rand io.Reader) (PublicKey, PrivateKey, error)
+
 	// DeriveKeyPair deterministically derives a pair of keys from a seed.
 	// Panics if the length of seed is not equal to the value returned by
 	// SeedSize.
@@ -49,6 +54,12 @@ type Scheme interface {
 	// Panics if key is nil or wrong type.
 	Encapsulate(pk PublicKey, seed []byte) (ct, ss []byte, err error)
 
+	// EncapsulateFrom is like Encapsulate, but reads the seed from rand.
+	// If rand is nil, crypto/rand.Reader is used.
+	//
+	// Panics if key is nil or wrong type.
+	EncapsulateFrom(pk PublicKey, rand io.Reader) (ct, ss []byte, err error)
+
 	// EncapsulateTo generates a shared key ss for the public
 	// key deterministically from the given seed and encapsulates it into
 	// a ciphertext ct. If unsure, you're better off using Encapsulate().
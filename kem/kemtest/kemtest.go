@@ -0,0 +1,237 @@
+// Package kemtest implements conformance tests for implementations of
+// kem.Scheme.
+//
+// It can be used to check that schemes defined outside of this module, such
+// as wrappers of keys held in hardware, follow the conventions of the kem
+// package:
+//
+//	func TestMyScheme(t *testing.T) {
+//		kemtest.TestScheme(t, myScheme)
+//	}
+package kemtest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karalef/circl/internal/test"
+	"github.com/karalef/circl/kem"
+)
+
+// TestScheme checks that the scheme
+//
+//   - reports sizes matching those of its keys, ciphertexts and shared keys,
+//   - unmarshals its marshalled keys to equal keys and rejects wrong sizes,
+//   - derives the same keys and ciphertexts from the same seeds,
+//   - generates from a reader the keys and ciphertexts it derives from the
+//     seeds read, and uses crypto/rand.Reader for a nil reader,
+//   - decapsulates the shared keys it encapsulates,
+//   - implicitly rejects tampered ciphertexts, returning a pseudorandom
+//     shared key instead of an error,
+//   - and compares keys with Equal consistently with their encodings.
+func TestScheme(t *testing.T, s kem.Scheme) {
+	t.Run("Sizes", func(t *testing.T) { testSizes(t, s) })
+	t.Run("Marshal", func(t *testing.T) { testMarshal(t, s) })
+	t.Run("Derive", func(t *testing.T) { testDerive(t, s) })
+	t.Run("Reader", func(t *testing.T) { testReader(t, s) })
+	t.Run("Encapsulate", func(t *testing.T) { testEncapsulate(t, s) })
+	t.Run("ImplicitRejection", func(t *testing.T) { testImplicitRejection(t, s) })
+	t.Run("Equal", func(t *testing.T) { testEqual(t, s) })
+}
+
+func seed(n int, start byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = start + byte(i)
+	}
+	return b
+}
+
+func testSizes(t *testing.T, s kem.Scheme) {
+	test.CheckOk(s.Name() != "", "empty name", t)
+	test.CheckOk(s.PublicKeySize() > 0, "PublicKeySize is not positive", t)
+	test.CheckOk(s.PrivateKeySize() > 0, "PrivateKeySize is not positive", t)
+	test.CheckOk(s.CiphertextSize() > 0, "CiphertextSize is not positive", t)
+	test.CheckOk(s.SharedKeySize() > 0, "SharedKeySize is not positive", t)
+	test.CheckOk(s.SeedSize() > 0, "SeedSize is not positive", t)
+	test.CheckOk(s.EncapsulationSeedSize() > 0, "EncapsulationSeedSize is not positive", t)
+
+	pk, sk, err := s.GenerateKeyPair()
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(ppk) == s.PublicKeySize(), "wrong size of public key", t)
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(psk) == s.PrivateKeySize(), "wrong size of private key", t)
+
+	ct, ss, err := s.Encapsulate(pk, nil)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	test.CheckOk(len(ct) == s.CiphertextSize(), "wrong size of ciphertext", t)
+	test.CheckOk(len(ss) == s.SharedKeySize(), "wrong size of shared key", t)
+}
+
+func testMarshal(t *testing.T, s kem.Scheme) {
+	pk, sk, err := s.GenerateKeyPair()
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+	ppk, _ := pk.MarshalBinary()
+	psk, _ := sk.MarshalBinary()
+
+	pk2, err := s.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	sk2, err := s.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	test.CheckOk(pk.Equal(pk2), "public key changed by a round trip", t)
+	test.CheckOk(sk.Equal(sk2), "private key changed by a round trip", t)
+	ppk2, _ := pk2.MarshalBinary()
+	psk2, _ := sk2.MarshalBinary()
+	test.CheckOk(bytes.Equal(ppk, ppk2), "public key encoding changed by a round trip", t)
+	test.CheckOk(bytes.Equal(psk, psk2), "private key encoding changed by a round trip", t)
+
+	test.CheckOk(pk2.Scheme() == s, "wrong scheme of public key", t)
+	test.CheckOk(sk2.Scheme() == s, "wrong scheme of private key", t)
+	test.CheckOk(sk2.Public().Equal(pk), "wrong public key of private key", t)
+
+	_, err = s.UnmarshalBinaryPublicKey(ppk[:len(ppk)-1])
+	test.CheckIsErr(t, err, "short public key accepted")
+	_, err = s.UnmarshalBinaryPublicKey(append(ppk, 0))
+	test.CheckIsErr(t, err, "long public key accepted")
+	_, err = s.UnmarshalBinaryPrivateKey(psk[:len(psk)-1])
+	test.CheckIsErr(t, err, "short private key accepted")
+	_, err = s.UnmarshalBinaryPrivateKey(append(psk, 0))
+	test.CheckIsErr(t, err, "long private key accepted")
+}
+
+func testDerive(t *testing.T, s kem.Scheme) {
+	pk1, sk1 := s.DeriveKeyPair(seed(s.SeedSize(), 0))
+	pk2, sk2 := s.DeriveKeyPair(seed(s.SeedSize(), 0))
+	test.CheckOk(pk1.Equal(pk2), "different public keys from the same seed", t)
+	test.CheckOk(sk1.Equal(sk2), "different private keys from the same seed", t)
+	pk3, sk3 := s.DeriveKeyPair(seed(s.SeedSize(), 1))
+	test.CheckOk(!pk1.Equal(pk3), "same public key from different seeds", t)
+	test.CheckOk(!sk1.Equal(sk3), "same private key from different seeds", t)
+
+	err := test.CheckPanic(func() { s.DeriveKeyPair(seed(s.SeedSize()+1, 0)) })
+	test.CheckNoErr(t, err, "DeriveKeyPair with a wrong seed size did not panic")
+
+	eseed := seed(s.EncapsulationSeedSize(), 2)
+	ct1, ss1, err := s.Encapsulate(pk1, eseed)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	ct2, ss2, err := s.Encapsulate(pk1, eseed)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	test.CheckOk(bytes.Equal(ct1, ct2), "different ciphertexts from the same seed", t)
+	test.CheckOk(bytes.Equal(ss1, ss2), "different shared keys from the same seed", t)
+
+	ct3 := make([]byte, s.CiphertextSize())
+	ss3 := make([]byte, s.SharedKeySize())
+	s.EncapsulateTo(pk1, ct3, ss3, eseed)
+	test.CheckOk(bytes.Equal(ct1, ct3), "EncapsulateTo differs from Encapsulate", t)
+	test.CheckOk(bytes.Equal(ss1, ss3), "EncapsulateTo differs from Encapsulate", t)
+
+	_, _, err = s.Encapsulate(pk1, eseed[1:])
+	test.CheckIsErr(t, err, "Encapsulate accepted a wrong seed size")
+}
+
+func testReader(t *testing.T, s kem.Scheme) {
+	kseed := seed(s.SeedSize(), 0)
+	eseed := seed(s.EncapsulationSeedSize(), 2)
+	rand := bytes.NewReader(append(append([]byte{}, kseed...), eseed...))
+
+	pk, sk, err := s.GenerateKeyPairFrom(rand)
+	test.CheckNoErr(t, err, "GenerateKeyPairFrom failed")
+	pk2, sk2 := s.DeriveKeyPair(kseed)
+	test.CheckOk(pk.Equal(pk2), "GenerateKeyPairFrom differs from DeriveKeyPair", t)
+	test.CheckOk(sk.Equal(sk2), "GenerateKeyPairFrom differs from DeriveKeyPair", t)
+
+	ct, ss, err := s.EncapsulateFrom(pk, rand)
+	test.CheckNoErr(t, err, "EncapsulateFrom failed")
+	ct2, ss2, err := s.Encapsulate(pk, eseed)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	test.CheckOk(bytes.Equal(ct, ct2), "EncapsulateFrom differs from Encapsulate", t)
+	test.CheckOk(bytes.Equal(ss, ss2), "EncapsulateFrom differs from Encapsulate", t)
+
+	_, _, err = s.GenerateKeyPairFrom(rand)
+	test.CheckIsErr(t, err, "GenerateKeyPairFrom read an exhausted reader")
+	_, _, err = s.EncapsulateFrom(pk, rand)
+	test.CheckIsErr(t, err, "EncapsulateFrom read an exhausted reader")
+
+	pk3, sk3, err := s.GenerateKeyPairFrom(nil)
+	test.CheckNoErr(t, err, "GenerateKeyPairFrom failed with a nil reader")
+	test.CheckOk(!pk3.Equal(pk), "GenerateKeyPairFrom with a nil reader is not random", t)
+	ct3, ss3, err := s.EncapsulateFrom(pk3, nil)
+	test.CheckNoErr(t, err, "EncapsulateFrom failed with a nil reader")
+	got, err := s.Decapsulate(sk3, ct3)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	test.CheckOk(bytes.Equal(got, ss3), "wrong shared key", t)
+}
+
+func testEncapsulate(t *testing.T, s kem.Scheme) {
+	pk, sk, err := s.GenerateKeyPair()
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+
+	ct, ss, err := s.Encapsulate(pk, nil)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	ct2, ss2, err := s.Encapsulate(pk, nil)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	test.CheckOk(!bytes.Equal(ct, ct2), "same ciphertexts from random seeds", t)
+	test.CheckOk(!bytes.Equal(ss, ss2), "same shared keys from random seeds", t)
+
+	got, err := s.Decapsulate(sk, ct)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	test.CheckOk(bytes.Equal(got, ss), "wrong shared key", t)
+
+	got = make([]byte, s.SharedKeySize())
+	s.DecapsulateTo(sk, got, ct)
+	test.CheckOk(bytes.Equal(got, ss), "DecapsulateTo differs from Decapsulate", t)
+
+	_, err = s.Decapsulate(sk, ct[:len(ct)-1])
+	test.CheckIsErr(t, err, "short ciphertext accepted")
+	_, err = s.Decapsulate(sk, append(ct, 0))
+	test.CheckIsErr(t, err, "long ciphertext accepted")
+
+	// A ciphertext for another key decapsulates to another shared key.
+	_, sk2, err := s.GenerateKeyPair()
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+	got, err = s.Decapsulate(sk2, ct)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	test.CheckOk(!bytes.Equal(got, ss), "same shared key with another private key", t)
+}
+
+func testImplicitRejection(t *testing.T, s kem.Scheme) {
+	pk, sk := s.DeriveKeyPair(seed(s.SeedSize(), 0))
+	ct, ss, err := s.Encapsulate(pk, seed(s.EncapsulationSeedSize(), 1))
+	test.CheckNoErr(t, err, "Encapsulate failed")
+
+	for _, i := range []int{0, len(ct) / 2, len(ct) - 1} {
+		bad := append([]byte{}, ct...)
+		bad[i] ^= 1
+		got, err := s.Decapsulate(sk, bad)
+		test.CheckNoErr(t, err, "tampered ciphertext not implicitly rejected")
+		test.CheckOk(len(got) == s.SharedKeySize(), "wrong size of rejection key", t)
+		test.CheckOk(!bytes.Equal(got, ss), "same shared key from a tampered ciphertext", t)
+
+		// The rejection key is a function of the private key and ciphertext.
+		again, _ := s.Decapsulate(sk, bad)
+		test.CheckOk(bytes.Equal(got, again), "different rejection keys for the same ciphertext", t)
+	}
+}
+
+// otherPublicKey and otherPrivateKey wrap keys into types the scheme doesn't
+// know, which must compare unequal to its keys.
+type (
+	otherPublicKey  struct{ kem.PublicKey }
+	otherPrivateKey struct{ kem.PrivateKey }
+)
+
+func testEqual(t *testing.T, s kem.Scheme) {
+	pk, sk := s.DeriveKeyPair(seed(s.SeedSize(), 0))
+	pk2, sk2 := s.DeriveKeyPair(seed(s.SeedSize(), 1))
+
+	test.CheckOk(pk.Equal(pk), "public key not equal to itself", t)
+	test.CheckOk(sk.Equal(sk), "private key not equal to itself", t)
+	test.CheckOk(!pk.Equal(pk2) && !pk2.Equal(pk), "different public keys are equal", t)
+	test.CheckOk(!sk.Equal(sk2) && !sk2.Equal(sk), "different private keys are equal", t)
+	test.CheckOk(!pk.Equal(otherPublicKey{pk}), "public key equal to a foreign type", t)
+	test.CheckOk(!sk.Equal(otherPrivateKey{sk}), "private key equal to a foreign type", t)
+	test.CheckOk(sk.Public().Equal(pk) && pk.Equal(sk.Public()), "public key of private key not equal", t)
+}
@@ -357,6 +357,21 @@ func (*scheme) Encapsulate(pk kem.PublicKey, seed []byte) (ct, ss []byte, err er
 	return
 }
 
+func (*scheme) GenerateKeyPairFrom(rand io.Reader) (kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(rand)
+}
+
+func (s *scheme) EncapsulateFrom(pk kem.PublicKey, rand io.Reader) (ct, ss []byte, err error) {
+	var seed [EncapsulationSeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	return s.Encapsulate(pk, seed[:])
+}
+
 func (*scheme) EncapsulateTo(pk kem.PublicKey, ct, ss, seed []byte) {
 	pub, ok := pk.(*PublicKey)
 	if !ok {
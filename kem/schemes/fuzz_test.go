@@ -0,0 +1,179 @@
+package schemes_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/karalef/circl/internal/nist"
+	"github.com/karalef/circl/kem"
+	"github.com/karalef/circl/kem/schemes"
+)
+
+// fuzzScheme returns the scheme selected by a fuzzed index.
+func fuzzScheme(i uint8) kem.Scheme {
+	all := schemes.All()
+	return all[int(i)%len(all)]
+}
+
+// fuzzKeys derives the keys of the scheme from a fixed seed.
+func fuzzKeys(s kem.Scheme) (kem.PublicKey, kem.PrivateKey) {
+	seed := make([]byte, s.SeedSize())
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	return s.DeriveKeyPair(seed)
+}
+
+// katCount is the number of vectors of the NIST KATs added to the corpus.
+const katCount = 3
+
+// addCorpus seeds the corpus of f with the keys and a ciphertext of every
+// scheme, both from the fixed seed and from the first inputs of the NIST
+// KATs of kem/kyber and kem/frodo.
+func addCorpus(f *testing.F, add func(i uint8, pk, sk, ct []byte)) {
+	for i, s := range schemes.All() {
+		addKeys := func(pk kem.PublicKey, sk kem.PrivateKey, eseed []byte) {
+			ppk, _ := pk.MarshalBinary()
+			psk, _ := sk.MarshalBinary()
+			ct, _, _ := s.Encapsulate(pk, eseed)
+			add(uint8(i), ppk, psk, ct)
+		}
+		pk, sk := fuzzKeys(s)
+		addKeys(pk, sk, make([]byte, s.EncapsulationSeedSize()))
+
+		var seed [48]byte
+		for j := range seed {
+			seed[j] = byte(j)
+		}
+		g := nist.NewDRBG(&seed)
+		kseed := make([]byte, s.SeedSize())
+		eseed := make([]byte, s.EncapsulationSeedSize())
+		for j := 0; j < katCount; j++ {
+			g.Fill(seed[:])
+			g2 := nist.NewDRBG(&seed)
+			// The reference implementation of Kyber calls randombytes
+			// twice to generate the keypair.
+			if strings.HasPrefix(s.Name(), "Kyber") {
+				g2.Fill(kseed[:32])
+				g2.Fill(kseed[32:])
+			} else {
+				g2.Fill(kseed)
+			}
+			g2.Fill(eseed)
+			pk, sk := s.DeriveKeyPair(kseed)
+			addKeys(pk, sk, eseed)
+		}
+	}
+}
+
+func FuzzUnmarshalBinaryPublicKey(f *testing.F) {
+	addCorpus(f, func(i uint8, pk, _, _ []byte) { f.Add(i, pk) })
+	f.Add(uint8(0), []byte{})
+
+	f.Fuzz(func(t *testing.T, i uint8, data []byte) {
+		s := fuzzScheme(i)
+		pk, err := s.UnmarshalBinaryPublicKey(data)
+		if len(data) != s.PublicKeySize() {
+			if err == nil {
+				t.Fatalf("%s: public key of %d bytes accepted", s.Name(), len(data))
+			}
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		// Non-canonical encodings may be accepted, but the encodings of the
+		// keys are canonical.
+		packed, err := pk.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk2, err := s.UnmarshalBinaryPublicKey(packed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packed2, _ := pk2.MarshalBinary()
+		if !bytes.Equal(packed, packed2) {
+			t.Fatalf("%s: public key changed by a round trip", s.Name())
+		}
+		if _, _, err = s.Encapsulate(pk, make([]byte, s.EncapsulationSeedSize())); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzUnmarshalBinaryPrivateKey(f *testing.F) {
+	addCorpus(f, func(i uint8, _, sk, _ []byte) { f.Add(i, sk) })
+	f.Add(uint8(0), []byte{})
+
+	f.Fuzz(func(t *testing.T, i uint8, data []byte) {
+		s := fuzzScheme(i)
+		sk, err := s.UnmarshalBinaryPrivateKey(data)
+		if len(data) != s.PrivateKeySize() {
+			if err == nil {
+				t.Fatalf("%s: private key of %d bytes accepted", s.Name(), len(data))
+			}
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		// Non-canonical encodings may be accepted, but the encodings of the
+		// keys are canonical.
+		packed, err := sk.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk2, err := s.UnmarshalBinaryPrivateKey(packed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packed2, _ := sk2.MarshalBinary()
+		if !bytes.Equal(packed, packed2) {
+			t.Fatalf("%s: private key changed by a round trip", s.Name())
+		}
+		if _, err = s.Decapsulate(sk, make([]byte, s.CiphertextSize())); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzDecapsulate(f *testing.F) {
+	addCorpus(f, func(i uint8, _, _, ct []byte) { f.Add(i, ct) })
+	f.Add(uint8(0), []byte{})
+
+	f.Fuzz(func(t *testing.T, i uint8, ct []byte) {
+		s := fuzzScheme(i)
+		pk, sk := fuzzKeys(s)
+		ss, err := s.Decapsulate(sk, ct)
+		if len(ct) != s.CiphertextSize() {
+			if err == nil {
+				t.Fatalf("%s: ciphertext of %d bytes accepted", s.Name(), len(ct))
+			}
+			return
+		}
+
+		// Invalid ciphertexts are implicitly rejected.
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ss) != s.SharedKeySize() {
+			t.Fatalf("%s: shared key of %d bytes", s.Name(), len(ss))
+		}
+		ss2 := make([]byte, s.SharedKeySize())
+		s.DecapsulateTo(sk, ss2, ct)
+		if !bytes.Equal(ss, ss2) {
+			t.Fatalf("%s: DecapsulateTo differs from Decapsulate", s.Name())
+		}
+
+		// Only the ciphertexts of the corpus decapsulate to shared keys of
+		// known seeds.
+		ct2, ss3, _ := s.Encapsulate(pk, make([]byte, s.EncapsulationSeedSize()))
+		if bytes.Equal(ss, ss3) != bytes.Equal(ct, ct2) {
+			t.Fatalf("%s: shared key of another ciphertext", s.Name())
+		}
+	})
+}
@@ -1,5 +1,11 @@
 // Package schemes contains a register of KEM schemes.
 //
+// Besides the lookup by name, every scheme is registered together with its
+// metadata (see Info): the NIST security category, the standardization
+// status, the ASN.1 object identifier and the code points assigned to it by
+// protocol registries. This allows protocol code to negotiate algorithms
+// from the register instead of keeping its own tables.
+//
 // # Schemes Implemented
 //
 //	FrodoKEM-640-SHAKE
@@ -7,8 +13,9 @@
 package schemes
 
 import (
-	"strings"
+	"encoding/asn1"
 
+	"github.com/karalef/circl/internal/registry"
 	"github.com/karalef/circl/kem"
 	"github.com/karalef/circl/kem/frodo/frodo640shake"
 	"github.com/karalef/circl/kem/kyber/kyber1024"
@@ -16,29 +23,136 @@ import (
 	"github.com/karalef/circl/kem/kyber/kyber768"
 )
 
-var allSchemes = [...]kem.Scheme{
-	frodo640shake.Scheme(),
-	kyber512.Scheme(),
-	kyber768.Scheme(),
-	kyber1024.Scheme(),
+// Status describes how far a scheme is in its standardization process.
+type Status = registry.Status
+
+const (
+	// Experimental schemes are not submitted to any standardization process.
+	Experimental = registry.Experimental
+	// Candidate schemes are submissions to a standardization process, such
+	// as the rounds of the NIST PQC competition, or Internet-Drafts.
+	Candidate = registry.Candidate
+	// Standard schemes are published as a final standard.
+	Standard = registry.Standard
+)
+
+// Registry identifies a protocol registry of code points. HPKE only
+// assigns identifiers to ML-KEM and hybrids, not to the round 3 schemes, so
+// TLS is the only one with code points for the registered schemes.
+type Registry int
+
+const (
+	// TLS NamedGroup values.
+	TLS Registry = iota + 1
+)
+
+// Info holds the metadata of a registered KEM scheme. The sizes of keys,
+// ciphertexts and shared keys are available through the embedded Scheme.
+type Info struct {
+	kem.Scheme
+
+	// Level is the NIST security category, from 1 to 5.
+	Level int
+
+	// Status of standardization of the scheme.
+	Status Status
+
+	// OID is the ASN.1 object identifier of the public key algorithm, or
+	// nil if none is assigned.
+	OID asn1.ObjectIdentifier
+
+	// TLS is the TLS NamedGroup code point, or 0 if none is assigned.
+	TLS uint16
 }
 
-var allSchemeNames map[string]kem.Scheme
+// CodePoint returns the code point of the scheme in the registry r and
+// whether one is assigned.
+func (i *Info) CodePoint(r Registry) (uint16, bool) {
+	switch r {
+	case TLS:
+		return i.TLS, i.TLS != 0
+	default:
+		return 0, false
+	}
+}
+
+// Object identifiers and TLS code points of the round 3 submissions as
+// assigned by the Open Quantum Safe project.
+var allInfos = [...]Info{
+	{
+		Scheme: frodo640shake.Scheme(),
+		Level:  1,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 22554, 5, 1, 2},
+		TLS:    0x0201,
+	},
+	{
+		Scheme: kyber512.Scheme(),
+		Level:  1,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 22554, 5, 6, 1},
+		TLS:    0x023a,
+	},
+	{
+		Scheme: kyber768.Scheme(),
+		Level:  3,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 22554, 5, 6, 2},
+		TLS:    0x023c,
+	},
+	{
+		Scheme: kyber1024.Scheme(),
+		Level:  5,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 22554, 5, 6, 3},
+		TLS:    0x023d,
+	},
+}
 
-func init() {
-	allSchemeNames = make(map[string]kem.Scheme)
-	for _, scheme := range allSchemes {
-		allSchemeNames[strings.ToLower(scheme.Name())] = scheme
+var table = registry.New(allInfos[:], func(i *Info) kem.Scheme { return i.Scheme }, (*Info).clone)
+
+// clone returns a copy of the info that doesn't share its OID.
+func (i *Info) clone() Info {
+	c := *i
+	if i.OID != nil {
+		c.OID = append(asn1.ObjectIdentifier(nil), i.OID...)
 	}
+	return c
 }
 
 // ByName returns the scheme with the given name and nil if it is not
 // supported.
 //
 // Names are case insensitive.
-func ByName(name string) kem.Scheme {
-	return allSchemeNames[strings.ToLower(name)]
+func ByName(name string) kem.Scheme { return table.ByName(name) }
+
+// ByOID returns the scheme with the given object identifier and nil if it is
+// not supported.
+func ByOID(oid asn1.ObjectIdentifier) kem.Scheme {
+	return table.Find(func(i *Info) bool { return i.OID != nil && i.OID.Equal(oid) })
+}
+
+// ByCodePoint returns the scheme with the given code point in the registry r
+// and nil if it is not supported.
+func ByCodePoint(r Registry, cp uint16) kem.Scheme {
+	return table.Find(func(i *Info) bool {
+		v, ok := i.CodePoint(r)
+		return ok && v == cp
+	})
+}
+
+// InfoOf returns a copy of the metadata of the scheme and nil if it is not
+// registered.
+func InfoOf(scheme kem.Scheme) *Info { return table.InfoOf(scheme) }
+
+// Filter returns, in the order of All, the schemes whose metadata satisfies
+// the predicate, which is given a copy of the metadata.
+func Filter(pred func(*Info) bool) []kem.Scheme { return table.Filter(pred) }
+
+// AtLeastLevel returns the schemes of NIST security category level or higher.
+func AtLeastLevel(level int) []kem.Scheme {
+	return Filter(func(i *Info) bool { return i.Level >= level })
 }
 
 // All returns all KEM schemes supported.
-func All() []kem.Scheme { a := allSchemes; return a[:] }
+func All() []kem.Scheme { return table.All() }
@@ -2,9 +2,11 @@ package schemes_test
 
 import (
 	"bytes"
+	"encoding/asn1"
 	"fmt"
 	"testing"
 
+	"github.com/karalef/circl/kem/kemtest"
 	"github.com/karalef/circl/kem/schemes"
 )
 
@@ -14,6 +16,63 @@ func TestCaseSensitivity(t *testing.T) {
 	}
 }
 
+func TestMetadata(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		info := schemes.InfoOf(scheme)
+		if info == nil || info.Scheme != scheme {
+			t.Fatal(scheme.Name())
+		}
+		if info.Level < 1 || info.Level > 5 {
+			t.Fatal(scheme.Name())
+		}
+		if info.OID != nil && schemes.ByOID(info.OID) != scheme {
+			t.Fatal(scheme.Name())
+		}
+		if cp, ok := info.CodePoint(schemes.TLS); ok && schemes.ByCodePoint(schemes.TLS, cp) != scheme {
+			t.Fatal(scheme.Name())
+		}
+	}
+
+	if schemes.ByOID(asn1.ObjectIdentifier{1, 2, 3}) != nil {
+		t.Fatal()
+	}
+	if schemes.ByCodePoint(schemes.TLS, 0) != nil {
+		t.Fatal()
+	}
+	if schemes.ByName("X25519") != nil {
+		t.Fatal()
+	}
+
+	for _, scheme := range schemes.AtLeastLevel(3) {
+		if schemes.InfoOf(scheme).Level < 3 {
+			t.Fatal(scheme.Name())
+		}
+	}
+}
+
+func TestInfoOfCopy(t *testing.T) {
+	// The metadata handed out can't change the registered one.
+	scheme := schemes.All()[0]
+	info := schemes.InfoOf(scheme)
+	oid := append(asn1.ObjectIdentifier(nil), info.OID...)
+	level, tls := info.Level, info.TLS
+	info.OID[len(info.OID)-1]++
+	info.Level, info.TLS = 0, 0
+	schemes.Filter(func(i *schemes.Info) bool {
+		i.OID[0]++
+		i.Level = 0
+		return false
+	})
+
+	info = schemes.InfoOf(scheme)
+	if !info.OID.Equal(oid) || info.Level != level || info.TLS != tls {
+		t.Fatal(scheme.Name())
+	}
+	if schemes.ByOID(oid) != scheme || len(schemes.AtLeastLevel(level)) == 0 {
+		t.Fatal(scheme.Name())
+	}
+}
+
 func BenchmarkGenerateKeyPair(b *testing.B) {
 	allSchemes := schemes.All()
 	for _, scheme := range allSchemes {
@@ -139,6 +198,13 @@ func TestApi(t *testing.T) {
 	}
 }
 
+func TestConformance(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) { kemtest.TestScheme(t, scheme) })
+	}
+}
+
 func Example_schemes() {
 	// import "github.com/karalef/circl/kem/schemes"
 
@@ -150,8 +216,79 @@ func Example_schemes() {
 	// Kyber512
 	// Kyber768
 	// Kyber1024
-	// Kyber512-X25519
-	// Kyber768-X25519
-	// Kyber768-X448
-	// Kyber1024-X448
+}
+
+func ExampleAtLeastLevel() {
+	// import "github.com/karalef/circl/kem/schemes"
+
+	for _, sch := range schemes.AtLeastLevel(3) {
+		info := schemes.InfoOf(sch)
+		fmt.Printf("%v %v %#04x\n", sch.Name(), info.OID, info.TLS)
+	}
+	// Output:
+	// Kyber768 1.3.6.1.4.1.22554.5.6.2 0x023c
+	// Kyber1024 1.3.6.1.4.1.22554.5.6.3 0x023d
+}
+
+func TestReader(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) {
+			seed := make([]byte, scheme.SeedSize())
+			eseed := make([]byte, scheme.EncapsulationSeedSize())
+			for i := range seed {
+				seed[i] = byte(i)
+			}
+			for i := range eseed {
+				eseed[i] = byte(i + 1)
+			}
+			rand := bytes.NewReader(append(append([]byte{}, seed...), eseed...))
+
+			pk, sk, err := scheme.GenerateKeyPairFrom(rand)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pk2, sk2 := scheme.DeriveKeyPair(seed)
+			if !pk.Equal(pk2) || !sk.Equal(sk2) {
+				t.Fatal("GenerateKeyPairFrom differs from DeriveKeyPair")
+			}
+
+			ct, ss, err := scheme.EncapsulateFrom(pk, rand)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ct2, ss2, err := scheme.Encapsulate(pk, eseed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ct, ct2) || !bytes.Equal(ss, ss2) {
+				t.Fatal("EncapsulateFrom differs from Encapsulate")
+			}
+
+			// The reader is exhausted.
+			if _, _, err = scheme.GenerateKeyPairFrom(rand); err == nil {
+				t.Fatal("expected an error")
+			}
+			if _, _, err = scheme.EncapsulateFrom(pk, rand); err == nil {
+				t.Fatal("expected an error")
+			}
+
+			// A nil reader defaults to crypto/rand.
+			pk, sk, err = scheme.GenerateKeyPairFrom(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ct, ss, err = scheme.EncapsulateFrom(pk, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ss2, err = scheme.Decapsulate(sk, ct)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ss, ss2) {
+				t.Fatal()
+			}
+		})
+	}
 }
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// The fuzz targets below check the AVX2 implementations of the polynomial
+// operations against the generic ones. They are trivial on other platforms,
+// where the operations are the generic ones.
+
+// fuzzPoly sets p from data, with coefficients in [-offset, max-offset).
+func fuzzPoly(data []byte, max, offset int16) (p Poly) {
+	var buf [2 * N]byte
+	copy(buf[:], data)
+	for i := range p {
+		x := int32(binary.LittleEndian.Uint16(buf[2*i:]))
+		p[i] = int16(x%int32(max) - int32(offset))
+	}
+	return p
+}
+
+func addSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 4*N))
+	ff := make([]byte, 4*N)
+	for i := range ff {
+		ff[i] = 0xff
+	}
+	f.Add(ff)
+	seq := make([]byte, 4*N)
+	for i := range seq {
+		seq[i] = byte(i * 37)
+	}
+	f.Add(seq)
+}
+
+func FuzzNTT(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := fuzzPoly(data, 2*Q+1, Q)
+		q1, q2 := p, p
+		q1.NTT()
+		q1.Detangle()
+		q2.nttGeneric()
+		if q1 != q2 {
+			t.Fatalf("NTT(%v) = %v != %v", p, q1, q2)
+		}
+		q1, q2 = p, p
+		q1.Tangle()
+		q1.InvNTT()
+		q2.invNTTGeneric()
+		q1.Normalize()
+		q2.Normalize()
+		if q1 != q2 {
+			t.Fatalf("InvNTT(%v) = %v != %v", p, q1, q2)
+		}
+	})
+}
+
+func FuzzArith(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		a := fuzzPoly(data, 2*Q+1, Q)
+		var b Poly
+		if len(data) > 2*N {
+			b = fuzzPoly(data[2*N:], 2*Q+1, Q)
+		}
+		var p1, p2 Poly
+		p1.Add(&a, &b)
+		p2.addGeneric(&a, &b)
+		if p1 != p2 {
+			t.Fatalf("Add(%v, %v) = %v != %v", a, b, p1, p2)
+		}
+		p1.Sub(&a, &b)
+		p2.subGeneric(&a, &b)
+		if p1 != p2 {
+			t.Fatalf("Sub(%v, %v) = %v != %v", a, b, p1, p2)
+		}
+		a2, b2 := a, b
+		a2.Tangle()
+		b2.Tangle()
+		p1.MulHat(&a2, &b2)
+		p1.Detangle()
+		p2.mulHatGeneric(&a, &b)
+		if p1 != p2 {
+			t.Fatalf("MulHat(%v, %v) = %v != %v", a, b, p1, p2)
+		}
+	})
+}
+
+func FuzzReduce(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := fuzzPoly(data, 9*Q, 0)
+		p1, p2 := p, p
+		p1.BarrettReduce()
+		p2.barrettReduceGeneric()
+		if p1 != p2 {
+			t.Fatalf("BarrettReduce(%v) = %v != %v", p, p1, p2)
+		}
+		p1, p2 = p, p
+		p1.Normalize()
+		p2.normalizeGeneric()
+		if p1 != p2 {
+			t.Fatalf("Normalize(%v) = %v != %v", p, p1, p2)
+		}
+	})
+}
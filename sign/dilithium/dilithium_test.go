@@ -105,3 +105,25 @@ func TestStream(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestVerifyTrailingBytes(t *testing.T) {
+	// A signature followed by more bytes is not the signature.
+	for _, name := range ModeNames() {
+		t.Run(name, func(t *testing.T) {
+			mode := ModeByName(name)
+			var seed [32]byte
+			pk, sk := mode.DeriveKey(seed[:])
+			msg := []byte("message")
+			sig := mode.Sign(sk, msg)
+			if !mode.Verify(pk, msg, sig) {
+				t.Fatal("valid signature rejected")
+			}
+			if mode.Verify(pk, msg, append(sig, 0)) {
+				t.Fatal("signature with trailing bytes accepted")
+			}
+			if mode.Verify(pk, msg, sig[:len(sig)-1]) {
+				t.Fatal("short signature accepted")
+			}
+		})
+	}
+}
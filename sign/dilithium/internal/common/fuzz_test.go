@@ -0,0 +1,127 @@
+package common
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// The fuzz targets below check the AVX2 implementations of the polynomial
+// operations against the generic ones. They are trivial on other platforms,
+// where the operations are the generic ones.
+
+// fuzzPoly sets p from data, with coefficients reduced modulo bound, or
+// arbitrary if bound is zero.
+func fuzzPoly(data []byte, bound uint32) (p Poly) {
+	var buf [4 * N]byte
+	copy(buf[:], data)
+	for i := range p {
+		p[i] = binary.LittleEndian.Uint32(buf[4*i:])
+		if bound != 0 {
+			p[i] %= bound
+		}
+	}
+	return p
+}
+
+func addSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 8*N))
+	ff := make([]byte, 8*N)
+	for i := range ff {
+		ff[i] = 0xff
+	}
+	f.Add(ff)
+	seq := make([]byte, 8*N)
+	for i := range seq {
+		seq[i] = byte(i * 37)
+	}
+	f.Add(seq)
+}
+
+func FuzzNTT(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := fuzzPoly(data, 2*Q)
+		q1, q2 := p, p
+		q1.NTT()
+		q2.nttGeneric()
+		if q1 != q2 {
+			t.Fatalf("NTT(%v) = %v != %v", p, q1, q2)
+		}
+		q1, q2 = p, p
+		q1.InvNTT()
+		q2.invNttGeneric()
+		if q1 != q2 {
+			t.Fatalf("InvNTT(%v) = %v != %v", p, q1, q2)
+		}
+	})
+}
+
+func FuzzArith(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		a := fuzzPoly(data, 2*Q)
+		var b Poly
+		if len(data) > 4*N {
+			b = fuzzPoly(data[4*N:], 2*Q)
+		}
+		var p1, p2 Poly
+		p1.Add(&a, &b)
+		p2.addGeneric(&a, &b)
+		if p1 != p2 {
+			t.Fatalf("Add(%v, %v) = %v != %v", a, b, p1, p2)
+		}
+		p1.Sub(&a, &b)
+		p2.subGeneric(&a, &b)
+		if p1 != p2 {
+			t.Fatalf("Sub(%v, %v) = %v != %v", a, b, p1, p2)
+		}
+		p1.MulHat(&a, &b)
+		p2.mulHatGeneric(&a, &b)
+		if p1 != p2 {
+			t.Fatalf("MulHat(%v, %v) = %v != %v", a, b, p1, p2)
+		}
+		p1.MulBy2toD(&a)
+		p2.mulBy2toDGeneric(&a)
+		if p1 != p2 {
+			t.Fatalf("MulBy2toD(%v) = %v != %v", a, p1, p2)
+		}
+		p1, p2 = a, a
+		p1.NormalizeAssumingLe2Q()
+		p2.normalizeAssumingLe2QGeneric()
+		if p1 != p2 {
+			t.Fatalf("NormalizeAssumingLe2Q(%v) = %v != %v", a, p1, p2)
+		}
+		bound := b[0] % Q
+		if p1.Exceeds(bound) != p1.exceedsGeneric(bound) {
+			t.Fatalf("Exceeds(%v, %d) differs", p1, bound)
+		}
+	})
+}
+
+func FuzzReduce(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := fuzzPoly(data, 0)
+		p1, p2 := p, p
+		p1.ReduceLe2Q()
+		p2.reduceLe2QGeneric()
+		if p1 != p2 {
+			t.Fatalf("ReduceLe2Q(%v) = %v != %v", p, p1, p2)
+		}
+		p1, p2 = p, p
+		p1.Normalize()
+		p2.normalizeGeneric()
+		if p1 != p2 {
+			t.Fatalf("Normalize(%v) = %v != %v", p, p1, p2)
+		}
+
+		var buf1, buf2 [PolyLe16Size]byte
+		p = fuzzPoly(data, 16)
+		p.PackLe16(buf1[:])
+		p.packLe16Generic(buf2[:])
+		if buf1 != buf2 {
+			t.Fatalf("PackLe16(%v) = %x != %x", p, buf1, buf2)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// Code generated from mode3/internal/fuzz_test.go by gen.go
+
+package internal
+
+import (
+	"bytes"
+	"testing"
+)
+
+// addSignatures seeds the corpus of f with valid signatures.
+func addSignatures(f *testing.F, add func(sig []byte)) {
+	var seed [32]byte
+	for i := 0; i < 4; i++ {
+		seed[0] = byte(i)
+		_, sk := NewKeyFromSeed(&seed)
+		var sig [SignatureSize]byte
+		SignTo(sk, seed[:i], sig[:])
+		add(sig[:])
+	}
+}
+
+func FuzzUnpackSignature(f *testing.F) {
+	addSignatures(f, func(sig []byte) { f.Add(sig) })
+	f.Add(make([]byte, SignatureSize))
+	f.Add(bytes.Repeat([]byte{0xff}, SignatureSize))
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		var sig unpackedSignature
+		if !sig.Unpack(buf) {
+			return
+		}
+
+		// The encoding of an accepted signature is unique.
+		out := make([]byte, SignatureSize)
+		sig.Pack(out)
+		if !bytes.Equal(out, buf) {
+			t.Fatalf("Pack(Unpack(%x)) = %x", buf, out)
+		}
+	})
+}
+
+func FuzzUnpackHint(f *testing.F) {
+	addSignatures(f, func(sig []byte) { f.Add(sig[32+L*PolyLeGamma1Size:]) })
+	f.Add(make([]byte, Omega+K))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var buf [Omega + K]byte
+		copy(buf[:], data)
+		var v VecK
+		if !v.UnpackHint(buf[:]) {
+			return
+		}
+
+		ones := 0
+		for i := 0; i < K; i++ {
+			for j := range v[i] {
+				if v[i][j] > 1 {
+					t.Fatalf("hint coefficient %d", v[i][j])
+				}
+				ones += int(v[i][j])
+			}
+		}
+		if ones > Omega {
+			t.Fatalf("%d ones in hint", ones)
+		}
+
+		var out [Omega + K]byte
+		v.PackHint(out[:])
+		if out != buf {
+			t.Fatalf("PackHint(UnpackHint(%x)) = %x", buf, out)
+		}
+	})
+}
@@ -0,0 +1,261 @@
+// Package envelope provides self-describing containers for signatures
+// produced by the schemes of the sign package.
+//
+// A detached Signature records the scheme that produced it, the fingerprint
+// of the signing key and the signature itself, so a verifier only needs the
+// public key and the message. Signatures can be computed over an io.Reader
+// with SignReader and VerifyReader without buffering the whole message.
+//
+// An attached signed message, as produced by Seal, consists of an encoded
+// Signature followed by the message. Open returns the message only after
+// the signature has been checked.
+//
+// # Encoding
+//
+// A Signature is encoded as
+//
+//	version (1 byte) ‖ len(name) (1 byte) ‖ name ‖ fingerprint ‖ signature
+//
+// where name is the scheme name as registered in
+//
+//	github.com/karalef/circl/sign/schemes
+//
+// fingerprint is the FingerprintSize-byte SHA3-256 digest of the packed public
+// key and the length of signature is given by the scheme's SignatureSize().
+package envelope
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/karalef/circl/internal/sha3"
+	"github.com/karalef/circl/sign"
+	"github.com/karalef/circl/sign/schemes"
+)
+
+const (
+	// Version of the encoding produced by this package.
+	Version = 1
+
+	// FingerprintSize is the size of a key fingerprint.
+	FingerprintSize = 32
+
+	// maximum length of a scheme name in the encoding.
+	maxNameSize = 255
+)
+
+var (
+	// ErrUnknownScheme is returned when the encoded scheme is not registered.
+	ErrUnknownScheme = errors.New("envelope: unknown signature scheme")
+
+	// ErrVersion is returned when the encoding version is not supported.
+	ErrVersion = errors.New("envelope: unsupported version")
+
+	// ErrMalformed is returned when the encoding can not be parsed.
+	ErrMalformed = errors.New("envelope: malformed signature")
+
+	// ErrKeyMismatch is returned when the public key does not match the
+	// scheme or the fingerprint recorded in the signature.
+	ErrKeyMismatch = errors.New("envelope: public key does not match signature")
+
+	// ErrVerification is returned when the signature is invalid.
+	ErrVerification = errors.New("envelope: invalid signature")
+)
+
+// Fingerprint returns the fingerprint of the public key, that is the
+// SHA3-256 digest of its packed form.
+func Fingerprint(pk sign.PublicKey) [FingerprintSize]byte {
+	return sha3.Sum256(pk.Bytes())
+}
+
+// Signature is a detached signature together with the information needed to
+// verify it.
+type Signature struct {
+	// Scheme that produced the signature.
+	Scheme sign.Scheme
+
+	// Fingerprint of the public key that verifies the signature.
+	Fingerprint [FingerprintSize]byte
+
+	// Signature bytes as produced by Scheme.
+	Signature []byte
+}
+
+// Size returns the size of the encoded signature.
+func (s *Signature) Size() int {
+	return 2 + len(s.Scheme.Name()) + FingerprintSize + len(s.Signature)
+}
+
+// MarshalBinary encodes the signature.
+func (s *Signature) MarshalBinary() ([]byte, error) {
+	if s.Scheme == nil {
+		return nil, ErrUnknownScheme
+	}
+	name := s.Scheme.Name()
+	if len(name) > maxNameSize || len(s.Signature) != s.Scheme.SignatureSize() {
+		return nil, ErrMalformed
+	}
+
+	buf := make([]byte, 0, s.Size())
+	buf = append(buf, Version, byte(len(name)))
+	buf = append(buf, name...)
+	buf = append(buf, s.Fingerprint[:]...)
+	buf = append(buf, s.Signature...)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes the signature from data. The data must contain
+// exactly one encoded signature.
+func (s *Signature) UnmarshalBinary(data []byte) error {
+	n, err := s.unmarshal(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return ErrMalformed
+	}
+	return nil
+}
+
+// unmarshal decodes the signature at the start of data and returns the
+// number of bytes consumed.
+func (s *Signature) unmarshal(data []byte) (int, error) {
+	if len(data) < 2 {
+		return 0, ErrMalformed
+	}
+	if data[0] != Version {
+		return 0, ErrVersion
+	}
+	nameLen := int(data[1])
+	data = data[2:]
+	if len(data) < nameLen+FingerprintSize {
+		return 0, ErrMalformed
+	}
+
+	scheme := schemes.ByName(string(data[:nameLen]))
+	if scheme == nil {
+		return 0, ErrUnknownScheme
+	}
+	data = data[nameLen:]
+
+	sigSize := scheme.SignatureSize()
+	if len(data) < FingerprintSize+sigSize {
+		return 0, ErrMalformed
+	}
+
+	s.Scheme = scheme
+	copy(s.Fingerprint[:], data[:FingerprintSize])
+	s.Signature = append([]byte(nil), data[FingerprintSize:FingerprintSize+sigSize]...)
+	return 2 + nameLen + FingerprintSize + sigSize, nil
+}
+
+// Parse decodes an encoded detached signature.
+func Parse(data []byte) (*Signature, error) {
+	sig := new(Signature)
+	if err := sig.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// Sign signs the message with sk and returns the detached signature.
+func Sign(sk sign.PrivateKey, message []byte) *Signature {
+	scheme := sk.Scheme()
+	return &Signature{
+		Scheme:      scheme,
+		Fingerprint: Fingerprint(sk.Public()),
+		Signature:   scheme.Sign(sk, message),
+	}
+}
+
+// SignReader signs the data read from r until EOF with sk and returns the
+// detached signature.
+func SignReader(sk sign.PrivateKey, r io.Reader) (*Signature, error) {
+	scheme := sk.Scheme()
+	signer := scheme.Signer(sk)
+	if _, err := io.Copy(signer, r); err != nil {
+		return nil, err
+	}
+	return &Signature{
+		Scheme:      scheme,
+		Fingerprint: Fingerprint(sk.Public()),
+		Signature:   signer.Sign(),
+	}, nil
+}
+
+// checkKey returns an error if pk can not have produced sig.
+func (s *Signature) checkKey(pk sign.PublicKey) error {
+	if s.Scheme == nil || pk.Scheme() != s.Scheme {
+		return ErrKeyMismatch
+	}
+	fp := Fingerprint(pk)
+	if subtle.ConstantTimeCompare(fp[:], s.Fingerprint[:]) != 1 {
+		return ErrKeyMismatch
+	}
+	return nil
+}
+
+// Verify checks whether sig is a valid signature by pk on message.
+func Verify(pk sign.PublicKey, message []byte, sig *Signature) error {
+	if err := sig.checkKey(pk); err != nil {
+		return err
+	}
+	if !sig.Scheme.Verify(pk, message, sig.Signature) {
+		return ErrVerification
+	}
+	return nil
+}
+
+// VerifyReader checks whether sig is a valid signature by pk on the data
+// read from r until EOF.
+func VerifyReader(pk sign.PublicKey, r io.Reader, sig *Signature) error {
+	if err := sig.checkKey(pk); err != nil {
+		return err
+	}
+	verifier := sig.Scheme.Verifier(pk)
+	if _, err := io.Copy(verifier, r); err != nil {
+		return err
+	}
+	if !verifier.Verify(sig.Signature) {
+		return ErrVerification
+	}
+	return nil
+}
+
+// Seal signs the message with sk and returns the signed message, consisting
+// of the encoded detached signature followed by the message.
+func Seal(sk sign.PrivateKey, message []byte) ([]byte, error) {
+	sig, err := Sign(sk, message).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(sig, message...), nil
+}
+
+// Open checks the signed message produced by Seal against pk and returns
+// the message if the signature is valid.
+//
+// The returned slice aliases signed.
+func Open(pk sign.PublicKey, signed []byte) ([]byte, error) {
+	var sig Signature
+	n, err := sig.unmarshal(signed)
+	if err != nil {
+		return nil, err
+	}
+	message := signed[n:]
+	if err := Verify(pk, message, &sig); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// OpenReader reads a signed message produced by Seal from r until EOF,
+// checks it against pk and returns the message if the signature is valid.
+func OpenReader(pk sign.PublicKey, r io.Reader) ([]byte, error) {
+	signed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Open(pk, signed)
+}
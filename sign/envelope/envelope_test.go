@@ -0,0 +1,116 @@
+package envelope_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/karalef/circl/internal/test"
+	"github.com/karalef/circl/sign/envelope"
+	"github.com/karalef/circl/sign/schemes"
+)
+
+func TestDetached(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) {
+			pk, sk, err := scheme.GenerateKey(nil)
+			test.CheckNoErr(t, err, "key generation failed")
+			pk2, _, err := scheme.GenerateKey(nil)
+			test.CheckNoErr(t, err, "key generation failed")
+
+			msg := bytes.Repeat([]byte("envelope"), 1000)
+			sig, err := envelope.SignReader(sk, bytes.NewReader(msg))
+			test.CheckNoErr(t, err, "SignReader failed")
+
+			test.CheckOk(scheme.Verify(pk, msg, sig.Signature), "streamed signature is invalid", t)
+			test.CheckOk(scheme.Verify(pk, msg, envelope.Sign(sk, msg).Signature), "signature is invalid", t)
+
+			enc, err := sig.MarshalBinary()
+			test.CheckNoErr(t, err, "MarshalBinary failed")
+			test.CheckOk(len(enc) == sig.Size(), "bad size", t)
+
+			sig2, err := envelope.Parse(enc)
+			test.CheckNoErr(t, err, "Parse failed")
+			test.CheckOk(sig2.Scheme == scheme, "scheme mismatch", t)
+			test.CheckOk(sig2.Fingerprint == envelope.Fingerprint(pk), "fingerprint mismatch", t)
+
+			err = envelope.VerifyReader(pk, bytes.NewReader(msg), sig2)
+			test.CheckNoErr(t, err, "VerifyReader failed")
+			err = envelope.Verify(pk, msg, sig2)
+			test.CheckNoErr(t, err, "Verify failed")
+
+			err = envelope.Verify(pk2, msg, sig2)
+			test.CheckOk(errors.Is(err, envelope.ErrKeyMismatch), "should fail due to wrong key", t)
+
+			err = envelope.VerifyReader(pk, bytes.NewReader(msg[1:]), sig2)
+			test.CheckOk(errors.Is(err, envelope.ErrVerification), "should fail due to bad message", t)
+
+			_, err = envelope.Parse(enc[:len(enc)-1])
+			test.CheckIsErr(t, err, "should fail due to short signature")
+			_, err = envelope.Parse(append(enc, 0))
+			test.CheckIsErr(t, err, "should fail due to trailing data")
+		})
+	}
+}
+
+func TestAttached(t *testing.T) {
+	scheme := schemes.ByName("Dilithium2")
+	pk, sk, err := scheme.GenerateKey(nil)
+	test.CheckNoErr(t, err, "key generation failed")
+
+	msg := []byte("attached message")
+	signed, err := envelope.Seal(sk, msg)
+	test.CheckNoErr(t, err, "Seal failed")
+
+	got, err := envelope.Open(pk, signed)
+	test.CheckNoErr(t, err, "Open failed")
+	if !bytes.Equal(got, msg) {
+		test.ReportError(t, got, msg)
+	}
+
+	got, err = envelope.OpenReader(pk, bytes.NewReader(signed))
+	test.CheckNoErr(t, err, "OpenReader failed")
+	if !bytes.Equal(got, msg) {
+		test.ReportError(t, got, msg)
+	}
+
+	signed[len(signed)-1] ^= 0xFF
+	got, err = envelope.Open(pk, signed)
+	test.CheckOk(errors.Is(err, envelope.ErrVerification), "should fail due to tampered message", t)
+	test.CheckOk(got == nil, "should not return message", t)
+}
+
+func TestBadInputs(t *testing.T) {
+	_, err := envelope.Parse(nil)
+	test.CheckOk(errors.Is(err, envelope.ErrMalformed), "should fail due to empty input", t)
+
+	_, err = envelope.Parse([]byte{envelope.Version + 1, 0})
+	test.CheckOk(errors.Is(err, envelope.ErrVersion), "should fail due to bad version", t)
+
+	name := "unknown"
+	enc := append([]byte{envelope.Version, byte(len(name))}, name...)
+	enc = append(enc, make([]byte, envelope.FingerprintSize)...)
+	_, err = envelope.Parse(enc)
+	test.CheckOk(errors.Is(err, envelope.ErrUnknownScheme), "should fail due to unknown scheme", t)
+
+	_, err = new(envelope.Signature).MarshalBinary()
+	test.CheckIsErr(t, err, "should fail due to missing scheme")
+}
+
+func Example() {
+	// import "github.com/karalef/circl/sign/schemes"
+	scheme := schemes.ByName("Dilithium3")
+	pk, sk, _ := scheme.GenerateKey(nil)
+
+	// Sign a stream, e.g. a file, and encode the detached signature.
+	sig, _ := envelope.SignReader(sk, bytes.NewReader([]byte("some data")))
+	enc, _ := sig.MarshalBinary()
+
+	// The verifier learns the scheme from the encoded signature.
+	sig2, _ := envelope.Parse(enc)
+	err := envelope.VerifyReader(pk, bytes.NewReader([]byte("some data")), sig2)
+	fmt.Println(sig2.Scheme.Name(), err)
+	// Output: Dilithium3 <nil>
+}
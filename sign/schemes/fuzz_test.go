@@ -0,0 +1,127 @@
+package schemes_test
+
+import (
+	"testing"
+
+	"github.com/karalef/circl/internal/nist"
+	"github.com/karalef/circl/sign"
+	"github.com/karalef/circl/sign/schemes"
+)
+
+// fuzzScheme returns the scheme selected by a fuzzed index.
+func fuzzScheme(i uint8) sign.Scheme {
+	all := schemes.All()
+	return all[int(i)%len(all)]
+}
+
+// fuzzKeys derives the keys of the scheme from a fixed seed.
+func fuzzKeys(s sign.Scheme) (sign.PublicKey, sign.PrivateKey) {
+	seed := make([]byte, s.SeedSize())
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	return s.DeriveKey(seed)
+}
+
+var fuzzMessage = []byte("fuzz")
+
+// katCount is the number of vectors of the NIST KATs added to the corpus.
+const katCount = 3
+
+// addCorpus seeds the corpus of f with the keys and a signature of every
+// scheme, both from the fixed seed and from the first inputs of the NIST
+// KATs of sign/dilithium, which also sign the message of the KAT.
+func addCorpus(f *testing.F, add func(i uint8, pk, sk, msg, sig []byte)) {
+	for i, s := range schemes.All() {
+		pk, sk := fuzzKeys(s)
+		add(uint8(i), pk.Bytes(), sk.Bytes(), fuzzMessage, s.Sign(sk, fuzzMessage))
+
+		var seed [48]byte
+		var eseed [32]byte
+		for j := range seed {
+			seed[j] = byte(j)
+		}
+		g := nist.NewDRBG(&seed)
+		for j := 0; j < katCount; j++ {
+			g.Fill(seed[:])
+			msg := make([]byte, 33*(j+1))
+			g.Fill(msg)
+			g2 := nist.NewDRBG(&seed)
+			g2.Fill(eseed[:])
+			pk, sk := s.DeriveKey(eseed[:])
+			add(uint8(i), pk.Bytes(), sk.Bytes(), msg, s.Sign(sk, msg))
+		}
+	}
+}
+
+func FuzzUnmarshalBinaryPublicKey(f *testing.F) {
+	addCorpus(f, func(i uint8, pk, _, _, _ []byte) { f.Add(i, pk) })
+	f.Add(uint8(0), []byte{})
+
+	f.Fuzz(func(t *testing.T, i uint8, data []byte) {
+		s := fuzzScheme(i)
+		pk, err := s.UnmarshalBinaryPublicKey(data)
+		if len(data) != s.PublicKeySize() {
+			if err == nil {
+				t.Fatalf("%s: public key of %d bytes accepted", s.Name(), len(data))
+			}
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		pk2, err := s.UnmarshalBinaryPublicKey(pk.Bytes())
+		if err != nil || !pk.Equal(pk2) {
+			t.Fatalf("%s: public key changed by a round trip", s.Name())
+		}
+		_ = s.Verify(pk, fuzzMessage, make([]byte, s.SignatureSize()))
+	})
+}
+
+func FuzzUnmarshalBinaryPrivateKey(f *testing.F) {
+	addCorpus(f, func(i uint8, _, sk, _, _ []byte) { f.Add(i, sk) })
+	f.Add(uint8(0), []byte{})
+
+	f.Fuzz(func(t *testing.T, i uint8, data []byte) {
+		s := fuzzScheme(i)
+		sk, err := s.UnmarshalBinaryPrivateKey(data)
+		if len(data) != s.PrivateKeySize() {
+			if err == nil {
+				t.Fatalf("%s: private key of %d bytes accepted", s.Name(), len(data))
+			}
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		sk2, err := s.UnmarshalBinaryPrivateKey(sk.Bytes())
+		if err != nil || !sk.Equal(sk2) {
+			t.Fatalf("%s: private key changed by a round trip", s.Name())
+		}
+	})
+}
+
+func FuzzVerify(f *testing.F) {
+	addCorpus(f, func(i uint8, _, _, msg, sig []byte) { f.Add(i, msg, sig) })
+	f.Add(uint8(0), []byte{}, []byte{})
+
+	f.Fuzz(func(t *testing.T, i uint8, msg, sig []byte) {
+		s := fuzzScheme(i)
+		pk, sk := fuzzKeys(s)
+		ok := s.Verify(pk, msg, sig)
+
+		v := s.Verifier(pk)
+		_, _ = v.Write(msg)
+		if v.Verify(sig) != ok {
+			t.Fatalf("%s: Verifier differs from Verify", s.Name())
+		}
+
+		// The signatures are deterministic, and the only valid one is that
+		// of the private key.
+		if ok && string(sig) != string(s.Sign(sk, msg)) {
+			t.Fatalf("%s: forged signature %x on %x", s.Name(), sig, msg)
+		}
+	})
+}
@@ -1,43 +1,170 @@
 // Package schemes contains a register of signature algorithms.
 //
+// Besides the lookup by name, every scheme is registered together with its
+// metadata (see Info): the NIST security category, the standardization
+// status, the ASN.1 object identifier and the code points assigned to it by
+// protocol registries. This allows protocol code to negotiate algorithms
+// from the register instead of keeping its own tables.
+//
 // Implemented schemes:
 //
-//	Ed25519-Dilithium2
-//	Ed448-Dilithium3
+//	Dilithium2, Dilithium2-AES
+//	Dilithium3, Dilithium3-AES
+//	Dilithium5, Dilithium5-AES
 package schemes
 
 import (
-	"strings"
+	"encoding/asn1"
 
+	"github.com/karalef/circl/internal/registry"
 	"github.com/karalef/circl/sign"
 	"github.com/karalef/circl/sign/dilithium"
 )
 
-var allSchemes = [...]sign.Scheme{
-	dilithium.Mode2,
-	dilithium.Mode2AES,
-	dilithium.Mode3,
-	dilithium.Mode3AES,
-	dilithium.Mode5,
-	dilithium.Mode5AES,
+// Status describes how far a scheme is in its standardization process.
+type Status = registry.Status
+
+const (
+	// Experimental schemes are not submitted to any standardization process.
+	Experimental = registry.Experimental
+	// Candidate schemes are submissions to a standardization process, such
+	// as the rounds of the NIST PQC competition, or Internet-Drafts.
+	Candidate = registry.Candidate
+	// Standard schemes are published as a final standard.
+	Standard = registry.Standard
+)
+
+// Registry identifies a protocol registry of code points. The COSE and JOSE
+// registries only list ML-DSA, not round 3 Dilithium, so TLS is the only
+// one with code points for the registered schemes.
+type Registry int
+
+const (
+	// TLS SignatureScheme values.
+	TLS Registry = iota + 1
+)
+
+// Info holds the metadata of a registered signature scheme. The sizes of
+// keys and signatures are available through the embedded Scheme.
+type Info struct {
+	sign.Scheme
+
+	// Level is the NIST security category, from 1 to 5.
+	Level int
+
+	// Status of standardization of the scheme.
+	Status Status
+
+	// OID is the ASN.1 object identifier used for both the public key and
+	// the signature algorithm, or nil if none is assigned.
+	OID asn1.ObjectIdentifier
+
+	// TLS is the TLS SignatureScheme code point, or 0 if none is assigned.
+	TLS uint16
 }
 
-var allSchemeNames map[string]sign.Scheme
+// CodePoint returns the code point of the scheme in the registry r and
+// whether one is assigned.
+func (i *Info) CodePoint(r Registry) (uint16, bool) {
+	switch r {
+	case TLS:
+		return i.TLS, i.TLS != 0
+	default:
+		return 0, false
+	}
+}
+
+// Object identifiers and TLS code points of round 3 Dilithium as assigned by
+// the Open Quantum Safe project.
+var allInfos = [...]Info{
+	{
+		Scheme: dilithium.Mode2,
+		Level:  2,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 4, 4},
+		TLS:    0xfea0,
+	},
+	{
+		Scheme: dilithium.Mode2AES,
+		Level:  2,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 4, 4},
+		TLS:    0xfea7,
+	},
+	{
+		Scheme: dilithium.Mode3,
+		Level:  3,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 6, 5},
+		TLS:    0xfea3,
+	},
+	{
+		Scheme: dilithium.Mode3AES,
+		Level:  3,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 6, 5},
+		TLS:    0xfeaa,
+	},
+	{
+		Scheme: dilithium.Mode5,
+		Level:  5,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 8, 7},
+		TLS:    0xfea5,
+	},
+	{
+		Scheme: dilithium.Mode5AES,
+		Level:  5,
+		Status: Candidate,
+		OID:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 8, 7},
+		TLS:    0xfeac,
+	},
+}
 
-func init() {
-	allSchemeNames = make(map[string]sign.Scheme)
-	for _, scheme := range allSchemes {
-		allSchemeNames[strings.ToLower(scheme.Name())] = scheme
+var table = registry.New(allInfos[:], func(i *Info) sign.Scheme { return i.Scheme }, (*Info).clone)
+
+// clone returns a copy of the info that doesn't share its OID.
+func (i *Info) clone() Info {
+	c := *i
+	if i.OID != nil {
+		c.OID = append(asn1.ObjectIdentifier(nil), i.OID...)
 	}
+	return c
 }
 
 // ByName returns the scheme with the given name and nil if it is not
 // supported.
 //
 // Names are case insensitive.
-func ByName(name string) sign.Scheme {
-	return allSchemeNames[strings.ToLower(name)]
+func ByName(name string) sign.Scheme { return table.ByName(name) }
+
+// ByOID returns the scheme with the given object identifier and nil if it is
+// not supported.
+func ByOID(oid asn1.ObjectIdentifier) sign.Scheme {
+	return table.Find(func(i *Info) bool { return i.OID != nil && i.OID.Equal(oid) })
+}
+
+// ByCodePoint returns the scheme with the given code point in the registry r
+// and nil if it is not supported.
+func ByCodePoint(r Registry, cp uint16) sign.Scheme {
+	return table.Find(func(i *Info) bool {
+		v, ok := i.CodePoint(r)
+		return ok && v == cp
+	})
+}
+
+// InfoOf returns a copy of the metadata of the scheme and nil if it is not
+// registered.
+func InfoOf(scheme sign.Scheme) *Info { return table.InfoOf(scheme) }
+
+// Filter returns, in the order of All, the schemes whose metadata satisfies
+// the predicate, which is given a copy of the metadata.
+func Filter(pred func(*Info) bool) []sign.Scheme { return table.Filter(pred) }
+
+// AtLeastLevel returns the schemes of NIST security category level or higher.
+func AtLeastLevel(level int) []sign.Scheme {
+	return Filter(func(i *Info) bool { return i.Level >= level })
 }
 
 // All returns all signature schemes supported.
-func All() []sign.Scheme { a := allSchemes; return a[:] }
+func All() []sign.Scheme { return table.All() }
@@ -1,18 +1,80 @@
 package schemes_test
 
 import (
+	"encoding/asn1"
 	"fmt"
 	"testing"
 
 	"github.com/karalef/circl/sign/schemes"
+	"github.com/karalef/circl/sign/signtest"
 )
 
 func TestCaseSensitivity(t *testing.T) {
-	if schemes.ByName("ed25519") != schemes.ByName("Ed25519") {
+	if schemes.ByName("dilithium2") != schemes.ByName("Dilithium2") {
 		t.Fatal()
 	}
 }
 
+func TestMetadata(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		info := schemes.InfoOf(scheme)
+		if info == nil || info.Scheme != scheme {
+			t.Fatal(scheme.Name())
+		}
+		if info.Level < 1 || info.Level > 5 {
+			t.Fatal(scheme.Name())
+		}
+		if info.OID != nil && schemes.ByOID(info.OID) != scheme {
+			t.Fatal(scheme.Name())
+		}
+		if cp, ok := info.CodePoint(schemes.TLS); ok && schemes.ByCodePoint(schemes.TLS, cp) != scheme {
+			t.Fatal(scheme.Name())
+		}
+	}
+
+	if schemes.ByOID(asn1.ObjectIdentifier{1, 2, 3}) != nil {
+		t.Fatal()
+	}
+	if schemes.ByCodePoint(schemes.TLS, 0) != nil {
+		t.Fatal()
+	}
+	if schemes.InfoOf(nil) != nil {
+		t.Fatal()
+	}
+
+	for _, scheme := range schemes.AtLeastLevel(3) {
+		if schemes.InfoOf(scheme).Level < 3 {
+			t.Fatal(scheme.Name())
+		}
+	}
+	if len(schemes.AtLeastLevel(1)) != len(schemes.All()) {
+		t.Fatal()
+	}
+}
+
+func TestInfoOfCopy(t *testing.T) {
+	// The metadata handed out can't change the registered one.
+	scheme := schemes.All()[0]
+	info := schemes.InfoOf(scheme)
+	oid := append(asn1.ObjectIdentifier(nil), info.OID...)
+	level, tls := info.Level, info.TLS
+	info.OID[len(info.OID)-1]++
+	info.Level, info.TLS = 0, 0
+	schemes.Filter(func(i *schemes.Info) bool {
+		i.OID[0]++
+		i.Level = 0
+		return false
+	})
+
+	info = schemes.InfoOf(scheme)
+	if !info.OID.Equal(oid) || info.Level != level || info.TLS != tls {
+		t.Fatal(scheme.Name())
+	}
+	if schemes.ByOID(oid) != scheme || len(schemes.AtLeastLevel(level)) == 0 {
+		t.Fatal(scheme.Name())
+	}
+}
+
 func TestApi(t *testing.T) {
 	allSchemes := schemes.All()
 	for _, scheme := range allSchemes {
@@ -95,13 +157,38 @@ func TestApi(t *testing.T) {
 	}
 }
 
+func TestConformance(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) { signtest.TestScheme(t, scheme) })
+	}
+}
+
 func Example() {
 	for _, sch := range schemes.All() {
 		fmt.Println(sch.Name())
 	}
 	// Output:
-	// Ed25519-Dilithium2
-	// Ed448-Dilithium3
+	// Dilithium2
+	// Dilithium2-AES
+	// Dilithium3
+	// Dilithium3-AES
+	// Dilithium5
+	// Dilithium5-AES
+}
+
+func ExampleFilter() {
+	// Negotiate the smallest signatures of NIST category 3 or higher
+	// that can be identified in TLS.
+	for _, sch := range schemes.Filter(func(i *schemes.Info) bool {
+		_, ok := i.CodePoint(schemes.TLS)
+		return ok && i.Level >= 3 && i.SignatureSize() < 4000
+	}) {
+		fmt.Println(sch.Name())
+	}
+	// Output:
+	// Dilithium3
+	// Dilithium3-AES
 }
 
 func BenchmarkGenerateKeyPair(b *testing.B) {
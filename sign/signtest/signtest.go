@@ -0,0 +1,227 @@
+// Package signtest implements conformance tests for implementations of
+// sign.Scheme.
+//
+// It can be used to check that schemes defined outside of this module, such
+// as wrappers of keys held in hardware, follow the conventions of the sign
+// package:
+//
+//	func TestMyScheme(t *testing.T) {
+//		signtest.TestScheme(t, myScheme)
+//	}
+package signtest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karalef/circl/internal/test"
+	"github.com/karalef/circl/sign"
+)
+
+// TestScheme checks that the scheme
+//
+//   - reports sizes matching those of its keys and signatures,
+//   - unmarshals its marshalled keys to equal keys and rejects wrong sizes,
+//   - derives the same keys from the same seeds,
+//   - verifies its signatures and rejects altered ones,
+//   - produces and verifies with its Signer and Verifier the same signatures
+//     as with Sign and Verify, whatever the writes the message is split into,
+//   - and compares keys with Equal consistently with their encodings.
+func TestScheme(t *testing.T, s sign.Scheme) {
+	t.Run("Sizes", func(t *testing.T) { testSizes(t, s) })
+	t.Run("Marshal", func(t *testing.T) { testMarshal(t, s) })
+	t.Run("Derive", func(t *testing.T) { testDerive(t, s) })
+	t.Run("SignVerify", func(t *testing.T) { testSignVerify(t, s) })
+	t.Run("Streaming", func(t *testing.T) { testStreaming(t, s) })
+	t.Run("Equal", func(t *testing.T) { testEqual(t, s) })
+}
+
+func seed(n int, start byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = start + byte(i)
+	}
+	return b
+}
+
+func message(s sign.Scheme) []byte {
+	return bytes.Repeat([]byte("Signing with "+s.Name()+". "), 50)
+}
+
+func testSizes(t *testing.T, s sign.Scheme) {
+	test.CheckOk(s.Name() != "", "empty name", t)
+	test.CheckOk(s.PublicKeySize() > 0, "PublicKeySize is not positive", t)
+	test.CheckOk(s.PrivateKeySize() > 0, "PrivateKeySize is not positive", t)
+	test.CheckOk(s.SignatureSize() > 0, "SignatureSize is not positive", t)
+	test.CheckOk(s.SeedSize() > 0, "SeedSize is not positive", t)
+
+	pk, sk, err := s.GenerateKey(nil)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(ppk) == s.PublicKeySize(), "wrong size of public key", t)
+	test.CheckOk(bytes.Equal(pk.Bytes(), ppk), "Bytes differs from MarshalBinary", t)
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(psk) == s.PrivateKeySize(), "wrong size of private key", t)
+	test.CheckOk(bytes.Equal(sk.Bytes(), psk), "Bytes differs from MarshalBinary", t)
+
+	sig := s.Sign(sk, message(s))
+	test.CheckOk(len(sig) == s.SignatureSize(), "wrong size of signature", t)
+}
+
+func testMarshal(t *testing.T, s sign.Scheme) {
+	pk, sk, err := s.GenerateKey(nil)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	ppk, _ := pk.MarshalBinary()
+	psk, _ := sk.MarshalBinary()
+
+	pk2, err := s.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	sk2, err := s.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	test.CheckOk(pk.Equal(pk2), "public key changed by a round trip", t)
+	test.CheckOk(sk.Equal(sk2), "private key changed by a round trip", t)
+	ppk2, _ := pk2.MarshalBinary()
+	psk2, _ := sk2.MarshalBinary()
+	test.CheckOk(bytes.Equal(ppk, ppk2), "public key encoding changed by a round trip", t)
+	test.CheckOk(bytes.Equal(psk, psk2), "private key encoding changed by a round trip", t)
+
+	test.CheckOk(pk2.Scheme() == s, "wrong scheme of public key", t)
+	test.CheckOk(sk2.Scheme() == s, "wrong scheme of private key", t)
+	test.CheckOk(sk2.Public().Equal(pk), "wrong public key of private key", t)
+
+	// The keys are usable after a round trip.
+	msg := message(s)
+	test.CheckOk(s.Verify(pk2, msg, s.Sign(sk2, msg)), "invalid signature after a round trip", t)
+
+	_, err = s.UnmarshalBinaryPublicKey(ppk[:len(ppk)-1])
+	test.CheckIsErr(t, err, "short public key accepted")
+	_, err = s.UnmarshalBinaryPublicKey(append(ppk, 0))
+	test.CheckIsErr(t, err, "long public key accepted")
+	_, err = s.UnmarshalBinaryPrivateKey(psk[:len(psk)-1])
+	test.CheckIsErr(t, err, "short private key accepted")
+	_, err = s.UnmarshalBinaryPrivateKey(append(psk, 0))
+	test.CheckIsErr(t, err, "long private key accepted")
+}
+
+func testDerive(t *testing.T, s sign.Scheme) {
+	pk1, sk1 := s.DeriveKey(seed(s.SeedSize(), 0))
+	pk2, sk2 := s.DeriveKey(seed(s.SeedSize(), 0))
+	test.CheckOk(pk1.Equal(pk2), "different public keys from the same seed", t)
+	test.CheckOk(sk1.Equal(sk2), "different private keys from the same seed", t)
+	pk3, sk3 := s.DeriveKey(seed(s.SeedSize(), 1))
+	test.CheckOk(!pk1.Equal(pk3), "same public key from different seeds", t)
+	test.CheckOk(!sk1.Equal(sk3), "same private key from different seeds", t)
+
+	err := test.CheckPanic(func() { s.DeriveKey(seed(s.SeedSize()+1, 0)) })
+	test.CheckNoErr(t, err, "DeriveKey with a wrong seed size did not panic")
+}
+
+func testSignVerify(t *testing.T, s sign.Scheme) {
+	pk, sk, err := s.GenerateKey(nil)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	pk2, _, err := s.GenerateKey(nil)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	for _, msg := range [][]byte{nil, {0}, message(s)} {
+		sig := s.Sign(sk, msg)
+		test.CheckOk(s.Verify(pk, msg, sig), "invalid signature", t)
+		test.CheckOk(!s.Verify(pk2, msg, sig), "signature valid for another key", t)
+		test.CheckOk(!s.Verify(pk, append(msg, 0), sig), "signature valid for another message", t)
+		test.CheckOk(!s.Verify(pk, msg, sig[:len(sig)-1]), "truncated signature valid", t)
+		test.CheckOk(!s.Verify(pk, msg, append(sig, 0)), "extended signature valid", t)
+		test.CheckOk(!s.Verify(pk, msg, nil), "empty signature valid", t)
+		for _, i := range []int{0, len(sig) / 2, len(sig) - 1} {
+			bad := append([]byte{}, sig...)
+			bad[i] ^= 1
+			test.CheckOk(!s.Verify(pk, msg, bad), "altered signature valid", t)
+		}
+	}
+}
+
+// write writes msg to w in chunks of the given size.
+func write(t *testing.T, w interface{ Write([]byte) (int, error) }, msg []byte, chunk int) {
+	t.Helper()
+	for len(msg) > 0 {
+		n := chunk
+		if n > len(msg) {
+			n = len(msg)
+		}
+		m, err := w.Write(msg[:n])
+		test.CheckNoErr(t, err, "Write failed")
+		test.CheckOk(m == n, "short write", t)
+		msg = msg[n:]
+	}
+}
+
+func testStreaming(t *testing.T, s sign.Scheme) {
+	pk, sk := s.DeriveKey(seed(s.SeedSize(), 0))
+	msg := message(s)
+	sig := s.Sign(sk, msg)
+	deterministic := bytes.Equal(sig, s.Sign(sk, msg))
+
+	signer := s.Signer(sk)
+	verifier := s.Verifier(pk)
+	for _, chunk := range []int{1, 7, 64, len(msg)} {
+		signer.Reset()
+		write(t, signer, msg, chunk)
+		ssig := signer.Sign()
+		test.CheckOk(len(ssig) == s.SignatureSize(), "wrong size of signature", t)
+		test.CheckOk(s.Verify(pk, msg, ssig), "invalid signature from Signer", t)
+		if deterministic {
+			test.CheckOk(bytes.Equal(ssig, sig), "Signer differs from Sign", t)
+		}
+
+		verifier.Reset()
+		write(t, verifier, msg, chunk)
+		test.CheckOk(verifier.Verify(sig), "Verifier rejects a signature from Sign", t)
+	}
+
+	// SignTo writes the same signature as Sign.
+	signer.Reset()
+	write(t, signer, msg, len(msg))
+	buf := make([]byte, s.SignatureSize())
+	signer.SignTo(buf)
+	test.CheckOk(s.Verify(pk, msg, buf), "invalid signature from SignTo", t)
+	if deterministic {
+		test.CheckOk(bytes.Equal(buf, sig), "SignTo differs from Sign", t)
+	}
+
+	// Reset discards the written message.
+	signer.Reset()
+	write(t, signer, []byte("discarded"), 64)
+	signer.Reset()
+	write(t, signer, msg, 64)
+	test.CheckOk(s.Verify(pk, msg, signer.Sign()), "Reset did not discard the message of Signer", t)
+
+	verifier.Reset()
+	write(t, verifier, []byte("discarded"), 64)
+	verifier.Reset()
+	write(t, verifier, msg, 64)
+	test.CheckOk(verifier.Verify(sig), "Reset did not discard the message of Verifier", t)
+
+	verifier.Reset()
+	write(t, verifier, msg[1:], 64)
+	test.CheckOk(!verifier.Verify(sig), "Verifier accepts a signature of another message", t)
+}
+
+// otherPublicKey and otherPrivateKey wrap keys into types the scheme doesn't
+// know, which must compare unequal to its keys.
+type (
+	otherPublicKey  struct{ sign.PublicKey }
+	otherPrivateKey struct{ sign.PrivateKey }
+)
+
+func testEqual(t *testing.T, s sign.Scheme) {
+	pk, sk := s.DeriveKey(seed(s.SeedSize(), 0))
+	pk2, sk2 := s.DeriveKey(seed(s.SeedSize(), 1))
+
+	test.CheckOk(pk.Equal(pk), "public key not equal to itself", t)
+	test.CheckOk(sk.Equal(sk), "private key not equal to itself", t)
+	test.CheckOk(!pk.Equal(pk2) && !pk2.Equal(pk), "different public keys are equal", t)
+	test.CheckOk(!sk.Equal(sk2) && !sk2.Equal(sk), "different private keys are equal", t)
+	test.CheckOk(!pk.Equal(otherPublicKey{pk}), "public key equal to a foreign type", t)
+	test.CheckOk(!sk.Equal(otherPrivateKey{sk}), "private key equal to a foreign type", t)
+	test.CheckOk(sk.Public().Equal(pk) && pk.Equal(sk.Public()), "public key of private key not equal", t)
+}
@@ -1,19 +1,23 @@
-// Package keccakf1600 provides a two and four-way Keccak-f[1600] permutation in parallel.
+// Package keccakf1600 provides a two, four and eight-way Keccak-f[1600] permutation in parallel.
 //
 // Keccak-f[1600] is the permutation underlying several algorithms such as
-// Keccak, SHA3 and SHAKE. Running two or four permutations in parallel is
-// useful in some scenarios like in hash-based signatures.
+// Keccak, SHA3 and SHAKE. Running two, four or eight permutations in parallel
+// is useful in some scenarios like in hash-based signatures.
 //
 // # Limitations
 //
 // Note that not all the architectures support SIMD instructions. This package
-// uses AVX2 instructions that are available in some AMD64 architectures
-// and  NEON instructions that are available in some ARM64 architectures.
+// uses AVX2 and AVX-512 instructions that are available in some AMD64
+// architectures and NEON instructions with the SHA3 extension that are
+// available in some ARM64 architectures. On ARM64, only the two-way
+// permutation is implemented with SIMD instructions; the four-way and
+// eight-way ones use the generic implementation.
 //
 // For those systems not supporting these, the package still provides the
 // expected functionality by means of a generic and slow implementation.
-// The recommendation is to beforehand verify IsEnabledX4() and IsEnabledX2()
-// to determine if the current system supports the SIMD implementation.
+// The recommendation is to beforehand verify IsEnabledX8(), IsEnabledX4()
+// and IsEnabledX2() to determine if the current system supports the SIMD
+// implementation.
 package keccakf1600
 
 import (
@@ -43,6 +47,25 @@ type StateX4 struct {
 	turbo bool
 }
 
+// StateX8 contains state for the eight-way permutation including the eight
+// interleaved [25]uint64 buffers. Call Initialize() before use to initialize
+// and get a pointer to the interleaved buffer.
+type StateX8 struct {
+	// Go guarantees a to be aligned on 8 bytes, whereas we need it to be
+	// aligned on 64 bytes for AVX-512.  Thus we leave some headroom to be
+	// able to move the start of the state.
+
+	// 8 x 25 uint64s for the interleaved states and seven uint64s headroom
+	// to fix alignment.
+	a [207]uint64
+
+	// Offset into a that is 64 byte aligned.
+	offset int
+
+	// If true, permute will use 12-round keccak instead of 24-round keccak
+	turbo bool
+}
+
 // StateX2 contains state for the two-way permutation including the two
 // interleaved [25]uint64 buffers. Call Initialize() before use to initialize
 // and get a pointer to the interleaved buffer.
@@ -62,14 +85,38 @@ type StateX2 struct {
 	turbo bool
 }
 
+// IsEnabledX8 returns true if the architecture supports an eight-way SIMD
+// implementation provided in this package.
+func IsEnabledX8() bool { return enabledX8 }
+
 // IsEnabledX4 returns true if the architecture supports a four-way SIMD
 // implementation provided in this package.
-func IsEnabledX4() bool { return cpu.X86.HasAVX2 }
+func IsEnabledX4() bool { return enabledX4 }
 
 // IsEnabledX2 returns true if the architecture supports a two-way SIMD
 // implementation provided in this package.
 func IsEnabledX2() bool { return enabledX2 }
 
+// Initialize the state and returns the buffer on which the eight permutations
+// will act: a uint64 slice of length 200.  The first permutation will act
+// on {a[0], a[8], ..., a[192]}, the second on {a[1], a[9], ..., a[193]}, etc.
+// If turbo is true, applies 12-round variant instead of the usual 24.
+func (s *StateX8) Initialize(turbo bool) []uint64 {
+	s.turbo = turbo
+	rp := unsafe.Pointer(&s.a[0])
+
+	// uint64s are always aligned by a multiple of 8.  Compute the remainder
+	// of the address modulo 64 divided by 8.
+	rem := (int(uintptr(rp)&63) >> 3)
+
+	if rem != 0 {
+		s.offset = 8 - rem
+	}
+
+	// The slice we return will be aligned on 64 byte boundary.
+	return s.a[s.offset : s.offset+200]
+}
+
 // Initialize the state and returns the buffer on which the four permutations
 // will act: a uint64 slice of length 100.  The first permutation will act
 // on {a[0], a[4], ..., a[96]}, the second on {a[1], a[5], ..., a[97]}, etc.
@@ -110,6 +157,16 @@ func (s *StateX2) Initialize(turbo bool) []uint64 {
 	return s.a[s.offset : s.offset+50]
 }
 
+// Permute performs the eight parallel Keccak-f[1600]s interleaved on the
+// slice returned from Initialize().
+func (s *StateX8) Permute() {
+	if IsEnabledX8() {
+		permuteSIMDx8(s.a[s.offset:], s.turbo)
+	} else {
+		permuteScalarX8(s.a[s.offset:], s.turbo) // A slower generic implementation.
+	}
+}
+
 // Permute performs the four parallel Keccak-f[1600]s interleaved on the slice
 // returned from Initialize().
 func (s *StateX4) Permute() {
@@ -130,6 +187,19 @@ func (s *StateX2) Permute() {
 	}
 }
 
+func permuteScalarX8(a []uint64, turbo bool) {
+	var buf [25]uint64
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 25; j++ {
+			buf[j] = a[8*j+i]
+		}
+		sha3.KeccakF1600(&buf, turbo)
+		for j := 0; j < 25; j++ {
+			a[8*j+i] = buf[j]
+		}
+	}
+}
+
 func permuteScalarX4(a []uint64, turbo bool) {
 	var buf [25]uint64
 	for i := 0; i < 4; i++ {
@@ -156,8 +226,15 @@ func permuteScalarX2(a []uint64, turbo bool) {
 	}
 }
 
-var enabledX2 bool
+var enabledX2, enabledX4, enabledX8 bool
 
 func init() {
-	enabledX2 = runtime.GOARCH == "arm64" && runtime.GOOS == "darwin"
+	switch runtime.GOARCH {
+	case "amd64":
+		enabledX2 = cpu.X86.HasAVX2
+		enabledX4 = cpu.X86.HasAVX2
+		enabledX8 = cpu.X86.HasAVX512F
+	case "arm64":
+		enabledX2 = runtime.GOOS == "darwin"
+	}
 }
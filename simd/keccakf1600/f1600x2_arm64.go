@@ -7,7 +7,10 @@ import "github.com/karalef/circl/internal/sha3"
 
 func permuteSIMDx2(state []uint64, turbo bool) { f1600x2ARM(&state[0], &sha3.RC, turbo) }
 
+// There is no four-way kernel for ARM64 yet, so IsEnabledX4 is false.
 func permuteSIMDx4(state []uint64, turbo bool) { permuteScalarX4(state, turbo) }
 
+func permuteSIMDx8(state []uint64, turbo bool) { permuteScalarX8(state, turbo) }
+
 //go:noescape
 func f1600x2ARM(state *uint64, rc *[24]uint64, turbo bool)
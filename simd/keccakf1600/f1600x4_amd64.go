@@ -4,4 +4,6 @@ import "github.com/karalef/circl/internal/sha3"
 
 func permuteSIMDx4(state []uint64, turbo bool) { f1600x4AVX2(&state[0], &sha3.RC, turbo) }
 
-func permuteSIMDx2(state []uint64, turbo bool) { permuteScalarX2(state, turbo) }
+func permuteSIMDx2(state []uint64, turbo bool) { f1600x2AVX2(&state[0], &sha3.RC, turbo) }
+
+func permuteSIMDx8(state []uint64, turbo bool) { f1600x8AVX512(&state[0], &sha3.RC, turbo) }
@@ -897,3 +897,1559 @@ loop:
 	SUBQ         $0x00000001, DX
 	JNZ          loop
 	RET
+
+// func f1600x2AVX2(state *uint64, rc *[24]uint64, turbo bool)
+// Requires: AVX, AVX2
+TEXT ·f1600x2AVX2(SB), NOSPLIT, $0-17
+	MOVQ    state+0(FP), AX
+	MOVQ    rc+8(FP), CX
+	MOVQ    $0x0000000000000006, DX
+	MOVBQZX turbo+16(FP), BX
+	TESTQ   BX, BX
+	JZ      loop
+	MOVQ    $0x0000000000000003, DX
+	ADDQ    $0x60, CX
+
+loop:
+	VMOVDQA      (AX), X0
+	VMOVDQA      16(AX), X1
+	VMOVDQA      32(AX), X2
+	VMOVDQA      48(AX), X3
+	VMOVDQA      64(AX), X4
+	VPXOR        80(AX), X0, X0
+	VPXOR        96(AX), X1, X1
+	VPXOR        112(AX), X2, X2
+	VPXOR        128(AX), X3, X3
+	VPXOR        144(AX), X4, X4
+	VPXOR        160(AX), X0, X0
+	VPXOR        176(AX), X1, X1
+	VPXOR        192(AX), X2, X2
+	VPXOR        208(AX), X3, X3
+	VPXOR        224(AX), X4, X4
+	VPXOR        240(AX), X0, X0
+	VPXOR        256(AX), X1, X1
+	VPXOR        272(AX), X2, X2
+	VPXOR        288(AX), X3, X3
+	VPXOR        304(AX), X4, X4
+	VPXOR        320(AX), X0, X0
+	VPXOR        336(AX), X1, X1
+	VPXOR        352(AX), X2, X2
+	VPXOR        368(AX), X3, X3
+	VPXOR        384(AX), X4, X4
+	VPSLLQ       $0x01, X1, X5
+	VPSLLQ       $0x01, X2, X6
+	VPSLLQ       $0x01, X3, X7
+	VPSLLQ       $0x01, X4, X8
+	VPSLLQ       $0x01, X0, X9
+	VPSRLQ       $0x3f, X1, X10
+	VPSRLQ       $0x3f, X2, X11
+	VPSRLQ       $0x3f, X3, X12
+	VPSRLQ       $0x3f, X4, X13
+	VPSRLQ       $0x3f, X0, X14
+	VPOR         X5, X10, X10
+	VPOR         X6, X11, X11
+	VPOR         X7, X12, X12
+	VPOR         X8, X13, X13
+	VPOR         X9, X14, X14
+	VPXOR        X10, X4, X10
+	VPXOR        X11, X0, X11
+	VPXOR        X12, X1, X12
+	VPXOR        X13, X2, X13
+	VPXOR        X14, X3, X14
+	VPXOR        (AX), X10, X0
+	VPXOR        96(AX), X11, X1
+	VPXOR        192(AX), X12, X2
+	VPXOR        288(AX), X13, X3
+	VPXOR        384(AX), X14, X4
+	VPSLLQ       $0x2c, X1, X6
+	VPSLLQ       $0x2b, X2, X7
+	VPSLLQ       $0x15, X3, X8
+	VPSLLQ       $0x0e, X4, X9
+	VPSRLQ       $0x14, X1, X1
+	VPSRLQ       $0x15, X2, X2
+	VPSRLQ       $0x2b, X3, X3
+	VPSRLQ       $0x32, X4, X4
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VPBROADCASTQ (CX), X0
+	VPXOR        X0, X5, X5
+	VMOVDQA      X5, (AX)
+	VMOVDQA      X6, 96(AX)
+	VMOVDQA      X7, 192(AX)
+	VMOVDQA      X8, 288(AX)
+	VMOVDQA      X9, 384(AX)
+	VPXOR        48(AX), X13, X0
+	VPXOR        144(AX), X14, X1
+	VPXOR        160(AX), X10, X2
+	VPXOR        256(AX), X11, X3
+	VPXOR        352(AX), X12, X4
+	VPSLLQ       $0x1c, X0, X5
+	VPSLLQ       $0x14, X1, X6
+	VPSLLQ       $0x03, X2, X7
+	VPSLLQ       $0x2d, X3, X8
+	VPSLLQ       $0x3d, X4, X9
+	VPSRLQ       $0x24, X0, X0
+	VPSRLQ       $0x2c, X1, X1
+	VPSRLQ       $0x3d, X2, X2
+	VPSRLQ       $0x13, X3, X3
+	VPSRLQ       $0x03, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 160(AX)
+	VMOVDQA      X6, 256(AX)
+	VMOVDQA      X7, 352(AX)
+	VMOVDQA      X8, 48(AX)
+	VMOVDQA      X9, 144(AX)
+	VPXOR        16(AX), X11, X0
+	VPXOR        112(AX), X12, X1
+	VPXOR        208(AX), X13, X2
+	VPXOR        304(AX), X14, X3
+	VPXOR        320(AX), X10, X4
+	VPSLLQ       $0x01, X0, X5
+	VPSLLQ       $0x06, X1, X6
+	VPSLLQ       $0x19, X2, X7
+	VPSLLQ       $0x08, X3, X8
+	VPSLLQ       $0x12, X4, X9
+	VPSRLQ       $0x3f, X0, X0
+	VPSRLQ       $0x3a, X1, X1
+	VPSRLQ       $0x27, X2, X2
+	VPSRLQ       $0x38, X3, X3
+	VPSRLQ       $0x2e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 320(AX)
+	VMOVDQA      X6, 16(AX)
+	VMOVDQA      X7, 112(AX)
+	VMOVDQA      X8, 208(AX)
+	VMOVDQA      X9, 304(AX)
+	VPXOR        64(AX), X14, X0
+	VPXOR        80(AX), X10, X1
+	VPXOR        176(AX), X11, X2
+	VPXOR        272(AX), X12, X3
+	VPXOR        368(AX), X13, X4
+	VPSLLQ       $0x1b, X0, X5
+	VPSLLQ       $0x24, X1, X6
+	VPSLLQ       $0x0a, X2, X7
+	VPSLLQ       $0x0f, X3, X8
+	VPSLLQ       $0x38, X4, X9
+	VPSRLQ       $0x25, X0, X0
+	VPSRLQ       $0x1c, X1, X1
+	VPSRLQ       $0x36, X2, X2
+	VPSRLQ       $0x31, X3, X3
+	VPSRLQ       $0x08, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 80(AX)
+	VMOVDQA      X6, 176(AX)
+	VMOVDQA      X7, 272(AX)
+	VMOVDQA      X8, 368(AX)
+	VMOVDQA      X9, 64(AX)
+	VPXOR        32(AX), X12, X0
+	VPXOR        128(AX), X13, X1
+	VPXOR        224(AX), X14, X2
+	VPXOR        240(AX), X10, X3
+	VPXOR        336(AX), X11, X4
+	VPSLLQ       $0x3e, X0, X5
+	VPSLLQ       $0x37, X1, X6
+	VPSLLQ       $0x27, X2, X7
+	VPSLLQ       $0x29, X3, X8
+	VPSLLQ       $0x02, X4, X9
+	VPSRLQ       $0x02, X0, X0
+	VPSRLQ       $0x09, X1, X1
+	VPSRLQ       $0x19, X2, X2
+	VPSRLQ       $0x17, X3, X3
+	VPSRLQ       $0x3e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 240(AX)
+	VMOVDQA      X6, 336(AX)
+	VMOVDQA      X7, 32(AX)
+	VMOVDQA      X8, 128(AX)
+	VMOVDQA      X9, 224(AX)
+	VMOVDQA      (AX), X0
+	VMOVDQA      16(AX), X1
+	VMOVDQA      32(AX), X2
+	VMOVDQA      48(AX), X3
+	VMOVDQA      64(AX), X4
+	VPXOR        80(AX), X0, X0
+	VPXOR        96(AX), X1, X1
+	VPXOR        112(AX), X2, X2
+	VPXOR        128(AX), X3, X3
+	VPXOR        144(AX), X4, X4
+	VPXOR        160(AX), X0, X0
+	VPXOR        176(AX), X1, X1
+	VPXOR        192(AX), X2, X2
+	VPXOR        208(AX), X3, X3
+	VPXOR        224(AX), X4, X4
+	VPXOR        240(AX), X0, X0
+	VPXOR        256(AX), X1, X1
+	VPXOR        272(AX), X2, X2
+	VPXOR        288(AX), X3, X3
+	VPXOR        304(AX), X4, X4
+	VPXOR        320(AX), X0, X0
+	VPXOR        336(AX), X1, X1
+	VPXOR        352(AX), X2, X2
+	VPXOR        368(AX), X3, X3
+	VPXOR        384(AX), X4, X4
+	VPSLLQ       $0x01, X1, X5
+	VPSLLQ       $0x01, X2, X6
+	VPSLLQ       $0x01, X3, X7
+	VPSLLQ       $0x01, X4, X8
+	VPSLLQ       $0x01, X0, X9
+	VPSRLQ       $0x3f, X1, X10
+	VPSRLQ       $0x3f, X2, X11
+	VPSRLQ       $0x3f, X3, X12
+	VPSRLQ       $0x3f, X4, X13
+	VPSRLQ       $0x3f, X0, X14
+	VPOR         X5, X10, X10
+	VPOR         X6, X11, X11
+	VPOR         X7, X12, X12
+	VPOR         X8, X13, X13
+	VPOR         X9, X14, X14
+	VPXOR        X10, X4, X10
+	VPXOR        X11, X0, X11
+	VPXOR        X12, X1, X12
+	VPXOR        X13, X2, X13
+	VPXOR        X14, X3, X14
+	VPXOR        (AX), X10, X0
+	VPXOR        256(AX), X11, X1
+	VPXOR        112(AX), X12, X2
+	VPXOR        368(AX), X13, X3
+	VPXOR        224(AX), X14, X4
+	VPSLLQ       $0x2c, X1, X6
+	VPSLLQ       $0x2b, X2, X7
+	VPSLLQ       $0x15, X3, X8
+	VPSLLQ       $0x0e, X4, X9
+	VPSRLQ       $0x14, X1, X1
+	VPSRLQ       $0x15, X2, X2
+	VPSRLQ       $0x2b, X3, X3
+	VPSRLQ       $0x32, X4, X4
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VPBROADCASTQ 8(CX), X0
+	VPXOR        X0, X5, X5
+	VMOVDQA      X5, (AX)
+	VMOVDQA      X6, 256(AX)
+	VMOVDQA      X7, 112(AX)
+	VMOVDQA      X8, 368(AX)
+	VMOVDQA      X9, 224(AX)
+	VPXOR        288(AX), X13, X0
+	VPXOR        144(AX), X14, X1
+	VPXOR        320(AX), X10, X2
+	VPXOR        176(AX), X11, X3
+	VPXOR        32(AX), X12, X4
+	VPSLLQ       $0x1c, X0, X5
+	VPSLLQ       $0x14, X1, X6
+	VPSLLQ       $0x03, X2, X7
+	VPSLLQ       $0x2d, X3, X8
+	VPSLLQ       $0x3d, X4, X9
+	VPSRLQ       $0x24, X0, X0
+	VPSRLQ       $0x2c, X1, X1
+	VPSRLQ       $0x3d, X2, X2
+	VPSRLQ       $0x13, X3, X3
+	VPSRLQ       $0x03, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 320(AX)
+	VMOVDQA      X6, 176(AX)
+	VMOVDQA      X7, 32(AX)
+	VMOVDQA      X8, 288(AX)
+	VMOVDQA      X9, 144(AX)
+	VPXOR        96(AX), X11, X0
+	VPXOR        352(AX), X12, X1
+	VPXOR        208(AX), X13, X2
+	VPXOR        64(AX), X14, X3
+	VPXOR        240(AX), X10, X4
+	VPSLLQ       $0x01, X0, X5
+	VPSLLQ       $0x06, X1, X6
+	VPSLLQ       $0x19, X2, X7
+	VPSLLQ       $0x08, X3, X8
+	VPSLLQ       $0x12, X4, X9
+	VPSRLQ       $0x3f, X0, X0
+	VPSRLQ       $0x3a, X1, X1
+	VPSRLQ       $0x27, X2, X2
+	VPSRLQ       $0x38, X3, X3
+	VPSRLQ       $0x2e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 240(AX)
+	VMOVDQA      X6, 96(AX)
+	VMOVDQA      X7, 352(AX)
+	VMOVDQA      X8, 208(AX)
+	VMOVDQA      X9, 64(AX)
+	VPXOR        384(AX), X14, X0
+	VPXOR        160(AX), X10, X1
+	VPXOR        16(AX), X11, X2
+	VPXOR        272(AX), X12, X3
+	VPXOR        128(AX), X13, X4
+	VPSLLQ       $0x1b, X0, X5
+	VPSLLQ       $0x24, X1, X6
+	VPSLLQ       $0x0a, X2, X7
+	VPSLLQ       $0x0f, X3, X8
+	VPSLLQ       $0x38, X4, X9
+	VPSRLQ       $0x25, X0, X0
+	VPSRLQ       $0x1c, X1, X1
+	VPSRLQ       $0x36, X2, X2
+	VPSRLQ       $0x31, X3, X3
+	VPSRLQ       $0x08, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 160(AX)
+	VMOVDQA      X6, 16(AX)
+	VMOVDQA      X7, 272(AX)
+	VMOVDQA      X8, 128(AX)
+	VMOVDQA      X9, 384(AX)
+	VPXOR        192(AX), X12, X0
+	VPXOR        48(AX), X13, X1
+	VPXOR        304(AX), X14, X2
+	VPXOR        80(AX), X10, X3
+	VPXOR        336(AX), X11, X4
+	VPSLLQ       $0x3e, X0, X5
+	VPSLLQ       $0x37, X1, X6
+	VPSLLQ       $0x27, X2, X7
+	VPSLLQ       $0x29, X3, X8
+	VPSLLQ       $0x02, X4, X9
+	VPSRLQ       $0x02, X0, X0
+	VPSRLQ       $0x09, X1, X1
+	VPSRLQ       $0x19, X2, X2
+	VPSRLQ       $0x17, X3, X3
+	VPSRLQ       $0x3e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 80(AX)
+	VMOVDQA      X6, 336(AX)
+	VMOVDQA      X7, 192(AX)
+	VMOVDQA      X8, 48(AX)
+	VMOVDQA      X9, 304(AX)
+	VMOVDQA      (AX), X0
+	VMOVDQA      16(AX), X1
+	VMOVDQA      32(AX), X2
+	VMOVDQA      48(AX), X3
+	VMOVDQA      64(AX), X4
+	VPXOR        80(AX), X0, X0
+	VPXOR        96(AX), X1, X1
+	VPXOR        112(AX), X2, X2
+	VPXOR        128(AX), X3, X3
+	VPXOR        144(AX), X4, X4
+	VPXOR        160(AX), X0, X0
+	VPXOR        176(AX), X1, X1
+	VPXOR        192(AX), X2, X2
+	VPXOR        208(AX), X3, X3
+	VPXOR        224(AX), X4, X4
+	VPXOR        240(AX), X0, X0
+	VPXOR        256(AX), X1, X1
+	VPXOR        272(AX), X2, X2
+	VPXOR        288(AX), X3, X3
+	VPXOR        304(AX), X4, X4
+	VPXOR        320(AX), X0, X0
+	VPXOR        336(AX), X1, X1
+	VPXOR        352(AX), X2, X2
+	VPXOR        368(AX), X3, X3
+	VPXOR        384(AX), X4, X4
+	VPSLLQ       $0x01, X1, X5
+	VPSLLQ       $0x01, X2, X6
+	VPSLLQ       $0x01, X3, X7
+	VPSLLQ       $0x01, X4, X8
+	VPSLLQ       $0x01, X0, X9
+	VPSRLQ       $0x3f, X1, X10
+	VPSRLQ       $0x3f, X2, X11
+	VPSRLQ       $0x3f, X3, X12
+	VPSRLQ       $0x3f, X4, X13
+	VPSRLQ       $0x3f, X0, X14
+	VPOR         X5, X10, X10
+	VPOR         X6, X11, X11
+	VPOR         X7, X12, X12
+	VPOR         X8, X13, X13
+	VPOR         X9, X14, X14
+	VPXOR        X10, X4, X10
+	VPXOR        X11, X0, X11
+	VPXOR        X12, X1, X12
+	VPXOR        X13, X2, X13
+	VPXOR        X14, X3, X14
+	VPXOR        (AX), X10, X0
+	VPXOR        176(AX), X11, X1
+	VPXOR        352(AX), X12, X2
+	VPXOR        128(AX), X13, X3
+	VPXOR        304(AX), X14, X4
+	VPSLLQ       $0x2c, X1, X6
+	VPSLLQ       $0x2b, X2, X7
+	VPSLLQ       $0x15, X3, X8
+	VPSLLQ       $0x0e, X4, X9
+	VPSRLQ       $0x14, X1, X1
+	VPSRLQ       $0x15, X2, X2
+	VPSRLQ       $0x2b, X3, X3
+	VPSRLQ       $0x32, X4, X4
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VPBROADCASTQ 16(CX), X0
+	VPXOR        X0, X5, X5
+	VMOVDQA      X5, (AX)
+	VMOVDQA      X6, 176(AX)
+	VMOVDQA      X7, 352(AX)
+	VMOVDQA      X8, 128(AX)
+	VMOVDQA      X9, 304(AX)
+	VPXOR        368(AX), X13, X0
+	VPXOR        144(AX), X14, X1
+	VPXOR        240(AX), X10, X2
+	VPXOR        16(AX), X11, X3
+	VPXOR        192(AX), X12, X4
+	VPSLLQ       $0x1c, X0, X5
+	VPSLLQ       $0x14, X1, X6
+	VPSLLQ       $0x03, X2, X7
+	VPSLLQ       $0x2d, X3, X8
+	VPSLLQ       $0x3d, X4, X9
+	VPSRLQ       $0x24, X0, X0
+	VPSRLQ       $0x2c, X1, X1
+	VPSRLQ       $0x3d, X2, X2
+	VPSRLQ       $0x13, X3, X3
+	VPSRLQ       $0x03, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 240(AX)
+	VMOVDQA      X6, 16(AX)
+	VMOVDQA      X7, 192(AX)
+	VMOVDQA      X8, 368(AX)
+	VMOVDQA      X9, 144(AX)
+	VPXOR        256(AX), X11, X0
+	VPXOR        32(AX), X12, X1
+	VPXOR        208(AX), X13, X2
+	VPXOR        384(AX), X14, X3
+	VPXOR        80(AX), X10, X4
+	VPSLLQ       $0x01, X0, X5
+	VPSLLQ       $0x06, X1, X6
+	VPSLLQ       $0x19, X2, X7
+	VPSLLQ       $0x08, X3, X8
+	VPSLLQ       $0x12, X4, X9
+	VPSRLQ       $0x3f, X0, X0
+	VPSRLQ       $0x3a, X1, X1
+	VPSRLQ       $0x27, X2, X2
+	VPSRLQ       $0x38, X3, X3
+	VPSRLQ       $0x2e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 80(AX)
+	VMOVDQA      X6, 256(AX)
+	VMOVDQA      X7, 32(AX)
+	VMOVDQA      X8, 208(AX)
+	VMOVDQA      X9, 384(AX)
+	VPXOR        224(AX), X14, X0
+	VPXOR        320(AX), X10, X1
+	VPXOR        96(AX), X11, X2
+	VPXOR        272(AX), X12, X3
+	VPXOR        48(AX), X13, X4
+	VPSLLQ       $0x1b, X0, X5
+	VPSLLQ       $0x24, X1, X6
+	VPSLLQ       $0x0a, X2, X7
+	VPSLLQ       $0x0f, X3, X8
+	VPSLLQ       $0x38, X4, X9
+	VPSRLQ       $0x25, X0, X0
+	VPSRLQ       $0x1c, X1, X1
+	VPSRLQ       $0x36, X2, X2
+	VPSRLQ       $0x31, X3, X3
+	VPSRLQ       $0x08, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 320(AX)
+	VMOVDQA      X6, 96(AX)
+	VMOVDQA      X7, 272(AX)
+	VMOVDQA      X8, 48(AX)
+	VMOVDQA      X9, 224(AX)
+	VPXOR        112(AX), X12, X0
+	VPXOR        288(AX), X13, X1
+	VPXOR        64(AX), X14, X2
+	VPXOR        160(AX), X10, X3
+	VPXOR        336(AX), X11, X4
+	VPSLLQ       $0x3e, X0, X5
+	VPSLLQ       $0x37, X1, X6
+	VPSLLQ       $0x27, X2, X7
+	VPSLLQ       $0x29, X3, X8
+	VPSLLQ       $0x02, X4, X9
+	VPSRLQ       $0x02, X0, X0
+	VPSRLQ       $0x09, X1, X1
+	VPSRLQ       $0x19, X2, X2
+	VPSRLQ       $0x17, X3, X3
+	VPSRLQ       $0x3e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 160(AX)
+	VMOVDQA      X6, 336(AX)
+	VMOVDQA      X7, 112(AX)
+	VMOVDQA      X8, 288(AX)
+	VMOVDQA      X9, 64(AX)
+	VMOVDQA      (AX), X0
+	VMOVDQA      16(AX), X1
+	VMOVDQA      32(AX), X2
+	VMOVDQA      48(AX), X3
+	VMOVDQA      64(AX), X4
+	VPXOR        80(AX), X0, X0
+	VPXOR        96(AX), X1, X1
+	VPXOR        112(AX), X2, X2
+	VPXOR        128(AX), X3, X3
+	VPXOR        144(AX), X4, X4
+	VPXOR        160(AX), X0, X0
+	VPXOR        176(AX), X1, X1
+	VPXOR        192(AX), X2, X2
+	VPXOR        208(AX), X3, X3
+	VPXOR        224(AX), X4, X4
+	VPXOR        240(AX), X0, X0
+	VPXOR        256(AX), X1, X1
+	VPXOR        272(AX), X2, X2
+	VPXOR        288(AX), X3, X3
+	VPXOR        304(AX), X4, X4
+	VPXOR        320(AX), X0, X0
+	VPXOR        336(AX), X1, X1
+	VPXOR        352(AX), X2, X2
+	VPXOR        368(AX), X3, X3
+	VPXOR        384(AX), X4, X4
+	VPSLLQ       $0x01, X1, X5
+	VPSLLQ       $0x01, X2, X6
+	VPSLLQ       $0x01, X3, X7
+	VPSLLQ       $0x01, X4, X8
+	VPSLLQ       $0x01, X0, X9
+	VPSRLQ       $0x3f, X1, X10
+	VPSRLQ       $0x3f, X2, X11
+	VPSRLQ       $0x3f, X3, X12
+	VPSRLQ       $0x3f, X4, X13
+	VPSRLQ       $0x3f, X0, X14
+	VPOR         X5, X10, X10
+	VPOR         X6, X11, X11
+	VPOR         X7, X12, X12
+	VPOR         X8, X13, X13
+	VPOR         X9, X14, X14
+	VPXOR        X10, X4, X10
+	VPXOR        X11, X0, X11
+	VPXOR        X12, X1, X12
+	VPXOR        X13, X2, X13
+	VPXOR        X14, X3, X14
+	VPXOR        (AX), X10, X0
+	VPXOR        16(AX), X11, X1
+	VPXOR        32(AX), X12, X2
+	VPXOR        48(AX), X13, X3
+	VPXOR        64(AX), X14, X4
+	VPSLLQ       $0x2c, X1, X6
+	VPSLLQ       $0x2b, X2, X7
+	VPSLLQ       $0x15, X3, X8
+	VPSLLQ       $0x0e, X4, X9
+	VPSRLQ       $0x14, X1, X1
+	VPSRLQ       $0x15, X2, X2
+	VPSRLQ       $0x2b, X3, X3
+	VPSRLQ       $0x32, X4, X4
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VPBROADCASTQ 24(CX), X0
+	VPXOR        X0, X5, X5
+	VMOVDQA      X5, (AX)
+	VMOVDQA      X6, 16(AX)
+	VMOVDQA      X7, 32(AX)
+	VMOVDQA      X8, 48(AX)
+	VMOVDQA      X9, 64(AX)
+	VPXOR        128(AX), X13, X0
+	VPXOR        144(AX), X14, X1
+	VPXOR        80(AX), X10, X2
+	VPXOR        96(AX), X11, X3
+	VPXOR        112(AX), X12, X4
+	VPSLLQ       $0x1c, X0, X5
+	VPSLLQ       $0x14, X1, X6
+	VPSLLQ       $0x03, X2, X7
+	VPSLLQ       $0x2d, X3, X8
+	VPSLLQ       $0x3d, X4, X9
+	VPSRLQ       $0x24, X0, X0
+	VPSRLQ       $0x2c, X1, X1
+	VPSRLQ       $0x3d, X2, X2
+	VPSRLQ       $0x13, X3, X3
+	VPSRLQ       $0x03, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 80(AX)
+	VMOVDQA      X6, 96(AX)
+	VMOVDQA      X7, 112(AX)
+	VMOVDQA      X8, 128(AX)
+	VMOVDQA      X9, 144(AX)
+	VPXOR        176(AX), X11, X0
+	VPXOR        192(AX), X12, X1
+	VPXOR        208(AX), X13, X2
+	VPXOR        224(AX), X14, X3
+	VPXOR        160(AX), X10, X4
+	VPSLLQ       $0x01, X0, X5
+	VPSLLQ       $0x06, X1, X6
+	VPSLLQ       $0x19, X2, X7
+	VPSLLQ       $0x08, X3, X8
+	VPSLLQ       $0x12, X4, X9
+	VPSRLQ       $0x3f, X0, X0
+	VPSRLQ       $0x3a, X1, X1
+	VPSRLQ       $0x27, X2, X2
+	VPSRLQ       $0x38, X3, X3
+	VPSRLQ       $0x2e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 160(AX)
+	VMOVDQA      X6, 176(AX)
+	VMOVDQA      X7, 192(AX)
+	VMOVDQA      X8, 208(AX)
+	VMOVDQA      X9, 224(AX)
+	VPXOR        304(AX), X14, X0
+	VPXOR        240(AX), X10, X1
+	VPXOR        256(AX), X11, X2
+	VPXOR        272(AX), X12, X3
+	VPXOR        288(AX), X13, X4
+	VPSLLQ       $0x1b, X0, X5
+	VPSLLQ       $0x24, X1, X6
+	VPSLLQ       $0x0a, X2, X7
+	VPSLLQ       $0x0f, X3, X8
+	VPSLLQ       $0x38, X4, X9
+	VPSRLQ       $0x25, X0, X0
+	VPSRLQ       $0x1c, X1, X1
+	VPSRLQ       $0x36, X2, X2
+	VPSRLQ       $0x31, X3, X3
+	VPSRLQ       $0x08, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 240(AX)
+	VMOVDQA      X6, 256(AX)
+	VMOVDQA      X7, 272(AX)
+	VMOVDQA      X8, 288(AX)
+	VMOVDQA      X9, 304(AX)
+	VPXOR        352(AX), X12, X0
+	VPXOR        368(AX), X13, X1
+	VPXOR        384(AX), X14, X2
+	VPXOR        320(AX), X10, X3
+	VPXOR        336(AX), X11, X4
+	VPSLLQ       $0x3e, X0, X5
+	VPSLLQ       $0x37, X1, X6
+	VPSLLQ       $0x27, X2, X7
+	VPSLLQ       $0x29, X3, X8
+	VPSLLQ       $0x02, X4, X9
+	VPSRLQ       $0x02, X0, X0
+	VPSRLQ       $0x09, X1, X1
+	VPSRLQ       $0x19, X2, X2
+	VPSRLQ       $0x17, X3, X3
+	VPSRLQ       $0x3e, X4, X4
+	VPOR         X5, X0, X0
+	VPOR         X6, X1, X1
+	VPOR         X7, X2, X2
+	VPOR         X8, X3, X3
+	VPOR         X9, X4, X4
+	VPANDN       X2, X1, X5
+	VPANDN       X3, X2, X6
+	VPANDN       X4, X3, X7
+	VPANDN       X0, X4, X8
+	VPANDN       X1, X0, X9
+	VPXOR        X0, X5, X5
+	VPXOR        X1, X6, X6
+	VPXOR        X2, X7, X7
+	VPXOR        X3, X8, X8
+	VPXOR        X4, X9, X9
+	VMOVDQA      X5, 320(AX)
+	VMOVDQA      X6, 336(AX)
+	VMOVDQA      X7, 352(AX)
+	VMOVDQA      X8, 368(AX)
+	VMOVDQA      X9, 384(AX)
+	ADDQ         $0x20, CX
+	SUBQ         $0x00000001, DX
+	JNZ          loop
+	RET
+
+// func f1600x8AVX512(state *uint64, rc *[24]uint64, turbo bool)
+// Requires: AVX512F
+TEXT ·f1600x8AVX512(SB), NOSPLIT, $0-17
+	MOVQ    state+0(FP), AX
+	MOVQ    rc+8(FP), CX
+	MOVQ    $0x0000000000000006, DX
+	MOVBQZX turbo+16(FP), BX
+	TESTQ   BX, BX
+	JZ      loop
+	MOVQ    $0x0000000000000003, DX
+	ADDQ    $0x60, CX
+
+loop:
+	VMOVDQA64    (AX), Z0
+	VMOVDQA64    64(AX), Z1
+	VMOVDQA64    128(AX), Z2
+	VMOVDQA64    192(AX), Z3
+	VMOVDQA64    256(AX), Z4
+	VPXORQ       320(AX), Z0, Z0
+	VPXORQ       384(AX), Z1, Z1
+	VPXORQ       448(AX), Z2, Z2
+	VPXORQ       512(AX), Z3, Z3
+	VPXORQ       576(AX), Z4, Z4
+	VPXORQ       640(AX), Z0, Z0
+	VPXORQ       704(AX), Z1, Z1
+	VPXORQ       768(AX), Z2, Z2
+	VPXORQ       832(AX), Z3, Z3
+	VPXORQ       896(AX), Z4, Z4
+	VPXORQ       960(AX), Z0, Z0
+	VPXORQ       1024(AX), Z1, Z1
+	VPXORQ       1088(AX), Z2, Z2
+	VPXORQ       1152(AX), Z3, Z3
+	VPXORQ       1216(AX), Z4, Z4
+	VPXORQ       1280(AX), Z0, Z0
+	VPXORQ       1344(AX), Z1, Z1
+	VPXORQ       1408(AX), Z2, Z2
+	VPXORQ       1472(AX), Z3, Z3
+	VPXORQ       1536(AX), Z4, Z4
+	VPROLQ       $0x01, Z1, Z5
+	VPROLQ       $0x01, Z2, Z6
+	VPROLQ       $0x01, Z3, Z7
+	VPROLQ       $0x01, Z4, Z8
+	VPROLQ       $0x01, Z0, Z9
+	VPXORQ       Z5, Z4, Z5
+	VPXORQ       Z6, Z0, Z6
+	VPXORQ       Z7, Z1, Z7
+	VPXORQ       Z8, Z2, Z8
+	VPXORQ       Z9, Z3, Z9
+	VPXORQ       (AX), Z5, Z10
+	VPXORQ       384(AX), Z6, Z11
+	VPXORQ       768(AX), Z7, Z12
+	VPXORQ       1152(AX), Z8, Z13
+	VPXORQ       1536(AX), Z9, Z14
+	VPROLQ       $0x2c, Z11, Z11
+	VPROLQ       $0x2b, Z12, Z12
+	VPROLQ       $0x15, Z13, Z13
+	VPROLQ       $0x0e, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VPBROADCASTQ (CX), Z10
+	VPXORQ       Z10, Z0, Z0
+	VMOVDQA64    Z0, (AX)
+	VMOVDQA64    Z1, 384(AX)
+	VMOVDQA64    Z2, 768(AX)
+	VMOVDQA64    Z3, 1152(AX)
+	VMOVDQA64    Z4, 1536(AX)
+	VPXORQ       192(AX), Z8, Z10
+	VPXORQ       576(AX), Z9, Z11
+	VPXORQ       640(AX), Z5, Z12
+	VPXORQ       1024(AX), Z6, Z13
+	VPXORQ       1408(AX), Z7, Z14
+	VPROLQ       $0x1c, Z10, Z10
+	VPROLQ       $0x14, Z11, Z11
+	VPROLQ       $0x03, Z12, Z12
+	VPROLQ       $0x2d, Z13, Z13
+	VPROLQ       $0x3d, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 640(AX)
+	VMOVDQA64    Z1, 1024(AX)
+	VMOVDQA64    Z2, 1408(AX)
+	VMOVDQA64    Z3, 192(AX)
+	VMOVDQA64    Z4, 576(AX)
+	VPXORQ       64(AX), Z6, Z10
+	VPXORQ       448(AX), Z7, Z11
+	VPXORQ       832(AX), Z8, Z12
+	VPXORQ       1216(AX), Z9, Z13
+	VPXORQ       1280(AX), Z5, Z14
+	VPROLQ       $0x01, Z10, Z10
+	VPROLQ       $0x06, Z11, Z11
+	VPROLQ       $0x19, Z12, Z12
+	VPROLQ       $0x08, Z13, Z13
+	VPROLQ       $0x12, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 1280(AX)
+	VMOVDQA64    Z1, 64(AX)
+	VMOVDQA64    Z2, 448(AX)
+	VMOVDQA64    Z3, 832(AX)
+	VMOVDQA64    Z4, 1216(AX)
+	VPXORQ       256(AX), Z9, Z10
+	VPXORQ       320(AX), Z5, Z11
+	VPXORQ       704(AX), Z6, Z12
+	VPXORQ       1088(AX), Z7, Z13
+	VPXORQ       1472(AX), Z8, Z14
+	VPROLQ       $0x1b, Z10, Z10
+	VPROLQ       $0x24, Z11, Z11
+	VPROLQ       $0x0a, Z12, Z12
+	VPROLQ       $0x0f, Z13, Z13
+	VPROLQ       $0x38, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 320(AX)
+	VMOVDQA64    Z1, 704(AX)
+	VMOVDQA64    Z2, 1088(AX)
+	VMOVDQA64    Z3, 1472(AX)
+	VMOVDQA64    Z4, 256(AX)
+	VPXORQ       128(AX), Z7, Z7
+	VPXORQ       512(AX), Z8, Z8
+	VPXORQ       896(AX), Z9, Z9
+	VPXORQ       960(AX), Z5, Z5
+	VPXORQ       1344(AX), Z6, Z6
+	VPROLQ       $0x3e, Z7, Z7
+	VPROLQ       $0x37, Z8, Z8
+	VPROLQ       $0x27, Z9, Z9
+	VPROLQ       $0x29, Z5, Z5
+	VPROLQ       $0x02, Z6, Z6
+	VPANDNQ      Z9, Z8, Z0
+	VPANDNQ      Z5, Z9, Z1
+	VPANDNQ      Z6, Z5, Z2
+	VPANDNQ      Z7, Z6, Z3
+	VPANDNQ      Z8, Z7, Z4
+	VPXORQ       Z7, Z0, Z0
+	VPXORQ       Z8, Z1, Z1
+	VPXORQ       Z9, Z2, Z2
+	VPXORQ       Z5, Z3, Z3
+	VPXORQ       Z6, Z4, Z4
+	VMOVDQA64    Z0, 960(AX)
+	VMOVDQA64    Z1, 1344(AX)
+	VMOVDQA64    Z2, 128(AX)
+	VMOVDQA64    Z3, 512(AX)
+	VMOVDQA64    Z4, 896(AX)
+	VMOVDQA64    (AX), Z0
+	VMOVDQA64    64(AX), Z1
+	VMOVDQA64    128(AX), Z2
+	VMOVDQA64    192(AX), Z3
+	VMOVDQA64    256(AX), Z4
+	VPXORQ       320(AX), Z0, Z0
+	VPXORQ       384(AX), Z1, Z1
+	VPXORQ       448(AX), Z2, Z2
+	VPXORQ       512(AX), Z3, Z3
+	VPXORQ       576(AX), Z4, Z4
+	VPXORQ       640(AX), Z0, Z0
+	VPXORQ       704(AX), Z1, Z1
+	VPXORQ       768(AX), Z2, Z2
+	VPXORQ       832(AX), Z3, Z3
+	VPXORQ       896(AX), Z4, Z4
+	VPXORQ       960(AX), Z0, Z0
+	VPXORQ       1024(AX), Z1, Z1
+	VPXORQ       1088(AX), Z2, Z2
+	VPXORQ       1152(AX), Z3, Z3
+	VPXORQ       1216(AX), Z4, Z4
+	VPXORQ       1280(AX), Z0, Z0
+	VPXORQ       1344(AX), Z1, Z1
+	VPXORQ       1408(AX), Z2, Z2
+	VPXORQ       1472(AX), Z3, Z3
+	VPXORQ       1536(AX), Z4, Z4
+	VPROLQ       $0x01, Z1, Z5
+	VPROLQ       $0x01, Z2, Z6
+	VPROLQ       $0x01, Z3, Z7
+	VPROLQ       $0x01, Z4, Z8
+	VPROLQ       $0x01, Z0, Z9
+	VPXORQ       Z5, Z4, Z5
+	VPXORQ       Z6, Z0, Z6
+	VPXORQ       Z7, Z1, Z7
+	VPXORQ       Z8, Z2, Z8
+	VPXORQ       Z9, Z3, Z9
+	VPXORQ       (AX), Z5, Z10
+	VPXORQ       1024(AX), Z6, Z11
+	VPXORQ       448(AX), Z7, Z12
+	VPXORQ       1472(AX), Z8, Z13
+	VPXORQ       896(AX), Z9, Z14
+	VPROLQ       $0x2c, Z11, Z11
+	VPROLQ       $0x2b, Z12, Z12
+	VPROLQ       $0x15, Z13, Z13
+	VPROLQ       $0x0e, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VPBROADCASTQ 8(CX), Z10
+	VPXORQ       Z10, Z0, Z0
+	VMOVDQA64    Z0, (AX)
+	VMOVDQA64    Z1, 1024(AX)
+	VMOVDQA64    Z2, 448(AX)
+	VMOVDQA64    Z3, 1472(AX)
+	VMOVDQA64    Z4, 896(AX)
+	VPXORQ       1152(AX), Z8, Z10
+	VPXORQ       576(AX), Z9, Z11
+	VPXORQ       1280(AX), Z5, Z12
+	VPXORQ       704(AX), Z6, Z13
+	VPXORQ       128(AX), Z7, Z14
+	VPROLQ       $0x1c, Z10, Z10
+	VPROLQ       $0x14, Z11, Z11
+	VPROLQ       $0x03, Z12, Z12
+	VPROLQ       $0x2d, Z13, Z13
+	VPROLQ       $0x3d, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 1280(AX)
+	VMOVDQA64    Z1, 704(AX)
+	VMOVDQA64    Z2, 128(AX)
+	VMOVDQA64    Z3, 1152(AX)
+	VMOVDQA64    Z4, 576(AX)
+	VPXORQ       384(AX), Z6, Z10
+	VPXORQ       1408(AX), Z7, Z11
+	VPXORQ       832(AX), Z8, Z12
+	VPXORQ       256(AX), Z9, Z13
+	VPXORQ       960(AX), Z5, Z14
+	VPROLQ       $0x01, Z10, Z10
+	VPROLQ       $0x06, Z11, Z11
+	VPROLQ       $0x19, Z12, Z12
+	VPROLQ       $0x08, Z13, Z13
+	VPROLQ       $0x12, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 960(AX)
+	VMOVDQA64    Z1, 384(AX)
+	VMOVDQA64    Z2, 1408(AX)
+	VMOVDQA64    Z3, 832(AX)
+	VMOVDQA64    Z4, 256(AX)
+	VPXORQ       1536(AX), Z9, Z10
+	VPXORQ       640(AX), Z5, Z11
+	VPXORQ       64(AX), Z6, Z12
+	VPXORQ       1088(AX), Z7, Z13
+	VPXORQ       512(AX), Z8, Z14
+	VPROLQ       $0x1b, Z10, Z10
+	VPROLQ       $0x24, Z11, Z11
+	VPROLQ       $0x0a, Z12, Z12
+	VPROLQ       $0x0f, Z13, Z13
+	VPROLQ       $0x38, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 640(AX)
+	VMOVDQA64    Z1, 64(AX)
+	VMOVDQA64    Z2, 1088(AX)
+	VMOVDQA64    Z3, 512(AX)
+	VMOVDQA64    Z4, 1536(AX)
+	VPXORQ       768(AX), Z7, Z7
+	VPXORQ       192(AX), Z8, Z8
+	VPXORQ       1216(AX), Z9, Z9
+	VPXORQ       320(AX), Z5, Z5
+	VPXORQ       1344(AX), Z6, Z6
+	VPROLQ       $0x3e, Z7, Z7
+	VPROLQ       $0x37, Z8, Z8
+	VPROLQ       $0x27, Z9, Z9
+	VPROLQ       $0x29, Z5, Z5
+	VPROLQ       $0x02, Z6, Z6
+	VPANDNQ      Z9, Z8, Z0
+	VPANDNQ      Z5, Z9, Z1
+	VPANDNQ      Z6, Z5, Z2
+	VPANDNQ      Z7, Z6, Z3
+	VPANDNQ      Z8, Z7, Z4
+	VPXORQ       Z7, Z0, Z0
+	VPXORQ       Z8, Z1, Z1
+	VPXORQ       Z9, Z2, Z2
+	VPXORQ       Z5, Z3, Z3
+	VPXORQ       Z6, Z4, Z4
+	VMOVDQA64    Z0, 320(AX)
+	VMOVDQA64    Z1, 1344(AX)
+	VMOVDQA64    Z2, 768(AX)
+	VMOVDQA64    Z3, 192(AX)
+	VMOVDQA64    Z4, 1216(AX)
+	VMOVDQA64    (AX), Z0
+	VMOVDQA64    64(AX), Z1
+	VMOVDQA64    128(AX), Z2
+	VMOVDQA64    192(AX), Z3
+	VMOVDQA64    256(AX), Z4
+	VPXORQ       320(AX), Z0, Z0
+	VPXORQ       384(AX), Z1, Z1
+	VPXORQ       448(AX), Z2, Z2
+	VPXORQ       512(AX), Z3, Z3
+	VPXORQ       576(AX), Z4, Z4
+	VPXORQ       640(AX), Z0, Z0
+	VPXORQ       704(AX), Z1, Z1
+	VPXORQ       768(AX), Z2, Z2
+	VPXORQ       832(AX), Z3, Z3
+	VPXORQ       896(AX), Z4, Z4
+	VPXORQ       960(AX), Z0, Z0
+	VPXORQ       1024(AX), Z1, Z1
+	VPXORQ       1088(AX), Z2, Z2
+	VPXORQ       1152(AX), Z3, Z3
+	VPXORQ       1216(AX), Z4, Z4
+	VPXORQ       1280(AX), Z0, Z0
+	VPXORQ       1344(AX), Z1, Z1
+	VPXORQ       1408(AX), Z2, Z2
+	VPXORQ       1472(AX), Z3, Z3
+	VPXORQ       1536(AX), Z4, Z4
+	VPROLQ       $0x01, Z1, Z5
+	VPROLQ       $0x01, Z2, Z6
+	VPROLQ       $0x01, Z3, Z7
+	VPROLQ       $0x01, Z4, Z8
+	VPROLQ       $0x01, Z0, Z9
+	VPXORQ       Z5, Z4, Z5
+	VPXORQ       Z6, Z0, Z6
+	VPXORQ       Z7, Z1, Z7
+	VPXORQ       Z8, Z2, Z8
+	VPXORQ       Z9, Z3, Z9
+	VPXORQ       (AX), Z5, Z10
+	VPXORQ       704(AX), Z6, Z11
+	VPXORQ       1408(AX), Z7, Z12
+	VPXORQ       512(AX), Z8, Z13
+	VPXORQ       1216(AX), Z9, Z14
+	VPROLQ       $0x2c, Z11, Z11
+	VPROLQ       $0x2b, Z12, Z12
+	VPROLQ       $0x15, Z13, Z13
+	VPROLQ       $0x0e, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VPBROADCASTQ 16(CX), Z10
+	VPXORQ       Z10, Z0, Z0
+	VMOVDQA64    Z0, (AX)
+	VMOVDQA64    Z1, 704(AX)
+	VMOVDQA64    Z2, 1408(AX)
+	VMOVDQA64    Z3, 512(AX)
+	VMOVDQA64    Z4, 1216(AX)
+	VPXORQ       1472(AX), Z8, Z10
+	VPXORQ       576(AX), Z9, Z11
+	VPXORQ       960(AX), Z5, Z12
+	VPXORQ       64(AX), Z6, Z13
+	VPXORQ       768(AX), Z7, Z14
+	VPROLQ       $0x1c, Z10, Z10
+	VPROLQ       $0x14, Z11, Z11
+	VPROLQ       $0x03, Z12, Z12
+	VPROLQ       $0x2d, Z13, Z13
+	VPROLQ       $0x3d, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 960(AX)
+	VMOVDQA64    Z1, 64(AX)
+	VMOVDQA64    Z2, 768(AX)
+	VMOVDQA64    Z3, 1472(AX)
+	VMOVDQA64    Z4, 576(AX)
+	VPXORQ       1024(AX), Z6, Z10
+	VPXORQ       128(AX), Z7, Z11
+	VPXORQ       832(AX), Z8, Z12
+	VPXORQ       1536(AX), Z9, Z13
+	VPXORQ       320(AX), Z5, Z14
+	VPROLQ       $0x01, Z10, Z10
+	VPROLQ       $0x06, Z11, Z11
+	VPROLQ       $0x19, Z12, Z12
+	VPROLQ       $0x08, Z13, Z13
+	VPROLQ       $0x12, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 320(AX)
+	VMOVDQA64    Z1, 1024(AX)
+	VMOVDQA64    Z2, 128(AX)
+	VMOVDQA64    Z3, 832(AX)
+	VMOVDQA64    Z4, 1536(AX)
+	VPXORQ       896(AX), Z9, Z10
+	VPXORQ       1280(AX), Z5, Z11
+	VPXORQ       384(AX), Z6, Z12
+	VPXORQ       1088(AX), Z7, Z13
+	VPXORQ       192(AX), Z8, Z14
+	VPROLQ       $0x1b, Z10, Z10
+	VPROLQ       $0x24, Z11, Z11
+	VPROLQ       $0x0a, Z12, Z12
+	VPROLQ       $0x0f, Z13, Z13
+	VPROLQ       $0x38, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 1280(AX)
+	VMOVDQA64    Z1, 384(AX)
+	VMOVDQA64    Z2, 1088(AX)
+	VMOVDQA64    Z3, 192(AX)
+	VMOVDQA64    Z4, 896(AX)
+	VPXORQ       448(AX), Z7, Z7
+	VPXORQ       1152(AX), Z8, Z8
+	VPXORQ       256(AX), Z9, Z9
+	VPXORQ       640(AX), Z5, Z5
+	VPXORQ       1344(AX), Z6, Z6
+	VPROLQ       $0x3e, Z7, Z7
+	VPROLQ       $0x37, Z8, Z8
+	VPROLQ       $0x27, Z9, Z9
+	VPROLQ       $0x29, Z5, Z5
+	VPROLQ       $0x02, Z6, Z6
+	VPANDNQ      Z9, Z8, Z0
+	VPANDNQ      Z5, Z9, Z1
+	VPANDNQ      Z6, Z5, Z2
+	VPANDNQ      Z7, Z6, Z3
+	VPANDNQ      Z8, Z7, Z4
+	VPXORQ       Z7, Z0, Z0
+	VPXORQ       Z8, Z1, Z1
+	VPXORQ       Z9, Z2, Z2
+	VPXORQ       Z5, Z3, Z3
+	VPXORQ       Z6, Z4, Z4
+	VMOVDQA64    Z0, 640(AX)
+	VMOVDQA64    Z1, 1344(AX)
+	VMOVDQA64    Z2, 448(AX)
+	VMOVDQA64    Z3, 1152(AX)
+	VMOVDQA64    Z4, 256(AX)
+	VMOVDQA64    (AX), Z0
+	VMOVDQA64    64(AX), Z1
+	VMOVDQA64    128(AX), Z2
+	VMOVDQA64    192(AX), Z3
+	VMOVDQA64    256(AX), Z4
+	VPXORQ       320(AX), Z0, Z0
+	VPXORQ       384(AX), Z1, Z1
+	VPXORQ       448(AX), Z2, Z2
+	VPXORQ       512(AX), Z3, Z3
+	VPXORQ       576(AX), Z4, Z4
+	VPXORQ       640(AX), Z0, Z0
+	VPXORQ       704(AX), Z1, Z1
+	VPXORQ       768(AX), Z2, Z2
+	VPXORQ       832(AX), Z3, Z3
+	VPXORQ       896(AX), Z4, Z4
+	VPXORQ       960(AX), Z0, Z0
+	VPXORQ       1024(AX), Z1, Z1
+	VPXORQ       1088(AX), Z2, Z2
+	VPXORQ       1152(AX), Z3, Z3
+	VPXORQ       1216(AX), Z4, Z4
+	VPXORQ       1280(AX), Z0, Z0
+	VPXORQ       1344(AX), Z1, Z1
+	VPXORQ       1408(AX), Z2, Z2
+	VPXORQ       1472(AX), Z3, Z3
+	VPXORQ       1536(AX), Z4, Z4
+	VPROLQ       $0x01, Z1, Z5
+	VPROLQ       $0x01, Z2, Z6
+	VPROLQ       $0x01, Z3, Z7
+	VPROLQ       $0x01, Z4, Z8
+	VPROLQ       $0x01, Z0, Z9
+	VPXORQ       Z5, Z4, Z5
+	VPXORQ       Z6, Z0, Z6
+	VPXORQ       Z7, Z1, Z7
+	VPXORQ       Z8, Z2, Z8
+	VPXORQ       Z9, Z3, Z9
+	VPXORQ       (AX), Z5, Z10
+	VPXORQ       64(AX), Z6, Z11
+	VPXORQ       128(AX), Z7, Z12
+	VPXORQ       192(AX), Z8, Z13
+	VPXORQ       256(AX), Z9, Z14
+	VPROLQ       $0x2c, Z11, Z11
+	VPROLQ       $0x2b, Z12, Z12
+	VPROLQ       $0x15, Z13, Z13
+	VPROLQ       $0x0e, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VPBROADCASTQ 24(CX), Z10
+	VPXORQ       Z10, Z0, Z0
+	VMOVDQA64    Z0, (AX)
+	VMOVDQA64    Z1, 64(AX)
+	VMOVDQA64    Z2, 128(AX)
+	VMOVDQA64    Z3, 192(AX)
+	VMOVDQA64    Z4, 256(AX)
+	VPXORQ       512(AX), Z8, Z10
+	VPXORQ       576(AX), Z9, Z11
+	VPXORQ       320(AX), Z5, Z12
+	VPXORQ       384(AX), Z6, Z13
+	VPXORQ       448(AX), Z7, Z14
+	VPROLQ       $0x1c, Z10, Z10
+	VPROLQ       $0x14, Z11, Z11
+	VPROLQ       $0x03, Z12, Z12
+	VPROLQ       $0x2d, Z13, Z13
+	VPROLQ       $0x3d, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 320(AX)
+	VMOVDQA64    Z1, 384(AX)
+	VMOVDQA64    Z2, 448(AX)
+	VMOVDQA64    Z3, 512(AX)
+	VMOVDQA64    Z4, 576(AX)
+	VPXORQ       704(AX), Z6, Z10
+	VPXORQ       768(AX), Z7, Z11
+	VPXORQ       832(AX), Z8, Z12
+	VPXORQ       896(AX), Z9, Z13
+	VPXORQ       640(AX), Z5, Z14
+	VPROLQ       $0x01, Z10, Z10
+	VPROLQ       $0x06, Z11, Z11
+	VPROLQ       $0x19, Z12, Z12
+	VPROLQ       $0x08, Z13, Z13
+	VPROLQ       $0x12, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 640(AX)
+	VMOVDQA64    Z1, 704(AX)
+	VMOVDQA64    Z2, 768(AX)
+	VMOVDQA64    Z3, 832(AX)
+	VMOVDQA64    Z4, 896(AX)
+	VPXORQ       1216(AX), Z9, Z10
+	VPXORQ       960(AX), Z5, Z11
+	VPXORQ       1024(AX), Z6, Z12
+	VPXORQ       1088(AX), Z7, Z13
+	VPXORQ       1152(AX), Z8, Z14
+	VPROLQ       $0x1b, Z10, Z10
+	VPROLQ       $0x24, Z11, Z11
+	VPROLQ       $0x0a, Z12, Z12
+	VPROLQ       $0x0f, Z13, Z13
+	VPROLQ       $0x38, Z14, Z14
+	VPANDNQ      Z12, Z11, Z0
+	VPANDNQ      Z13, Z12, Z1
+	VPANDNQ      Z14, Z13, Z2
+	VPANDNQ      Z10, Z14, Z3
+	VPANDNQ      Z11, Z10, Z4
+	VPXORQ       Z10, Z0, Z0
+	VPXORQ       Z11, Z1, Z1
+	VPXORQ       Z12, Z2, Z2
+	VPXORQ       Z13, Z3, Z3
+	VPXORQ       Z14, Z4, Z4
+	VMOVDQA64    Z0, 960(AX)
+	VMOVDQA64    Z1, 1024(AX)
+	VMOVDQA64    Z2, 1088(AX)
+	VMOVDQA64    Z3, 1152(AX)
+	VMOVDQA64    Z4, 1216(AX)
+	VPXORQ       1408(AX), Z7, Z7
+	VPXORQ       1472(AX), Z8, Z8
+	VPXORQ       1536(AX), Z9, Z9
+	VPXORQ       1280(AX), Z5, Z5
+	VPXORQ       1344(AX), Z6, Z6
+	VPROLQ       $0x3e, Z7, Z7
+	VPROLQ       $0x37, Z8, Z8
+	VPROLQ       $0x27, Z9, Z9
+	VPROLQ       $0x29, Z5, Z5
+	VPROLQ       $0x02, Z6, Z6
+	VPANDNQ      Z9, Z8, Z0
+	VPANDNQ      Z5, Z9, Z1
+	VPANDNQ      Z6, Z5, Z2
+	VPANDNQ      Z7, Z6, Z3
+	VPANDNQ      Z8, Z7, Z4
+	VPXORQ       Z7, Z0, Z0
+	VPXORQ       Z8, Z1, Z1
+	VPXORQ       Z9, Z2, Z2
+	VPXORQ       Z5, Z3, Z3
+	VPXORQ       Z6, Z4, Z4
+	VMOVDQA64    Z0, 1280(AX)
+	VMOVDQA64    Z1, 1344(AX)
+	VMOVDQA64    Z2, 1408(AX)
+	VMOVDQA64    Z3, 1472(AX)
+	VMOVDQA64    Z4, 1536(AX)
+	ADDQ         $0x20, CX
+	SUBQ         $0x00000001, DX
+	JNZ          loop
+	RET
@@ -6,3 +6,9 @@ package keccakf1600
 
 //go:noescape
 func f1600x4AVX2(state *uint64, rc *[24]uint64, turbo bool)
+
+//go:noescape
+func f1600x2AVX2(state *uint64, rc *[24]uint64, turbo bool)
+
+//go:noescape
+func f1600x8AVX512(state *uint64, rc *[24]uint64, turbo bool)
@@ -1,8 +1,11 @@
 package keccakf1600
 
 import (
+	"math/rand"
 	"reflect"
 	"testing"
+
+	"github.com/karalef/circl/internal/sha3"
 )
 
 // From the Keccak code package.
@@ -64,6 +67,29 @@ func TestKeccakF1600x4(t *testing.T) {
 	})
 }
 
+func TestKeccakF1600x8(t *testing.T) {
+	test := func(t *testing.T, turbo bool, f func(s *StateX8, a []uint64)) {
+		t.Helper()
+		var state StateX8
+		a := state.Initialize(turbo)
+		f(&state, a)
+		for i := 0; i < 25; i++ {
+			for j := 0; j < 8; j++ {
+				if a[8*i+j] != permutationOfZeroes[i] {
+					t.Fatalf("%X", a)
+				}
+			}
+		}
+	}
+
+	t.Run("Generic", func(t *testing.T) {
+		test(t, false, func(s *StateX8, a []uint64) { permuteScalarX8(a, false) })
+	})
+	t.Run("SIMD", func(t *testing.T) {
+		test(t, false, func(s *StateX8, a []uint64) { s.Permute() })
+	})
+}
+
 func TestTurboX2(t *testing.T) {
 	var state1, state2 StateX2
 	a1 := state1.Initialize(true)
@@ -86,6 +112,98 @@ func TestTurboX4(t *testing.T) {
 	}
 }
 
+func TestRandomX2(t *testing.T) {
+	if !IsEnabledX2() {
+		t.Skip("no two-way SIMD implementation")
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, turbo := range []bool{false, true} {
+		for i := 0; i < 100; i++ {
+			var state StateX2
+			a := state.Initialize(turbo)
+			for j := range a {
+				a[j] = r.Uint64()
+			}
+			want := append([]uint64{}, a...)
+			permuteScalarX2(want, turbo)
+			state.Permute()
+			if !reflect.DeepEqual(a, want) {
+				t.Fatalf("turbo=%v: got %X, want %X", turbo, a, want)
+			}
+		}
+	}
+}
+
+func TestRandomX4(t *testing.T) {
+	if !IsEnabledX4() {
+		t.Skip("no four-way SIMD implementation")
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, turbo := range []bool{false, true} {
+		for i := 0; i < 100; i++ {
+			var state StateX4
+			a := state.Initialize(turbo)
+			for j := range a {
+				a[j] = r.Uint64()
+			}
+			want := append([]uint64{}, a...)
+			permuteScalarX4(want, turbo)
+			state.Permute()
+			if !reflect.DeepEqual(a, want) {
+				t.Fatalf("turbo=%v: got %X, want %X", turbo, a, want)
+			}
+		}
+	}
+}
+
+func TestTurboX8(t *testing.T) {
+	var state1, state2 StateX8
+	a1 := state1.Initialize(true)
+	a2 := state2.Initialize(true)
+	permuteScalarX8(a1, true)
+	state2.Permute()
+	if !reflect.DeepEqual(a1, a2) {
+		t.Fatal()
+	}
+}
+
+func TestRandomX8(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, turbo := range []bool{false, true} {
+		for i := 0; i < 100; i++ {
+			var state StateX8
+			a := state.Initialize(turbo)
+			for j := range a {
+				a[j] = r.Uint64()
+			}
+
+			// The generic implementation is checked against the scalar
+			// permutation of each state.
+			want := append([]uint64{}, a...)
+			for k := 0; k < 8; k++ {
+				var buf [25]uint64
+				for j := range buf {
+					buf[j] = want[8*j+k]
+				}
+				sha3.KeccakF1600(&buf, turbo)
+				for j := range buf {
+					want[8*j+k] = buf[j]
+				}
+			}
+			got := append([]uint64{}, a...)
+			permuteScalarX8(got, turbo)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("generic, turbo=%v: got %X, want %X", turbo, got, want)
+			}
+
+			state.Permute()
+			if !reflect.DeepEqual(a, want) {
+				t.Fatalf("turbo=%v: got %X, want %X", turbo, a, want)
+			}
+		}
+	}
+}
+
 func BenchmarkF1600x2(b *testing.B) {
 	benchmark := func(b *testing.B, turbo bool, f func(s *StateX2, a []uint64)) {
 		var state StateX2
@@ -131,3 +249,26 @@ func BenchmarkF1600x4(b *testing.B) {
 	b.Run("Regular", func(b *testing.B) { bench2(b, false) })
 	b.Run("Turbo", func(b *testing.B) { bench2(b, true) })
 }
+
+func BenchmarkF1600x8(b *testing.B) {
+	benchmark := func(b *testing.B, turbo bool, f func(s *StateX8, a []uint64)) {
+		var state StateX8
+		a := state.Initialize(turbo)
+
+		for i := 0; i < b.N; i++ {
+			f(&state, a)
+		}
+	}
+
+	bench2 := func(b *testing.B, turbo bool) {
+		b.Run("Generic", func(b *testing.B) {
+			benchmark(b, turbo, func(s *StateX8, a []uint64) { permuteScalarX8(a, turbo) })
+		})
+		b.Run("SIMD", func(b *testing.B) {
+			benchmark(b, turbo, func(s *StateX8, a []uint64) { s.Permute() })
+		})
+	}
+
+	b.Run("Regular", func(b *testing.B) { bench2(b, false) })
+	b.Run("Turbo", func(b *testing.B) { bench2(b, true) })
+}
@@ -6,3 +6,5 @@ package keccakf1600
 func permuteSIMDx2(state []uint64, turbo bool) { permuteScalarX2(state, turbo) }
 
 func permuteSIMDx4(state []uint64, turbo bool) { permuteScalarX4(state, turbo) }
+
+func permuteSIMDx8(state []uint64, turbo bool) { permuteScalarX8(state, turbo) }
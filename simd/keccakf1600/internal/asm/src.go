@@ -1,26 +1,53 @@
 //go:generate go run src.go -out ../../f1600x4_amd64.s -stubs ../../f1600x4stubs_amd64.go -pkg keccakf1600
 
-// AVX2 fourway parallelized Keccak-f[1600].
+// AVX2 two and fourway, and AVX-512 eightway parallelized Keccak-f[1600].
 
 package main
 
 import (
 	. "github.com/mmcloughlin/avo/build"   // nolint:stylecheck,golint
 	. "github.com/mmcloughlin/avo/operand" // nolint:stylecheck,golint
+	"github.com/mmcloughlin/avo/reg"
 )
 
-// nolint:funlen
 func main() {
 	ConstraintExpr("amd64")
 
 	// Must be called on 32 byte aligned memory.
-	TEXT("f1600x4AVX2", NOSPLIT, "func(state *uint64, rc *[24]uint64, turbo bool)")
+	permute("f1600x4AVX2", 4, YMM)
+
+	// Must be called on 16 byte aligned memory. The states fit into the
+	// lower halves of the registers.
+	permute("f1600x2AVX2", 2, XMM)
+
+	// Must be called on 64 byte aligned memory.
+	permute("f1600x8AVX512", 8, ZMM)
+
+	Generate()
+}
+
+// permute generates the permutation of n interleaved states, each word of
+// them fitting into a register returned by vec. The eightway permutation
+// uses the AVX-512 instructions, which can rotate the words.
+// nolint:funlen,gocyclo
+func permute(name string, n int, vec func() reg.VecVirtual) {
+	TEXT(name, NOSPLIT, "func(state *uint64, rc *[24]uint64, turbo bool)")
+
+	avx512 := n == 8
+	mov := func(a, b Op) { VMOVDQA(a, b) }
+	xor := func(a, b, c Op) { VPXOR(a, b, c) }
+	andn := func(a, b, c Op) { VPANDN(a, b, c) }
+	if avx512 {
+		mov = func(a, b Op) { VMOVDQA64(a, b) }
+		xor = func(a, b, c Op) { VPXORQ(a, b, c) }
+		andn = func(a, b, c Op) { VPANDNQ(a, b, c) }
+	}
 
 	Pragma("noescape")
 
 	statePtr := Load(Param("state"), GP64())
 	state := func(offset int) Op {
-		return Mem{Base: statePtr, Disp: 32 * offset}
+		return Mem{Base: statePtr, Disp: 8 * n * offset}
 	}
 
 	rcPtr := Load(Param("rc"), GP64())
@@ -46,30 +73,36 @@ func main() {
 
 	for r := 0; r < 4; r++ {
 		// Compute parities: p[i] = a[i] ^ a[i + 5] ^ ... ^ a[i + 20].
-		p := []Op{YMM(), YMM(), YMM(), YMM(), YMM()}
+		p := []Op{vec(), vec(), vec(), vec(), vec()}
 		for i := 0; i < 5; i++ {
-			VMOVDQA(state(i), p[i])
+			mov(state(i), p[i])
 		}
 		for j := 1; j < 5; j++ {
 			for i := 0; i < 5; i++ {
-				VPXOR(state(5*j+i), p[i], p[i])
+				xor(state(5*j+i), p[i], p[i])
 			}
 		}
 
 		// Rotate and xor parities: d[i] = rotate_left(p[i+1], 1) ^ p[i-1]
-		t := []Op{YMM(), YMM(), YMM(), YMM(), YMM()}
-		d := []Op{YMM(), YMM(), YMM(), YMM(), YMM()}
-		for i := 0; i < 5; i++ {
-			VPSLLQ(U8(1), p[(i+1)%5], t[i])
-		}
-		for i := 0; i < 5; i++ {
-			VPSRLQ(U8(63), p[(i+1)%5], d[i])
-		}
-		for i := 0; i < 5; i++ {
-			VPOR(t[i], d[i], d[i])
+		t := []Op{vec(), vec(), vec(), vec(), vec()}
+		d := []Op{vec(), vec(), vec(), vec(), vec()}
+		if avx512 {
+			for i := 0; i < 5; i++ {
+				VPROLQ(U8(1), p[(i+1)%5], d[i])
+			}
+		} else {
+			for i := 0; i < 5; i++ {
+				VPSLLQ(U8(1), p[(i+1)%5], t[i])
+			}
+			for i := 0; i < 5; i++ {
+				VPSRLQ(U8(63), p[(i+1)%5], d[i])
+			}
+			for i := 0; i < 5; i++ {
+				VPOR(t[i], d[i], d[i])
+			}
 		}
 		for i := 0; i < 5; i++ {
-			VPXOR(d[i], p[(i+4)%5], d[i])
+			xor(d[i], p[(i+4)%5], d[i])
 		}
 
 		// Rotation to use
@@ -98,49 +131,57 @@ func main() {
 		}
 
 		for g := 0; g < 5; g++ {
-			s := []Op{YMM(), YMM(), YMM(), YMM(), YMM()}
+			s := []Op{vec(), vec(), vec(), vec(), vec()}
 
 			// Load the right five words from the state and XOR d into them.
 			for i := 0; i < 5; i++ {
-				VPXOR(state(si(di(i, g), g, r)), d[di(i, g)], s[i])
+				xor(state(si(di(i, g), g, r)), d[di(i, g)], s[i])
 			}
 
 			// Rotate each s[i] by the appropriate amount
-			for i := 0; i < 5; i++ {
-				if rot(i, g) != 0 {
-					VPSLLQ(U8(rot(i, g)), s[i], t[i])
+			if avx512 {
+				for i := 0; i < 5; i++ {
+					if rot(i, g) != 0 {
+						VPROLQ(U8(rot(i, g)), s[i], s[i])
+					}
 				}
-			}
-			for i := 0; i < 5; i++ {
-				if rot(i, g) != 0 {
-					VPSRLQ(U8(64-rot(i, g)), s[i], s[i])
+			} else {
+				for i := 0; i < 5; i++ {
+					if rot(i, g) != 0 {
+						VPSLLQ(U8(rot(i, g)), s[i], t[i])
+					}
 				}
-			}
-			for i := 0; i < 5; i++ {
-				if rot(i, g) != 0 {
-					VPOR(t[i], s[i], s[i])
+				for i := 0; i < 5; i++ {
+					if rot(i, g) != 0 {
+						VPSRLQ(U8(64-rot(i, g)), s[i], s[i])
+					}
+				}
+				for i := 0; i < 5; i++ {
+					if rot(i, g) != 0 {
+						VPOR(t[i], s[i], s[i])
+					}
 				}
 			}
 
 			// Compute the new words s[i] ^ (s[i+2] & ~s[i+1])
 			for i := 0; i < 5; i++ {
-				VPANDN(s[(i+2)%5], s[(i+1)%5], t[i])
+				andn(s[(i+2)%5], s[(i+1)%5], t[i])
 			}
 			for i := 0; i < 5; i++ {
-				VPXOR(s[i], t[i], t[i])
+				xor(s[i], t[i], t[i])
 			}
 
 			// Round constant
 			if g == 0 {
 				// Note that we move rcPtr by 8*4 bytes after each superround.
-				rc := YMM()
+				rc := vec()
 				VPBROADCASTQ(Mem{Base: rcPtr, Disp: r * 8}, rc)
-				VPXOR(rc, t[0], t[0])
+				xor(rc, t[0], t[0])
 			}
 
 			// Store back into state
 			for i := 0; i < 5; i++ {
-				VMOVDQA(t[i], state(si(i, g, r)))
+				mov(t[i], state(si(i, g, r)))
 			}
 		}
 	}
@@ -150,6 +191,4 @@ func main() {
 	JNZ(LabelRef("loop"))
 
 	RET()
-
-	Generate()
 }
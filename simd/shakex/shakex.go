@@ -0,0 +1,266 @@
+// Package shakex provides SHAKE and TurboSHAKE instances that process
+// several independent streams in parallel.
+//
+// A State holds a fixed number of lanes, each of which is a separate SHAKE
+// or TurboSHAKE computation. The permutations of the lanes that are ready at
+// the same time are batched through the four-way or two-way Keccak-f[1600]
+// of package keccakf1600 when the platform supports it, and computed one by
+// one otherwise, so the results are the same on every platform.
+//
+// Lanes are most efficient when they absorb and squeeze the same number of
+// bytes, for instance the nodes of a Merkle tree, but inputs of different
+// lengths are supported too.
+package shakex
+
+import (
+	"encoding/binary"
+
+	"github.com/karalef/circl/internal/sha3"
+	"github.com/karalef/circl/simd/keccakf1600"
+)
+
+const maxRate = 168
+
+type lane struct {
+	a   [25]uint64
+	buf [maxRate]byte // pending input or available output
+	pos int           // bytes used in buf
+}
+
+// State is a multi-lane SHAKE or TurboSHAKE instance.
+type State struct {
+	lanes     []lane
+	rate      int
+	dsbyte    byte
+	turbo     bool
+	squeezing bool
+}
+
+// NewShake128 returns a State computing n SHAKE128 instances.
+func NewShake128(n int) *State { return newState(n, 168, 0x1f, false) }
+
+// NewShake256 returns a State computing n SHAKE256 instances.
+func NewShake256(n int) *State { return newState(n, 136, 0x1f, false) }
+
+// NewTurboShake128 returns a State computing n TurboSHAKE128 instances with
+// domain separation byte D, which must be between 0x01 and 0x7f inclusive,
+// otherwise it panics.
+func NewTurboShake128(n int, D byte) *State {
+	checkDomain(D)
+	return newState(n, 168, D, true)
+}
+
+// NewTurboShake256 returns a State computing n TurboSHAKE256 instances with
+// domain separation byte D, which must be between 0x01 and 0x7f inclusive,
+// otherwise it panics.
+func NewTurboShake256(n int, D byte) *State {
+	checkDomain(D)
+	return newState(n, 136, D, true)
+}
+
+func checkDomain(D byte) {
+	if D == 0 || D > 0x7f {
+		panic("shakex: D out of range")
+	}
+}
+
+func newState(n, rate int, dsbyte byte, turbo bool) *State {
+	if n <= 0 {
+		panic("shakex: non-positive number of lanes")
+	}
+	return &State{
+		lanes:  make([]lane, n),
+		rate:   rate,
+		dsbyte: dsbyte,
+		turbo:  turbo,
+	}
+}
+
+// Lanes returns the number of lanes.
+func (s *State) Lanes() int { return len(s.lanes) }
+
+// BlockSize returns the rate of the sponges.
+func (s *State) BlockSize() int { return s.rate }
+
+// Reset restores all the lanes to their initial state.
+func (s *State) Reset() {
+	for i := range s.lanes {
+		s.lanes[i] = lane{}
+	}
+	s.squeezing = false
+}
+
+// Clone returns a copy of the State.
+func (s *State) Clone() *State {
+	c := *s
+	c.lanes = append([]lane(nil), s.lanes...)
+	return &c
+}
+
+// Absorb writes in[i] into the i-th lane. The slices may have different
+// lengths. It panics if len(in) differs from the number of lanes or if
+// called after Squeeze.
+func (s *State) Absorb(in ...[]byte) {
+	if len(in) != len(s.lanes) {
+		panic("shakex: wrong number of inputs")
+	}
+	if s.squeezing {
+		panic("shakex: absorb after squeeze")
+	}
+	in = append([][]byte(nil), in...)
+
+	ready := make([]int, 0, len(s.lanes))
+	for {
+		ready = ready[:0]
+		for i := range s.lanes {
+			l := &s.lanes[i]
+			n := copy(l.buf[l.pos:s.rate], in[i])
+			l.pos += n
+			in[i] = in[i][n:]
+			if l.pos == s.rate {
+				xorIn(&l.a, l.buf[:s.rate])
+				l.pos = 0
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			return
+		}
+		s.permute(ready)
+	}
+}
+
+// Squeeze reads out[i] from the i-th lane. The slices may have different
+// lengths. It panics if len(out) differs from the number of lanes.
+func (s *State) Squeeze(out ...[]byte) {
+	if len(out) != len(s.lanes) {
+		panic("shakex: wrong number of outputs")
+	}
+	if !s.squeezing {
+		s.pad()
+	}
+	out = append([][]byte(nil), out...)
+
+	ready := make([]int, 0, len(s.lanes))
+	for {
+		ready = ready[:0]
+		for i := range s.lanes {
+			l := &s.lanes[i]
+			n := copy(out[i], l.buf[l.pos:s.rate])
+			l.pos += n
+			out[i] = out[i][n:]
+			if l.pos == s.rate && len(out[i]) > 0 {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			return
+		}
+		s.permute(ready)
+		for _, i := range ready {
+			s.lanes[i].extract(s.rate)
+		}
+	}
+}
+
+// pad applies the padding to all the lanes and switches to squeezing.
+func (s *State) pad() {
+	all := make([]int, len(s.lanes))
+	for i := range s.lanes {
+		l := &s.lanes[i]
+		for j := l.pos; j < s.rate; j++ {
+			l.buf[j] = 0
+		}
+		l.buf[l.pos] ^= s.dsbyte
+		l.buf[s.rate-1] ^= 0x80
+		xorIn(&l.a, l.buf[:s.rate])
+		all[i] = i
+	}
+	s.permute(all)
+	for i := range s.lanes {
+		s.lanes[i].extract(s.rate)
+	}
+	s.squeezing = true
+}
+
+// extract copies the first rate bytes of the state into buf.
+func (l *lane) extract(rate int) {
+	for i := 0; i < rate/8; i++ {
+		binary.LittleEndian.PutUint64(l.buf[8*i:], l.a[i])
+	}
+	l.pos = 0
+}
+
+func xorIn(a *[25]uint64, b []byte) {
+	for i := 0; i < len(b)/8; i++ {
+		a[i] ^= binary.LittleEndian.Uint64(b[8*i:])
+	}
+}
+
+// permute applies Keccak-f[1600] to the given lanes, four or two at a time
+// when a SIMD implementation is available.
+func (s *State) permute(idx []int) {
+	if keccakf1600.IsEnabledX4() {
+		for ; len(idx) >= 4; idx = idx[4:] {
+			var x4 keccakf1600.StateX4
+			a := x4.Initialize(s.turbo)
+			s.interleave(a, idx[:4])
+			x4.Permute()
+			s.deinterleave(a, idx[:4])
+		}
+	}
+	if keccakf1600.IsEnabledX2() {
+		for ; len(idx) >= 2; idx = idx[2:] {
+			var x2 keccakf1600.StateX2
+			a := x2.Initialize(s.turbo)
+			s.interleave(a, idx[:2])
+			x2.Permute()
+			s.deinterleave(a, idx[:2])
+		}
+	}
+	for _, i := range idx {
+		sha3.KeccakF1600(&s.lanes[i].a, s.turbo)
+	}
+}
+
+func (s *State) interleave(a []uint64, idx []int) {
+	n := len(idx)
+	for j, i := range idx {
+		l := &s.lanes[i].a
+		for k := 0; k < 25; k++ {
+			a[k*n+j] = l[k]
+		}
+	}
+}
+
+func (s *State) deinterleave(a []uint64, idx []int) {
+	n := len(idx)
+	for j, i := range idx {
+		l := &s.lanes[i].a
+		for k := 0; k < 25; k++ {
+			l[k] = a[k*n+j]
+		}
+	}
+}
+
+// Shake128Sum writes the SHAKE128 digest of in[i] into out[i] for every i,
+// hashing them in parallel. It panics if len(in) != len(out).
+func Shake128Sum(out, in [][]byte) {
+	if len(in) == 0 && len(out) == 0 {
+		return
+	}
+	s := NewShake128(len(in))
+	s.Absorb(in...)
+	s.Squeeze(out...)
+}
+
+// Shake256Sum writes the SHAKE256 digest of in[i] into out[i] for every i,
+// hashing them in parallel. It panics if len(in) != len(out).
+func Shake256Sum(out, in [][]byte) {
+	if len(in) == 0 && len(out) == 0 {
+		return
+	}
+	s := NewShake256(len(in))
+	s.Absorb(in...)
+	s.Squeeze(out...)
+}
@@ -0,0 +1,157 @@
+package shakex_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/karalef/circl/internal/sha3"
+	"github.com/karalef/circl/internal/test"
+	"github.com/karalef/circl/simd/shakex"
+)
+
+func TestShakeX(t *testing.T) {
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	for _, v := range []struct {
+		name string
+		new  func(n int) *shakex.State
+		ref  func() sha3.State
+	}{
+		{"SHAKE128", shakex.NewShake128, sha3.NewShake128},
+		{"SHAKE256", shakex.NewShake256, sha3.NewShake256},
+		{"TurboSHAKE128", func(n int) *shakex.State { return shakex.NewTurboShake128(n, 0x0b) }, func() sha3.State { return sha3.NewTurboShake128(0x0b) }},
+		{"TurboSHAKE256", func(n int) *shakex.State { return shakex.NewTurboShake256(n, 0x0b) }, func() sha3.State { return sha3.NewTurboShake256(0x0b) }},
+	} {
+		for _, n := range []int{1, 2, 3, 4, 5, 8, 9} {
+			// Lanes of both equal and different lengths, absorbed and
+			// squeezed in several calls.
+			for _, equal := range []bool{true, false} {
+				inLen := func(i int) int {
+					if equal {
+						return 300
+					}
+					return (i * 137) % len(msg)
+				}
+				outLen := func(i int) int {
+					if equal {
+						return 400
+					}
+					return 1 + (i*211)%500
+				}
+
+				s := v.new(n)
+				test.CheckOk(s.Lanes() == n, "wrong number of lanes", t)
+				for _, split := range [][2]int{{0, 100}, {100, len(msg)}} {
+					in := make([][]byte, n)
+					for i := range in {
+						l := inLen(i)
+						lo, hi := split[0], split[1]
+						if lo > l {
+							lo = l
+						}
+						if hi > l {
+							hi = l
+						}
+						in[i] = msg[lo:hi]
+					}
+					s.Absorb(in...)
+				}
+
+				got := make([][]byte, n)
+				for i := range got {
+					got[i] = make([]byte, outLen(i))
+				}
+				half := make([][]byte, n)
+				rest := make([][]byte, n)
+				for i := range got {
+					half[i] = got[i][:len(got[i])/2]
+					rest[i] = got[i][len(got[i])/2:]
+				}
+				s.Squeeze(half...)
+				s.Squeeze(rest...)
+
+				for i := range got {
+					h := v.ref()
+					_, _ = h.Write(msg[:inLen(i)])
+					want := make([]byte, outLen(i))
+					_, _ = h.Read(want)
+					if !bytes.Equal(got[i], want) {
+						test.ReportError(t, got[i], want, v.name, n, equal, i)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAPI(t *testing.T) {
+	s := shakex.NewShake128(2)
+	s.Absorb([]byte("a"), []byte("b"))
+	c := s.Clone()
+	a, b := make([]byte, 32), make([]byte, 32)
+	s.Squeeze(a, make([]byte, 0))
+	c.Squeeze(b, make([]byte, 0))
+	test.CheckOk(bytes.Equal(a, b), "clone differs", t)
+
+	s.Reset()
+	s.Absorb([]byte("a"), []byte("b"))
+	s.Squeeze(b, make([]byte, 0))
+	test.CheckOk(bytes.Equal(a, b), "reset differs", t)
+
+	err := test.CheckPanic(func() { s.Absorb(nil, nil) })
+	test.CheckNoErr(t, err, "should panic due to Absorb after Squeeze")
+	err = test.CheckPanic(func() { s.Squeeze(a) })
+	test.CheckNoErr(t, err, "should panic due to wrong number of outputs")
+	err = test.CheckPanic(func() { shakex.NewTurboShake128(4, 0) })
+	test.CheckNoErr(t, err, "should panic due to bad domain separation byte")
+
+	// The caller's slices are not modified.
+	in := [][]byte{[]byte("abc")}
+	shakex.Shake128Sum([][]byte{a}, in)
+	test.CheckOk(len(in[0]) == 3, "input modified", t)
+}
+
+func BenchmarkShake128X4(b *testing.B) { benchmarkShake(b, 4, 1024) }
+func BenchmarkShake128X1(b *testing.B) { benchmarkShake(b, 1, 1024) }
+
+func benchmarkShake(b *testing.B, n, size int) {
+	in := make([][]byte, n)
+	out := make([][]byte, n)
+	for i := range in {
+		in[i] = make([]byte, size)
+		out[i] = make([]byte, 32)
+	}
+	b.SetBytes(int64(n * size))
+	for i := 0; i < b.N; i++ {
+		shakex.Shake128Sum(out, in)
+	}
+}
+
+func Example() {
+	// Computes the SHAKE256 digests of four strings at the same time.
+	msgs := [][]byte{
+		[]byte("These are some short"),
+		[]byte("strings of the same "),
+		[]byte("length that fit in a"),
+		[]byte("single block.       "),
+	}
+	hashes := make([][]byte, len(msgs))
+	for i := range hashes {
+		hashes[i] = make([]byte, 32)
+	}
+
+	shakex.Shake256Sum(hashes, msgs)
+
+	for _, h := range hashes {
+		fmt.Printf("%x\n", h)
+	}
+	// Output:
+	// 9b48efc4f4e562fe28c510b2ad3966b101ac20066dc88117d85a595cc965f7e4
+	// 19333d8bb71edce81f0630e4154abea83bf7d2f7e709d62fda878b6e9db9c9c1
+	// 28f31cc0b8d95185fbba5c4ed5cd94ed7dba0e13c21ca830d1325a212defdfc5
+	// 51392299d6b10e62b98eb02c9540784046cc9c83e46eddd2ce57cddc2037f917
+}
@@ -0,0 +1,122 @@
+package transcript
+
+import "github.com/karalef/circl/internal/sha3"
+
+// Flags of the STROBE operations.
+const (
+	flagI = 1 << iota // inbound
+	flagA             // application
+	flagC             // cipher
+	flagT             // transport
+	flagM             // meta
+	flagK             // keytree, reserved
+)
+
+// Operations used by the transcripts.
+const (
+	opAD      = flagA
+	opMetaAD  = flagM | flagA
+	opKey     = flagA | flagC
+	opPRF     = flagI | flagA | flagC
+	opSendEnc = flagA | flagC | flagT
+	opRecvEnc = flagI | flagA | flagC | flagT
+	opSendMAC = flagC | flagT
+	opRecvMAC = flagI | flagC | flagT
+	opRatchet = flagC
+)
+
+// strobeRate is the rate of STROBE-128/1600 in bytes: the capacity is 256
+// bits, and two more bytes are reserved for the padding.
+const strobeRate = 200 - 256/8 - 2
+
+// roleNone means that no transport operation has been performed yet.
+const roleNone = 0xff
+
+// strobe is the STROBE v1.0.2 protocol framework instantiated with
+// Keccak-f[1600] at the 128-bit security level.
+// https://strobe.sourceforge.io/specs/
+type strobe struct {
+	a        [25]uint64
+	pos      int
+	posBegin int
+	curFlags byte
+	role     byte // flagI of the first transport operation, or roleNone
+	turbo    bool
+}
+
+func newStrobe(protocol []byte, turbo bool) strobe {
+	s := strobe{role: roleNone, turbo: turbo}
+	domain := []byte{1, strobeRate + 2, 1, 0, 1, 12 * 8}
+	domain = append(domain, "STROBEv1.0.2"...)
+	for i, b := range domain {
+		s.xorByte(i, b)
+	}
+	sha3.KeccakF1600(&s.a, s.turbo)
+	s.operate(opMetaAD, protocol, false)
+	return s
+}
+
+func (s *strobe) byteAt(i int) byte { return byte(s.a[i/8] >> (8 * (i % 8))) }
+
+func (s *strobe) xorByte(i int, b byte) { s.a[i/8] ^= uint64(b) << (8 * (i % 8)) }
+
+func (s *strobe) runF() {
+	s.xorByte(s.pos, byte(s.posBegin))
+	s.xorByte(s.pos+1, 0x04)
+	s.xorByte(strobeRate+1, 0x80)
+	sha3.KeccakF1600(&s.a, s.turbo)
+	s.pos = 0
+	s.posBegin = 0
+}
+
+// duplex processes data in place. If cBefore is set, the bytes are replaced
+// by their XOR with the state before it is updated, and if cAfter is set,
+// by the updated state.
+func (s *strobe) duplex(data []byte, cBefore, cAfter bool) {
+	for i := range data {
+		if cBefore {
+			data[i] ^= s.byteAt(s.pos)
+		}
+		s.xorByte(s.pos, data[i])
+		if cAfter {
+			data[i] = s.byteAt(s.pos)
+		}
+		s.pos++
+		if s.pos == strobeRate {
+			s.runF()
+		}
+	}
+}
+
+func (s *strobe) beginOp(flags byte) {
+	if flags&flagT != 0 {
+		if s.role == roleNone {
+			s.role = flags & flagI
+		}
+		flags ^= s.role
+	}
+	oldBegin := byte(s.posBegin)
+	s.posBegin = s.pos + 1
+	s.duplex([]byte{oldBegin, flags}, false, false)
+	if flags&(flagC|flagK) != 0 && s.pos != 0 {
+		s.runF()
+	}
+}
+
+// operate performs the operation given by flags on data in place, which is
+// left unchanged by the operations without flagC. If more is set, it
+// continues the previous operation, which must have had the same flags.
+func (s *strobe) operate(flags byte, data []byte, more bool) {
+	if more {
+		if flags != s.curFlags {
+			panic("transcript: continued operation with different flags")
+		}
+	} else {
+		s.beginOp(flags)
+		s.curFlags = flags
+	}
+
+	cAfter := flags&(flagC|flagI|flagT) == flagC|flagT
+	cBefore := flags&flagC != 0 && !cAfter
+	s.duplex(data, cBefore, cAfter)
+}
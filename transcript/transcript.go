@@ -0,0 +1,167 @@
+// Package transcript provides transcripts of cryptographic protocols built
+// on the STROBE framework over Keccak-f[1600].
+//
+// A Transcript absorbs labeled messages exchanged by the parties of a
+// protocol and squeezes challenges bound to all of them, which is the
+// Fiat-Shamir transform of interactive proofs. Unlike an XOF, it can keep
+// absorbing messages after producing output. Once a secret has been
+// absorbed with Key, it can also encrypt and authenticate messages and
+// ratchet its state to provide forward secrecy.
+//
+// Transcripts returned by New are compatible with Merlin v1.0, which uses
+// STROBE-128 with the full 24-round permutation.
+// https://merlin.cool
+//
+// Those returned by NewTurbo use the 12-round permutation of TurboSHAKE
+// instead, and are thus incompatible with them.
+// https://strobe.sourceforge.io/specs/
+package transcript
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// MACSize is the size of the authentication tags of Seal and Open in bytes.
+const MACSize = 16
+
+// RatchetSize is the number of bytes of the state erased by Ratchet.
+const RatchetSize = 16
+
+// ErrDecryption is returned by Open when the authentication fails.
+var ErrDecryption = errors.New("transcript: invalid ciphertext")
+
+// Transcript is a transcript of a protocol.
+//
+// Both parties must perform the same sequence of operations with the same
+// labels. Operations that transfer data, Seal and Open, take the direction
+// into account: a message sealed by one party must be opened by the other.
+type Transcript struct {
+	s strobe
+}
+
+// New returns a transcript of the protocol with the given label, using the
+// full-round Keccak-f[1600] permutation.
+func New(label []byte) *Transcript { return newTranscript(label, false) }
+
+// NewTurbo returns a transcript of the protocol with the given label, using
+// the 12-round Keccak-p[1600, 12] permutation.
+func NewTurbo(label []byte) *Transcript { return newTranscript(label, true) }
+
+func newTranscript(label []byte, turbo bool) *Transcript {
+	t := &Transcript{newStrobe([]byte("Merlin v1.0"), turbo)}
+	t.AppendMessage([]byte("dom-sep"), label)
+	return t
+}
+
+// frame absorbs the label of an operation and the length of its data.
+func (t *Transcript) frame(label []byte, n int) {
+	var l [4]byte
+	binary.LittleEndian.PutUint32(l[:], uint32(n))
+	t.s.operate(opMetaAD, label, false)
+	t.s.operate(opMetaAD, l[:], true)
+}
+
+// AppendMessage absorbs a message with the given label.
+func (t *Transcript) AppendMessage(label, message []byte) {
+	t.frame(label, len(message))
+	t.s.operate(opAD, message, false)
+}
+
+// ChallengeBytes fills out with a challenge bound to the label and to all
+// the previous operations.
+func (t *Transcript) ChallengeBytes(label, out []byte) {
+	t.frame(label, len(out))
+	for i := range out {
+		out[i] = 0
+	}
+	t.s.operate(opPRF, out, false)
+}
+
+// Key absorbs a secret with the given label. It replaces part of the state,
+// so that the following outputs depend on the secret even if the state was
+// known before.
+func (t *Transcript) Key(label, key []byte) {
+	t.frame(label, len(key))
+	t.s.operate(opKey, append([]byte{}, key...), false)
+}
+
+// Seal encrypts plaintext, appends it to dst followed by an authentication
+// tag of MACSize bytes, and returns the updated slice. The encryption is only
+// secure if a secret has been absorbed with Key.
+//
+// To reuse plaintext's storage for the encrypted output, use plaintext[:0]
+// as dst. Otherwise, the remaining capacity of dst must not overlap plaintext.
+func (t *Transcript) Seal(label, dst, plaintext []byte) []byte {
+	ret, out := sliceForAppend(dst, len(plaintext)+MACSize)
+	ct, mac := out[:len(plaintext)], out[len(plaintext):]
+	copy(ct, plaintext)
+	t.frame(label, len(plaintext))
+	t.s.operate(opSendEnc, ct, false)
+	for i := range mac {
+		mac[i] = 0
+	}
+	t.s.operate(opSendMAC, mac, false)
+	return ret
+}
+
+// Open authenticates and decrypts ciphertext sealed by the other party and,
+// if successful, appends the resulting plaintext to dst, returning the
+// updated slice.
+//
+// If it fails, the transcript does not match the one of the other party
+// anymore and must be discarded. The contents of dst, up to its capacity,
+// may be overwritten.
+func (t *Transcript) Open(label, dst, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < MACSize {
+		return nil, ErrDecryption
+	}
+	n := len(ciphertext) - MACSize
+	var mac [MACSize]byte
+	copy(mac[:], ciphertext[n:])
+	ret, pt := sliceForAppend(dst, n)
+	copy(pt, ciphertext[:n])
+	t.frame(label, n)
+	t.s.operate(opRecvEnc, pt, false)
+	t.s.operate(opRecvMAC, mac[:], false)
+
+	var acc byte
+	for _, b := range mac {
+		acc |= b
+	}
+	if acc != 0 {
+		for i := range pt {
+			pt[i] = 0
+		}
+		return nil, ErrDecryption
+	}
+	return ret, nil
+}
+
+// Ratchet erases RatchetSize bytes of the state, so that it can not be
+// rolled back to recover the previous outputs, as long as a secret has been
+// absorbed with Key.
+func (t *Transcript) Ratchet(label []byte) {
+	var zero [RatchetSize]byte
+	t.frame(label, len(zero))
+	t.s.operate(opRatchet, zero[:], false)
+}
+
+// Clone returns a copy of the transcript in its current state, which can be
+// used to fork it.
+func (t *Transcript) Clone() *Transcript { c := *t; return &c }
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a
+// slice with the contents of the given slice followed by that many bytes and a
+// second slice that aliases into it and contains only the extra bytes. If the
+// original slice has sufficient capacity then no allocation is performed.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,159 @@
+package transcript_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/karalef/circl/internal/sha3"
+	"github.com/karalef/circl/internal/test"
+	"github.com/karalef/circl/transcript"
+)
+
+func TestMerlin(t *testing.T) {
+	// Test vectors of the Merlin reference implementation.
+	// https://github.com/dalek-cryptography/merlin
+	tr := transcript.New([]byte("test protocol"))
+	tr.AppendMessage([]byte("some label"), []byte("some data"))
+	got := make([]byte, 32)
+	tr.ChallengeBytes([]byte("challenge"), got)
+	want, _ := hex.DecodeString("d5a21972d0d5fe320c0d263fac7fffb8145aa640af6e9bca177c03c7efcf0615")
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+
+	tr = transcript.New([]byte("test protocol"))
+	tr.AppendMessage([]byte("step1"), []byte("some data"))
+	data := bytes.Repeat([]byte{99}, 1024)
+	for i := 0; i < 32; i++ {
+		tr.ChallengeBytes([]byte("challenge"), got)
+		tr.AppendMessage([]byte("bigdata"), data)
+		tr.AppendMessage([]byte("challengedata"), got)
+	}
+	want, _ = hex.DecodeString("a8c933f54fae76e3f9bea93648c1308e7dfa2152dd51674ff3ca438351cf003c")
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+}
+
+// session runs a keyed exchange between two parties and returns the
+// ciphertexts sealed by the initiator followed by the final challenge.
+func session(t *testing.T, newTranscript func([]byte) *transcript.Transcript) []byte {
+	a := newTranscript([]byte("test protocol"))
+	b := newTranscript([]byte("test protocol"))
+	var out []byte
+	for _, tr := range []*transcript.Transcript{a, b} {
+		tr.AppendMessage([]byte("hello"), []byte("some data"))
+		tr.Key([]byte("shared secret"), []byte("0123456789abcdef0123456789abcdef"))
+	}
+
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+	for i := 0; i < 3; i++ {
+		ct := a.Seal([]byte("request"), nil, msg)
+		out = append(out, ct...)
+		pt, err := b.Open([]byte("request"), nil, ct)
+		test.CheckNoErr(t, err, "failed to open request")
+		test.CheckOk(bytes.Equal(pt, msg), "request should match", t)
+
+		ct = b.Seal([]byte("response"), nil, msg[:i])
+		pt, err = a.Open([]byte("response"), nil, ct)
+		test.CheckNoErr(t, err, "failed to open response")
+		test.CheckOk(bytes.Equal(pt, msg[:i]), "response should match", t)
+
+		a.Ratchet([]byte("ratchet"))
+		b.Ratchet([]byte("ratchet"))
+	}
+
+	ca, cb := make([]byte, 32), make([]byte, 32)
+	a.ChallengeBytes([]byte("challenge"), ca)
+	b.ChallengeBytes([]byte("challenge"), cb)
+	test.CheckOk(bytes.Equal(ca, cb), "challenges should match", t)
+	return append(out, ca...)
+}
+
+func TestKeyed(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		new  func([]byte) *transcript.Transcript
+		want string
+	}{
+		{"Full", transcript.New, "a5dee769dcfca64e2ed92a524271c8923a35cd480e5617619793c35ae727031e"},
+		{"Turbo", transcript.NewTurbo, "67c3095231443903edea6e31128a94cb46a9ee278313c7c92efce401ec2d7f18"},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			got := sha3.Sum256(session(t, v.new))
+			want, _ := hex.DecodeString(v.want)
+			if !bytes.Equal(got[:], want) {
+				test.ReportError(t, got[:], want)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	a := transcript.New([]byte("test protocol"))
+	a.Key([]byte("key"), make([]byte, 32))
+	b := a.Clone()
+
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+	buf := append(make([]byte, 0, len(msg)+transcript.MACSize), msg...)
+	ct := a.Seal([]byte("label"), buf[:0], buf)
+	test.CheckOk(&ct[0] == &buf[0], "Seal should work in place", t)
+
+	for i := range ct {
+		c := b.Clone()
+		tampered := append([]byte{}, ct...)
+		tampered[i] ^= 1
+		_, err := c.Open([]byte("label"), nil, tampered)
+		test.CheckIsErr(t, err, "should fail due to tampered ciphertext")
+	}
+	_, err := b.Clone().Open([]byte("other label"), nil, ct)
+	test.CheckIsErr(t, err, "should fail due to wrong label")
+	_, err = b.Clone().Open([]byte("label"), nil, ct[:transcript.MACSize-1])
+	test.CheckIsErr(t, err, "should fail due to short ciphertext")
+
+	pt, err := b.Open([]byte("label"), ct[:0], ct)
+	test.CheckNoErr(t, err, "failed to open in place")
+	test.CheckOk(bytes.Equal(pt, msg), "plaintext should match", t)
+}
+
+func TestTranscript(t *testing.T) {
+	// The labels are framed with the length of the data, and the transcripts
+	// are separated by the protocol label and the permutation.
+	challenge := func(tr *transcript.Transcript, msgs ...string) []byte {
+		for i := 0; i+1 < len(msgs); i += 2 {
+			tr.AppendMessage([]byte(msgs[i]), []byte(msgs[i+1]))
+		}
+		out := make([]byte, 16)
+		tr.ChallengeBytes([]byte("challenge"), out)
+		return out
+	}
+	p := []byte("test protocol")
+	c0 := challenge(transcript.New(p), "ab", "c")
+	for _, c := range [][]byte{
+		challenge(transcript.New(p), "a", "bc"),
+		challenge(transcript.New(p), "ab", "c", "", ""),
+		challenge(transcript.New([]byte("other protocol")), "ab", "c"),
+		challenge(transcript.NewTurbo(p), "ab", "c"),
+	} {
+		test.CheckOk(!bytes.Equal(c, c0), "challenges should differ", t)
+	}
+	test.CheckOk(bytes.Equal(challenge(transcript.New(p), "ab", "c"), c0), "challenges should match", t)
+
+	// Ratcheting changes the state even without a key.
+	a := transcript.New(p)
+	b := a.Clone()
+	a.Ratchet([]byte("ratchet"))
+	test.CheckOk(!bytes.Equal(challenge(a), challenge(b)), "challenges should differ", t)
+}
+
+func BenchmarkTranscript(b *testing.B) {
+	msg := make([]byte, 1024)
+	var c [32]byte
+	b.SetBytes(int64(len(msg)))
+	for i := 0; i < b.N; i++ {
+		tr := transcript.New([]byte("benchmark"))
+		tr.AppendMessage([]byte("message"), msg)
+		tr.ChallengeBytes([]byte("challenge"), c[:])
+	}
+}
@@ -0,0 +1,299 @@
+package xof
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// blake2 is a BLAKE2b or BLAKE2s hash started from an arbitrary parameter
+// block, as needed by the nodes of BLAKE2X. The packages of
+// golang.org/x/crypto only start from the parameter block of a plain or
+// keyed hash.
+//
+// The words of BLAKE2s are held in the low half of h, and its 64-bit
+// counter in t[0].
+type blake2 struct {
+	p     *blake2xParams
+	h     [8]uint64
+	t     [2]uint64
+	block [128]byte
+	n     int // bytes buffered in block
+	size  int // digest size
+}
+
+var blake2Sigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// init starts the hash with the parameter block cfg, whose first byte is
+// the digest size.
+func (d *blake2) init(p *blake2xParams, cfg []byte) {
+	d.p = p
+	w := p.wordSize
+	for i := range d.h {
+		if w == 8 {
+			d.h[i] = p.iv[i] ^ binary.LittleEndian.Uint64(cfg[w*i:])
+		} else {
+			d.h[i] = p.iv[i] ^ uint64(binary.LittleEndian.Uint32(cfg[w*i:]))
+		}
+	}
+	d.t = [2]uint64{}
+	d.block = [128]byte{}
+	d.n = 0
+	d.size = int(cfg[0])
+}
+
+func (d *blake2) Write(p []byte) (int, error) {
+	n := len(p)
+	bs := d.p.blockSize()
+	for len(p) > 0 {
+		// The last block is kept until the hash is finalized.
+		if d.n == bs {
+			d.compress(d.block[:bs], bs, false)
+			d.n = 0
+		}
+		if d.n == 0 {
+			for len(p) > bs {
+				d.compress(p[:bs], bs, false)
+				p = p[bs:]
+			}
+		}
+		k := copy(d.block[d.n:bs], p)
+		d.n += k
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// Sum appends the digest to b without changing the state.
+func (d *blake2) Sum(b []byte) []byte {
+	c := *d
+	for i := c.n; i < len(c.block); i++ {
+		c.block[i] = 0
+	}
+	c.compress(c.block[:d.p.blockSize()], c.n, true)
+
+	var out [64]byte
+	w := d.p.wordSize
+	for i, v := range c.h {
+		if w == 8 {
+			binary.LittleEndian.PutUint64(out[w*i:], v)
+		} else {
+			binary.LittleEndian.PutUint32(out[w*i:], uint32(v))
+		}
+	}
+	return append(b, out[:d.size]...)
+}
+
+// compress adds the n bytes of the block to the counter and compresses it.
+func (d *blake2) compress(block []byte, n int, final bool) {
+	if d.p.wordSize == 8 {
+		d.t[0] += uint64(n)
+		if d.t[0] < uint64(n) {
+			d.t[1]++
+		}
+		blake2bCompress(&d.h, block, d.t, final)
+	} else {
+		d.t[0] += uint64(n)
+		blake2sCompress(&d.h, block, d.t[0], final)
+	}
+}
+
+// The compression functions keep the working vector in local variables,
+// as in the generic code of golang.org/x/crypto, which the compiler holds
+// in registers.
+
+func blake2bCompress(h *[8]uint64, block []byte, t [2]uint64, final bool) {
+	var m [16]uint64
+	_ = block[127]
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[8*i:])
+	}
+	iv := &blake2xbParams.iv
+	v0, v1, v2, v3, v4, v5, v6, v7 := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+	v8, v9, v10, v11, v12, v13, v14, v15 := iv[0], iv[1], iv[2], iv[3], iv[4], iv[5], iv[6], iv[7]
+	v12 ^= t[0]
+	v13 ^= t[1]
+	if final {
+		v14 = ^v14
+	}
+
+	for r := 0; r < 12; r++ {
+		s := &blake2Sigma[r%10]
+		v0 += v4 + m[s[0]]
+		v12 = bits.RotateLeft64(v12^v0, -32)
+		v8 += v12
+		v4 = bits.RotateLeft64(v4^v8, -24)
+		v0 += v4 + m[s[1]]
+		v12 = bits.RotateLeft64(v12^v0, -16)
+		v8 += v12
+		v4 = bits.RotateLeft64(v4^v8, -63)
+		v1 += v5 + m[s[2]]
+		v13 = bits.RotateLeft64(v13^v1, -32)
+		v9 += v13
+		v5 = bits.RotateLeft64(v5^v9, -24)
+		v1 += v5 + m[s[3]]
+		v13 = bits.RotateLeft64(v13^v1, -16)
+		v9 += v13
+		v5 = bits.RotateLeft64(v5^v9, -63)
+		v2 += v6 + m[s[4]]
+		v14 = bits.RotateLeft64(v14^v2, -32)
+		v10 += v14
+		v6 = bits.RotateLeft64(v6^v10, -24)
+		v2 += v6 + m[s[5]]
+		v14 = bits.RotateLeft64(v14^v2, -16)
+		v10 += v14
+		v6 = bits.RotateLeft64(v6^v10, -63)
+		v3 += v7 + m[s[6]]
+		v15 = bits.RotateLeft64(v15^v3, -32)
+		v11 += v15
+		v7 = bits.RotateLeft64(v7^v11, -24)
+		v3 += v7 + m[s[7]]
+		v15 = bits.RotateLeft64(v15^v3, -16)
+		v11 += v15
+		v7 = bits.RotateLeft64(v7^v11, -63)
+		v0 += v5 + m[s[8]]
+		v15 = bits.RotateLeft64(v15^v0, -32)
+		v10 += v15
+		v5 = bits.RotateLeft64(v5^v10, -24)
+		v0 += v5 + m[s[9]]
+		v15 = bits.RotateLeft64(v15^v0, -16)
+		v10 += v15
+		v5 = bits.RotateLeft64(v5^v10, -63)
+		v1 += v6 + m[s[10]]
+		v12 = bits.RotateLeft64(v12^v1, -32)
+		v11 += v12
+		v6 = bits.RotateLeft64(v6^v11, -24)
+		v1 += v6 + m[s[11]]
+		v12 = bits.RotateLeft64(v12^v1, -16)
+		v11 += v12
+		v6 = bits.RotateLeft64(v6^v11, -63)
+		v2 += v7 + m[s[12]]
+		v13 = bits.RotateLeft64(v13^v2, -32)
+		v8 += v13
+		v7 = bits.RotateLeft64(v7^v8, -24)
+		v2 += v7 + m[s[13]]
+		v13 = bits.RotateLeft64(v13^v2, -16)
+		v8 += v13
+		v7 = bits.RotateLeft64(v7^v8, -63)
+		v3 += v4 + m[s[14]]
+		v14 = bits.RotateLeft64(v14^v3, -32)
+		v9 += v14
+		v4 = bits.RotateLeft64(v4^v9, -24)
+		v3 += v4 + m[s[15]]
+		v14 = bits.RotateLeft64(v14^v3, -16)
+		v9 += v14
+		v4 = bits.RotateLeft64(v4^v9, -63)
+	}
+	h[0] ^= v0 ^ v8
+	h[1] ^= v1 ^ v9
+	h[2] ^= v2 ^ v10
+	h[3] ^= v3 ^ v11
+	h[4] ^= v4 ^ v12
+	h[5] ^= v5 ^ v13
+	h[6] ^= v6 ^ v14
+	h[7] ^= v7 ^ v15
+}
+
+func blake2sCompress(h *[8]uint64, block []byte, t uint64, final bool) {
+	var m [16]uint32
+	_ = block[63]
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+	iv := &blake2xsParams.iv
+	v0, v1, v2, v3 := uint32(h[0]), uint32(h[1]), uint32(h[2]), uint32(h[3])
+	v4, v5, v6, v7 := uint32(h[4]), uint32(h[5]), uint32(h[6]), uint32(h[7])
+	v8, v9, v10, v11 := uint32(iv[0]), uint32(iv[1]), uint32(iv[2]), uint32(iv[3])
+	v12, v13, v14, v15 := uint32(iv[4]), uint32(iv[5]), uint32(iv[6]), uint32(iv[7])
+	v12 ^= uint32(t)
+	v13 ^= uint32(t >> 32)
+	if final {
+		v14 = ^v14
+	}
+
+	for r := 0; r < 10; r++ {
+		s := &blake2Sigma[r]
+		v0 += v4 + m[s[0]]
+		v12 = bits.RotateLeft32(v12^v0, -16)
+		v8 += v12
+		v4 = bits.RotateLeft32(v4^v8, -12)
+		v0 += v4 + m[s[1]]
+		v12 = bits.RotateLeft32(v12^v0, -8)
+		v8 += v12
+		v4 = bits.RotateLeft32(v4^v8, -7)
+		v1 += v5 + m[s[2]]
+		v13 = bits.RotateLeft32(v13^v1, -16)
+		v9 += v13
+		v5 = bits.RotateLeft32(v5^v9, -12)
+		v1 += v5 + m[s[3]]
+		v13 = bits.RotateLeft32(v13^v1, -8)
+		v9 += v13
+		v5 = bits.RotateLeft32(v5^v9, -7)
+		v2 += v6 + m[s[4]]
+		v14 = bits.RotateLeft32(v14^v2, -16)
+		v10 += v14
+		v6 = bits.RotateLeft32(v6^v10, -12)
+		v2 += v6 + m[s[5]]
+		v14 = bits.RotateLeft32(v14^v2, -8)
+		v10 += v14
+		v6 = bits.RotateLeft32(v6^v10, -7)
+		v3 += v7 + m[s[6]]
+		v15 = bits.RotateLeft32(v15^v3, -16)
+		v11 += v15
+		v7 = bits.RotateLeft32(v7^v11, -12)
+		v3 += v7 + m[s[7]]
+		v15 = bits.RotateLeft32(v15^v3, -8)
+		v11 += v15
+		v7 = bits.RotateLeft32(v7^v11, -7)
+		v0 += v5 + m[s[8]]
+		v15 = bits.RotateLeft32(v15^v0, -16)
+		v10 += v15
+		v5 = bits.RotateLeft32(v5^v10, -12)
+		v0 += v5 + m[s[9]]
+		v15 = bits.RotateLeft32(v15^v0, -8)
+		v10 += v15
+		v5 = bits.RotateLeft32(v5^v10, -7)
+		v1 += v6 + m[s[10]]
+		v12 = bits.RotateLeft32(v12^v1, -16)
+		v11 += v12
+		v6 = bits.RotateLeft32(v6^v11, -12)
+		v1 += v6 + m[s[11]]
+		v12 = bits.RotateLeft32(v12^v1, -8)
+		v11 += v12
+		v6 = bits.RotateLeft32(v6^v11, -7)
+		v2 += v7 + m[s[12]]
+		v13 = bits.RotateLeft32(v13^v2, -16)
+		v8 += v13
+		v7 = bits.RotateLeft32(v7^v8, -12)
+		v2 += v7 + m[s[13]]
+		v13 = bits.RotateLeft32(v13^v2, -8)
+		v8 += v13
+		v7 = bits.RotateLeft32(v7^v8, -7)
+		v3 += v4 + m[s[14]]
+		v14 = bits.RotateLeft32(v14^v3, -16)
+		v9 += v14
+		v4 = bits.RotateLeft32(v4^v9, -12)
+		v3 += v4 + m[s[15]]
+		v14 = bits.RotateLeft32(v14^v3, -8)
+		v9 += v14
+		v4 = bits.RotateLeft32(v4^v9, -7)
+	}
+	h[0] = uint64(uint32(h[0]) ^ v0 ^ v8)
+	h[1] = uint64(uint32(h[1]) ^ v1 ^ v9)
+	h[2] = uint64(uint32(h[2]) ^ v2 ^ v10)
+	h[3] = uint64(uint32(h[3]) ^ v3 ^ v11)
+	h[4] = uint64(uint32(h[4]) ^ v4 ^ v12)
+	h[5] = uint64(uint32(h[5]) ^ v5 ^ v13)
+	h[6] = uint64(uint32(h[6]) ^ v6 ^ v14)
+	h[7] = uint64(uint32(h[7]) ^ v7 ^ v15)
+}
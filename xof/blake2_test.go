@@ -0,0 +1,73 @@
+package xof
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karalef/circl/internal/test"
+)
+
+func TestBlake2KAT(t *testing.T) {
+	for _, v := range []struct {
+		file string
+		p    *blake2xParams
+	}{
+		{"blake2b-kat.txt", &blake2xbParams},
+		{"blake2s-kat.txt", &blake2xsParams},
+	} {
+		f, err := os.Open(filepath.Join("testdata", v.file))
+		test.CheckNoErr(t, err, "open")
+
+		bs := v.p.blockSize()
+		cfg := make([]byte, 8*v.p.wordSize)
+		cfg[0] = byte(v.p.size)
+		cfg[1] = byte(v.p.size) // key length
+		cfg[2] = 1              // fanout
+		cfg[3] = 1              // depth
+		key := make([]byte, bs)
+		msg := make([]byte, 256)
+		for i := range msg {
+			msg[i] = byte(i)
+		}
+		copy(key[:v.p.size], msg)
+
+		n := 0
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			line := s.Text()
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+			want, err := hex.DecodeString(line)
+			test.CheckNoErr(t, err, "hex")
+
+			// The key is absorbed as a full block, then the message in one
+			// write and byte by byte.
+			var d blake2
+			d.init(v.p, cfg)
+			_, _ = d.Write(key)
+			_, _ = d.Write(msg[:n])
+			got := d.Sum(nil)
+			if string(got) != string(want) {
+				test.ReportError(t, hex.EncodeToString(got), line, v.file, n)
+			}
+			d.init(v.p, cfg)
+			_, _ = d.Write(key)
+			for i := 0; i < n; i++ {
+				_, _ = d.Write(msg[i : i+1])
+			}
+			got = d.Sum(nil)
+			if string(got) != string(want) {
+				test.ReportError(t, hex.EncodeToString(got), line, v.file, n)
+			}
+			n++
+		}
+		test.CheckNoErr(t, s.Err(), "read")
+		f.Close()
+		test.CheckOk(n == 256, "missing known answers in "+v.file, t)
+	}
+}
@@ -0,0 +1,271 @@
+package xof
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// blake2x implements BLAKE2Xb and BLAKE2Xs with an unknown output length,
+// producing the same output as golang.org/x/crypto. The XOFs of that module
+// can't be serialized, so this one is built on a local BLAKE2, whose state
+// is serialized along with the one of the XOF.
+type blake2x struct {
+	p         *blake2xParams
+	root      blake2 // hash of the input, until squeezing
+	h0        [64]byte
+	block     [64]byte
+	offset    int // bytes of block already read
+	node      uint32
+	remaining uint64
+	squeezing bool
+}
+
+type blake2xParams struct {
+	magic    string // of the serialized blake2x
+	size     int    // digest size of the root and inner hashes
+	wordSize int
+	iv       [8]uint64
+	xofLen   int // size of the XOF length parameter
+	innerLen int // offset of the inner hash length parameter
+}
+
+var (
+	blake2xbParams = blake2xParams{
+		magic:    "b2xb\x02",
+		size:     64,
+		wordSize: 8,
+		iv: [8]uint64{
+			0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+			0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+		},
+		xofLen:   4,
+		innerLen: 17,
+	}
+	blake2xsParams = blake2xParams{
+		magic:    "b2xs\x02",
+		size:     32,
+		wordSize: 4,
+		iv: [8]uint64{
+			0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+			0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+		},
+		xofLen:   2,
+		innerLen: 15,
+	}
+)
+
+var (
+	errBlake2xState    = errors.New("xof: invalid BLAKE2X state")
+	errBlake2xIdentity = errors.New("xof: invalid BLAKE2X state identifier")
+)
+
+func newBlake2x(p *blake2xParams) *blake2x {
+	x := &blake2x{p: p}
+	x.Reset()
+	return x
+}
+
+// blockSize is the size of the block of the BLAKE2 hash.
+func (p *blake2xParams) blockSize() int { return 16 * p.wordSize }
+
+// hashMarshaledSize is the size of the serialized root hash: state words,
+// counter, block and the number of bytes buffered in the block.
+func (p *blake2xParams) hashMarshaledSize() int {
+	return 8*p.wordSize + 2*p.wordSize + p.blockSize() + 1
+}
+
+// maxOutput is the limit of the output for an unknown output length.
+func (p *blake2xParams) maxOutput() uint64 { return uint64(p.size) << 32 }
+
+// rootConfig returns the parameter block of the root node.
+func (p *blake2xParams) rootConfig() []byte {
+	cfg := p.config()
+	cfg[0] = byte(p.size)
+	cfg[2] = 1 // fanout
+	cfg[3] = 1 // depth
+	return cfg
+}
+
+// nodeConfig returns the parameter block of the output node of the given
+// offset and digest size.
+func (p *blake2xParams) nodeConfig(node uint32, size int) []byte {
+	cfg := p.config()
+	cfg[0] = byte(size)
+	binary.LittleEndian.PutUint32(cfg[4:], uint32(p.size)) // leaf length
+	binary.LittleEndian.PutUint32(cfg[8:], node)
+	cfg[p.innerLen] = byte(p.size)
+	return cfg
+}
+
+// config returns a parameter block with the unknown output length set.
+func (p *blake2xParams) config() []byte {
+	cfg := make([]byte, 8*p.wordSize)
+	for i := 12; i < 12+p.xofLen; i++ {
+		cfg[i] = 0xff
+	}
+	return cfg
+}
+
+func (x *blake2x) Write(p []byte) (int, error) {
+	if x.squeezing {
+		panic("xof: write to BLAKE2X after read")
+	}
+	return x.root.Write(p)
+}
+
+func (x *blake2x) Read(p []byte) (n int, err error) {
+	size := x.p.size
+	if !x.squeezing {
+		x.root.Sum(x.h0[:0])
+		x.squeezing = true
+		x.offset = size
+	}
+	if x.remaining == 0 {
+		return 0, io.EOF
+	}
+
+	n = len(p)
+	if uint64(n) > x.remaining {
+		n = int(x.remaining)
+		p = p[:n]
+	}
+	for len(p) > 0 {
+		if x.offset == size {
+			s := size
+			if x.remaining < uint64(s) {
+				s = int(x.remaining)
+			}
+			var h blake2
+			h.init(x.p, x.p.nodeConfig(x.node, s))
+			_, _ = h.Write(x.h0[:size])
+			h.Sum(x.block[:0])
+			x.node++
+			x.offset = 0
+		}
+		k := copy(p, x.block[x.offset:size])
+		x.offset += k
+		x.remaining -= uint64(k)
+		p = p[k:]
+	}
+	return n, nil
+}
+
+func (x *blake2x) Reset() {
+	x.root.init(x.p, x.p.rootConfig())
+	x.h0 = [64]byte{}
+	x.block = [64]byte{}
+	x.offset = 0
+	x.node = 0
+	x.remaining = x.p.maxOutput()
+	x.squeezing = false
+}
+
+func (x *blake2x) Clone() XOF {
+	c := *x
+	return &c
+}
+
+// The marshalled state is
+//
+//	magic ‖ flags ‖ h ‖ t ‖ buf ‖ n ‖ h0 ‖ block ‖ offset ‖ node ‖ remaining
+//
+// where h, t, buf and n are the state words, counter, block and number of
+// bytes buffered in the block of the BLAKE2 hash of the input, h0 and block
+// are digest-sized, flags, n and offset are single bytes, and the integers
+// are encoded in little-endian order. The counter takes two words, node 4
+// bytes and remaining 8 bytes. Bit 0 of flags is set when squeezing.
+
+func (x *blake2x) marshaledSize() int {
+	return len(x.p.magic) + 1 + x.p.hashMarshaledSize() + 2*x.p.size + 1 + 4 + 8
+}
+
+// MarshalBinary encodes the state, including buffered input or output.
+func (x *blake2x) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(make([]byte, 0, x.marshaledSize()))
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (x *blake2x) AppendBinary(b []byte) ([]byte, error) {
+	p := x.p
+	var flags byte
+	if x.squeezing {
+		flags = 1
+	}
+	b = append(b, p.magic...)
+	b = append(b, flags)
+	for _, v := range x.root.h {
+		if p.wordSize == 8 {
+			b = binary.LittleEndian.AppendUint64(b, v)
+		} else {
+			b = binary.LittleEndian.AppendUint32(b, uint32(v))
+		}
+	}
+	b = binary.LittleEndian.AppendUint64(b, x.root.t[0])
+	if p.wordSize == 8 {
+		b = binary.LittleEndian.AppendUint64(b, x.root.t[1])
+	}
+	b = append(b, x.root.block[:p.blockSize()]...)
+	b = append(b, byte(x.root.n))
+	b = append(b, x.h0[:p.size]...)
+	b = append(b, x.block[:p.size]...)
+	b = append(b, byte(x.offset))
+	b = binary.LittleEndian.AppendUint32(b, x.node)
+	b = binary.LittleEndian.AppendUint64(b, x.remaining)
+	return b, nil
+}
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary.
+func (x *blake2x) UnmarshalBinary(b []byte) error {
+	p := x.p
+	if len(b) < len(p.magic) || string(b[:len(p.magic)]) != p.magic {
+		return errBlake2xIdentity
+	}
+	if len(b) != x.marshaledSize() {
+		return errBlake2xState
+	}
+	b = b[len(p.magic):]
+
+	flags := b[0]
+	b = b[1:]
+	var root blake2
+	root.init(p, p.rootConfig())
+	w := p.wordSize
+	for i := range root.h {
+		if w == 8 {
+			root.h[i] = binary.LittleEndian.Uint64(b[w*i:])
+		} else {
+			root.h[i] = uint64(binary.LittleEndian.Uint32(b[w*i:]))
+		}
+	}
+	b = b[8*w:]
+	root.t[0] = binary.LittleEndian.Uint64(b)
+	if w == 8 {
+		root.t[1] = binary.LittleEndian.Uint64(b[8:])
+	}
+	b = b[2*w:]
+	copy(root.block[:], b[:p.blockSize()])
+	root.n = int(b[p.blockSize()])
+	b = b[p.blockSize()+1:]
+
+	var h0, block [64]byte
+	copy(h0[:], b[:p.size])
+	copy(block[:], b[p.size:2*p.size])
+	b = b[2*p.size:]
+	offset := int(b[0])
+	node := binary.LittleEndian.Uint32(b[1:])
+	remaining := binary.LittleEndian.Uint64(b[5:])
+	if flags&^1 != 0 || root.n > p.blockSize() || offset > p.size || remaining > p.maxOutput() {
+		return errBlake2xState
+	}
+
+	x.root = root
+	x.h0 = h0
+	x.block = block
+	x.offset = offset
+	x.node = node
+	x.remaining = remaining
+	x.squeezing = flags&1 != 0
+	return nil
+}
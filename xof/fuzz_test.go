@@ -0,0 +1,42 @@
+package xof_test
+
+import (
+	"bytes"
+	"encoding"
+	"testing"
+)
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	msg := make([]byte, 9000)
+	out := make([]byte, 200)
+	for i, id := range allIDs {
+		for _, n := range []int{0, 1, 100, 8193} {
+			x := id.New()
+			_, _ = x.Write(msg[:n])
+			b, _ := x.(encoding.BinaryMarshaler).MarshalBinary()
+			f.Add(uint8(i), b)
+			_, _ = x.Read(out[:n%200])
+			b, _ = x.(encoding.BinaryMarshaler).MarshalBinary()
+			f.Add(uint8(i), b)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, i uint8, data []byte) {
+		id := allIDs[int(i)%len(allIDs)]
+		x := id.New()
+		if x.(encoding.BinaryUnmarshaler).UnmarshalBinary(data) != nil {
+			return
+		}
+		b, err := x.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil || !bytes.Equal(b, data) {
+			t.Fatalf("%v: UnmarshalBinary(%x) marshals to %x", id, data, b)
+		}
+
+		// The restored state is usable.
+		out := make([]byte, 300)
+		_, _ = x.Clone().Read(out)
+		x.Reset()
+		_, _ = x.Write(out)
+		_, _ = x.Read(out)
+	})
+}
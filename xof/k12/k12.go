@@ -1,13 +1,21 @@
-// k12 implements the KangarooTwelve XOF.
+// k12 implements the KangarooTwelve XOFs KT128 and KT256.
 //
-// KangarooTwelve is being standardised at the CFRG working group
-// of the IRTF. This package implements draft 10.
+// KT128 and KT256 are defined in RFC 9861. KT128 is the function called
+// KangarooTwelve in draft 10 of the CFRG document, which is still provided
+// under that name for compatibility.
 //
+// The hashes of the chunks of the input are computed with parallel
+// permutations when the CPU supports them and, for the instances returned
+// by NewKT128Parallel and NewKT256Parallel, on several goroutines.
+//
+// https://www.rfc-editor.org/rfc/rfc9861
 // https://datatracker.ietf.org/doc/draft-irtf-cfrg-kangarootwelve/10/
 package k12
 
 import (
 	"encoding/binary"
+	"runtime"
+	"sync"
 
 	"github.com/karalef/circl/internal/sha3"
 	"github.com/karalef/circl/simd/keccakf1600"
@@ -16,9 +24,10 @@ import (
 const chunkSize = 8192 // aka B
 
 // KangarooTwelve splits the message into chunks of 8192 bytes each.
-// The first chunk is absorbed directly in a TurboSHAKE128 instance, which
-// we call the stalk. The subsequent chunks aren't absorbed directly, but
-// instead their hash is absorbed: they're like leafs on a stalk.
+// The first chunk is absorbed directly in a TurboSHAKE instance, which
+// we call the stalk. KT128 uses TurboSHAKE128 and KT256 TurboSHAKE256.
+// The subsequent chunks aren't absorbed directly, but instead their hash
+// is absorbed: they're like leafs on a stalk.
 // If we have a fast TurboSHAKE128 available, we buffer chunks until we have
 // enough to do the parallel TurboSHAKE128. If not, we absorb directly into
 // a separate TurboSHAKE128 state.
@@ -46,35 +55,99 @@ type State struct {
 	leaf *sha3.State
 
 	lanes uint8 // number of TurboSHAKE128s to compute in parallel
+
+	cvSize int // size of the chunk hashes: 32 for KT128 and 64 for KT256
+
+	// Number of goroutines computing the chunk hashes, each on lanes chunks
+	// at a time. The state is sequential if it is at most 1.
+	workers int
+}
+
+// NewKT128 creates a new instance of KT128 with customization string c.
+func NewKT128(c []byte) State { return newKT128(c, defaultLanes()) }
+
+// NewKT256 creates a new instance of KT256 with customization string c.
+func NewKT256(c []byte) State { return newKT256(c, defaultLanes()) }
+
+// NewKT128Parallel creates a new instance of KT128 with customization string
+// c, which computes the hashes of the chunks of large inputs on up to workers
+// goroutines. If workers is not positive, runtime.GOMAXPROCS(0) is used.
+//
+// The output is the same as that of NewKT128. The input is buffered until
+// enough chunks are available to keep all the goroutines busy, which takes
+// 8 KiB per chunk, times the number of lanes and workers.
+func NewKT128Parallel(c []byte, workers int) State {
+	s := NewKT128(c)
+	s.workers = numWorkers(workers)
+	return s
+}
+
+// NewKT256Parallel is like NewKT128Parallel, but for KT256.
+func NewKT256Parallel(c []byte, workers int) State {
+	s := NewKT256(c)
+	s.workers = numWorkers(workers)
+	return s
+}
+
+func numWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
 }
 
 // NewDraft10 creates a new instance of Kangaroo12 draft version -10.
-func NewDraft10(c []byte) State {
+// It is equivalent to KT128.
+func NewDraft10(c []byte) State { return NewKT128(c) }
+
+func defaultLanes() byte {
 	var lanes byte = 1
 
-	if keccakf1600.IsEnabledX4() {
+	if keccakf1600.IsEnabledX8() {
+		lanes = 8
+	} else if keccakf1600.IsEnabledX4() {
 		lanes = 4
 	} else if keccakf1600.IsEnabledX2() {
 		lanes = 2
 	}
 
-	return newDraft10(c, lanes)
+	return lanes
 }
 
-func newDraft10(c []byte, lanes byte) State {
+func newKT128(c []byte, lanes byte) State {
 	return State{
 		initialTodo: chunkSize,
 		stalk:       sha3.NewTurboShake128(0x07),
 		context:     c,
 		lanes:       lanes,
+		cvSize:      32,
 	}
 }
 
+func newKT256(c []byte, lanes byte) State {
+	return State{
+		initialTodo: chunkSize,
+		stalk:       sha3.NewTurboShake256(0x07),
+		context:     c,
+		lanes:       lanes,
+		cvSize:      64,
+	}
+}
+
+// newLeaf returns the TurboSHAKE instance used to compute the chunk hashes.
+func (s *State) newLeaf() sha3.State {
+	if s.cvSize == 64 {
+		return sha3.NewTurboShake256(0x0B)
+	}
+	return sha3.NewTurboShake128(0x0B)
+}
+
 func (s *State) Reset() {
 	s.initialTodo = chunkSize
 	s.stalk.Reset()
 	s.stalk.SwitchDS(0x07)
 	s.buf = nil
+	s.leaf = nil
 	s.offset = 0
 	s.chunk = 0
 }
@@ -88,6 +161,8 @@ func (s *State) Clone() State {
 		offset:      s.offset,
 		chunk:       s.chunk,
 		lanes:       s.lanes,
+		cvSize:      s.cvSize,
+		workers:     s.workers,
 	}
 
 	if s.leaf != nil {
@@ -102,13 +177,27 @@ func (s *State) Clone() State {
 	return ret
 }
 
-func Draft10Sum(hash []byte, msg []byte, c []byte) {
+// KT128Sum writes the KT128 digest of msg with customization string c
+// into hash.
+func KT128Sum(hash []byte, msg []byte, c []byte) {
 	// TODO Tweak number of lanes depending on the length of the message
-	s := NewDraft10(c)
+	s := NewKT128(c)
 	_, _ = s.Write(msg)
 	_, _ = s.Read(hash)
 }
 
+// KT256Sum writes the KT256 digest of msg with customization string c
+// into hash.
+func KT256Sum(hash []byte, msg []byte, c []byte) {
+	s := NewKT256(c)
+	_, _ = s.Write(msg)
+	_, _ = s.Read(hash)
+}
+
+// Draft10Sum writes the Kangaroo12 draft version -10 digest of msg with
+// customization string c into hash. It is equivalent to KT128Sum.
+func Draft10Sum(hash []byte, msg []byte, c []byte) { KT128Sum(hash, msg, c) }
+
 func (s *State) Write(p []byte) (int, error) {
 	written := len(p)
 
@@ -131,13 +220,13 @@ func (s *State) Write(p []byte) (int, error) {
 	// If this is the first bit of data written after the initial chunk,
 	// we're out of the fast-path and allocate some buffers.
 	if s.buf == nil {
-		if s.lanes != 1 {
-			s.buf = make([]byte, int(s.lanes)*chunkSize)
+		if s.lanes != 1 || s.workers > 1 {
+			s.buf = make([]byte, s.bufSize())
 		} else {
 			// We create the buffer to signal we're past the first chunk,
 			// but do not use it.
 			s.buf = make([]byte, 0)
-			h := sha3.NewTurboShake128(0x0B)
+			h := s.newLeaf()
 			s.leaf = &h
 		}
 		_, _ = s.stalk.Write([]byte{0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
@@ -146,7 +235,7 @@ func (s *State) Write(p []byte) (int, error) {
 
 	// If we're just using one lane, we don't need to cache in a buffer
 	// for parallel hashing. Instead, we feed directly to TurboSHAKE.
-	if s.lanes == 1 {
+	if s.leaf != nil {
 		for len(p) > 0 {
 			// Write to current leaf.
 			to := chunkSize - s.offset
@@ -159,9 +248,9 @@ func (s *State) Write(p []byte) (int, error) {
 
 			// Did we fill the chunk?
 			if s.offset == chunkSize {
-				var cv [32]byte
-				_, _ = s.leaf.Read(cv[:])
-				_, _ = s.stalk.Write(cv[:])
+				var cv [64]byte
+				_, _ = s.leaf.Read(cv[:s.cvSize])
+				_, _ = s.stalk.Write(cv[:s.cvSize])
 				s.leaf.Reset()
 				s.offset = 0
 				s.chunk++
@@ -196,7 +285,7 @@ func (s *State) Write(p []byte) (int, error) {
 	}
 
 	// Absorb a bunch of chunks at the same time.
-	if len(p) >= int(s.lanes)*chunkSize {
+	if len(p) >= len(s.buf) {
 		p = s.writeX(p)
 	}
 
@@ -209,24 +298,147 @@ func (s *State) Write(p []byte) (int, error) {
 	return written, nil
 }
 
-// Absorb a multiple of a multiple of lanes * chunkSize.
+// bufSize returns the size of the buffer of the input that is absorbed in
+// parallel: lanes chunks for each worker.
+func (s *State) bufSize() int {
+	n := int(s.lanes) * chunkSize
+	if s.workers > 1 {
+		n *= s.workers
+	}
+	return n
+}
+
+// Absorb a multiple of lanes * chunkSize.
 // Returns the remainder.
 func (s *State) writeX(p []byte) []byte {
+	unit := int(s.lanes) * chunkSize
+	if s.workers > 1 && len(p) >= 2*unit {
+		return s.writeParallel(p)
+	}
+
+	var cvs [8 * 64]byte
+	cv := int(s.lanes) * s.cvSize
+	for ; len(p) >= unit; p = p[unit:] {
+		s.leaves(p[:unit], cvs[:cv])
+		_, _ = s.stalk.Write(cvs[:cv])
+		s.chunk += uint(s.lanes)
+	}
+	return p
+}
+
+// writeParallel is like writeX, but distributes the chunks among the
+// workers.
+func (s *State) writeParallel(p []byte) []byte {
+	unit := int(s.lanes) * chunkSize
+	cvUnit := int(s.lanes) * s.cvSize
+	n := len(p) / unit
+	workers := s.workers
+	if workers > n {
+		workers = n
+	}
+
+	cvs := make([]byte, n*cvUnit)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		lo, hi := n*w/workers, n*(w+1)/workers
+		go func() {
+			defer wg.Done()
+			s.leaves(p[lo*unit:hi*unit], cvs[lo*cvUnit:hi*cvUnit])
+		}()
+	}
+	wg.Wait()
+
+	_, _ = s.stalk.Write(cvs)
+	s.chunk += uint(n) * uint(s.lanes)
+	return p[n*unit:]
+}
+
+// leaves writes the hashes of the chunks of p, whose length is a multiple of
+// lanes * chunkSize, to cvs. It is safe to call concurrently.
+func (s *State) leaves(p, cvs []byte) {
 	switch s.lanes {
+	case 8:
+		s.leavesX8(p, cvs)
 	case 4:
-		return s.writeX4(p)
+		s.leavesX4(p, cvs)
+	case 2:
+		s.leavesX2(p, cvs)
 	default:
-		return s.writeX2(p)
+		for ; len(p) > 0; p = p[chunkSize:] {
+			h := s.newLeaf()
+			_, _ = h.Write(p[:chunkSize])
+			_, _ = h.Read(cvs[:s.cvSize])
+			cvs = cvs[s.cvSize:]
+		}
 	}
 }
 
-func (s *State) writeX4(p []byte) []byte {
+// The chunk hashes are computed on full chunks only, so the layout of the
+// leaves is fixed: chunkSize/rate full blocks followed by a tail of
+// (chunkSize%rate)/8 words, which is 16 words for KT128 and 4 for KT256.
+
+func (s *State) leavesX8(p, cvs []byte) {
+	rate := s.stalk.BlockSize()
+	words := rate / 8
+	full := chunkSize / rate * rate
+	tail := (chunkSize - full) / 8
+
+	for len(p) >= 8*chunkSize {
+		var x8 keccakf1600.StateX8
+		a := x8.Initialize(true)
+
+		for offset := 0; offset < full; offset += rate {
+			for i := 0; i < words; i++ {
+				for j := 0; j < 8; j++ {
+					a[i*8+j] ^= binary.LittleEndian.Uint64(
+						p[chunkSize*j+8*i+offset:],
+					)
+				}
+			}
+
+			x8.Permute()
+		}
+
+		for i := 0; i < tail; i++ {
+			for j := 0; j < 8; j++ {
+				a[i*8+j] ^= binary.LittleEndian.Uint64(
+					p[chunkSize*j+8*i+full:],
+				)
+			}
+		}
+
+		for j := 0; j < 8; j++ {
+			a[tail*8+j] ^= 0x0b
+			a[(words-1)*8+j] ^= 0x80 << 56
+		}
+
+		x8.Permute()
+
+		cv := s.cvSize
+		for i := 0; i < cv/8; i++ {
+			for j := 0; j < 8; j++ {
+				binary.LittleEndian.PutUint64(cvs[cv*j+8*i:], a[8*i+j])
+			}
+		}
+
+		p = p[chunkSize*8:]
+		cvs = cvs[cv*8:]
+	}
+}
+
+func (s *State) leavesX4(p, cvs []byte) {
+	rate := s.stalk.BlockSize()
+	words := rate / 8
+	full := chunkSize / rate * rate
+	tail := (chunkSize - full) / 8
+
 	for len(p) >= 4*chunkSize {
 		var x4 keccakf1600.StateX4
 		a := x4.Initialize(true)
 
-		for offset := 0; offset < 48*168; offset += 168 {
-			for i := 0; i < 21; i++ {
+		for offset := 0; offset < full; offset += rate {
+			for i := 0; i < words; i++ {
 				a[i*4] ^= binary.LittleEndian.Uint64(
 					p[8*i+offset:],
 				)
@@ -244,58 +456,60 @@ func (s *State) writeX4(p []byte) []byte {
 			x4.Permute()
 		}
 
-		for i := 0; i < 16; i++ {
+		for i := 0; i < tail; i++ {
 			a[i*4] ^= binary.LittleEndian.Uint64(
-				p[8*i+48*168:],
+				p[8*i+full:],
 			)
 			a[i*4+1] ^= binary.LittleEndian.Uint64(
-				p[chunkSize+8*i+48*168:],
+				p[chunkSize+8*i+full:],
 			)
 			a[i*4+2] ^= binary.LittleEndian.Uint64(
-				p[chunkSize*2+8*i+48*168:],
+				p[chunkSize*2+8*i+full:],
 			)
 			a[i*4+3] ^= binary.LittleEndian.Uint64(
-				p[chunkSize*3+8*i+48*168:],
+				p[chunkSize*3+8*i+full:],
 			)
 		}
 
-		a[16*4] ^= 0x0b
-		a[16*4+1] ^= 0x0b
-		a[16*4+2] ^= 0x0b
-		a[16*4+3] ^= 0x0b
-		a[20*4] ^= 0x80 << 56
-		a[20*4+1] ^= 0x80 << 56
-		a[20*4+2] ^= 0x80 << 56
-		a[20*4+3] ^= 0x80 << 56
+		a[tail*4] ^= 0x0b
+		a[tail*4+1] ^= 0x0b
+		a[tail*4+2] ^= 0x0b
+		a[tail*4+3] ^= 0x0b
+		a[(words-1)*4] ^= 0x80 << 56
+		a[(words-1)*4+1] ^= 0x80 << 56
+		a[(words-1)*4+2] ^= 0x80 << 56
+		a[(words-1)*4+3] ^= 0x80 << 56
 
 		x4.Permute()
 
-		var buf [32 * 4]byte
-		for i := 0; i < 4; i++ {
-			binary.LittleEndian.PutUint64(buf[8*i:], a[4*i])
-			binary.LittleEndian.PutUint64(buf[32+8*i:], a[4*i+1])
-			binary.LittleEndian.PutUint64(buf[32*2+8*i:], a[4*i+2])
-			binary.LittleEndian.PutUint64(buf[32*3+8*i:], a[4*i+3])
+		cv := s.cvSize
+		for i := 0; i < cv/8; i++ {
+			binary.LittleEndian.PutUint64(cvs[8*i:], a[4*i])
+			binary.LittleEndian.PutUint64(cvs[cv+8*i:], a[4*i+1])
+			binary.LittleEndian.PutUint64(cvs[cv*2+8*i:], a[4*i+2])
+			binary.LittleEndian.PutUint64(cvs[cv*3+8*i:], a[4*i+3])
 		}
 
-		_, _ = s.stalk.Write(buf[:])
 		p = p[chunkSize*4:]
-		s.chunk += 4
+		cvs = cvs[cv*4:]
 	}
-
-	return p
 }
 
-func (s *State) writeX2(p []byte) []byte {
+func (s *State) leavesX2(p, cvs []byte) {
 	// TODO On M2 Pro, 1/3 of the time is spent on this function
 	// and LittleEndian.Uint64 excluding the actual permutation.
 	// Rewriting in assembler might be worthwhile.
+	rate := s.stalk.BlockSize()
+	words := rate / 8
+	full := chunkSize / rate * rate
+	tail := (chunkSize - full) / 8
+
 	for len(p) >= 2*chunkSize {
 		var x2 keccakf1600.StateX2
 		a := x2.Initialize(true)
 
-		for offset := 0; offset < 48*168; offset += 168 {
-			for i := 0; i < 21; i++ {
+		for offset := 0; offset < full; offset += rate {
+			for i := 0; i < words; i++ {
 				a[i*2] ^= binary.LittleEndian.Uint64(
 					p[8*i+offset:],
 				)
@@ -307,34 +521,31 @@ func (s *State) writeX2(p []byte) []byte {
 			x2.Permute()
 		}
 
-		for i := 0; i < 16; i++ {
+		for i := 0; i < tail; i++ {
 			a[i*2] ^= binary.LittleEndian.Uint64(
-				p[8*i+48*168:],
+				p[8*i+full:],
 			)
 			a[i*2+1] ^= binary.LittleEndian.Uint64(
-				p[chunkSize+8*i+48*168:],
+				p[chunkSize+8*i+full:],
 			)
 		}
 
-		a[16*2] ^= 0x0b
-		a[16*2+1] ^= 0x0b
-		a[20*2] ^= 0x80 << 56
-		a[20*2+1] ^= 0x80 << 56
+		a[tail*2] ^= 0x0b
+		a[tail*2+1] ^= 0x0b
+		a[(words-1)*2] ^= 0x80 << 56
+		a[(words-1)*2+1] ^= 0x80 << 56
 
 		x2.Permute()
 
-		var buf [32 * 2]byte
-		for i := 0; i < 4; i++ {
-			binary.LittleEndian.PutUint64(buf[8*i:], a[2*i])
-			binary.LittleEndian.PutUint64(buf[32+8*i:], a[2*i+1])
+		cv := s.cvSize
+		for i := 0; i < cv/8; i++ {
+			binary.LittleEndian.PutUint64(cvs[8*i:], a[2*i])
+			binary.LittleEndian.PutUint64(cvs[cv+8*i:], a[2*i+1])
 		}
 
-		_, _ = s.stalk.Write(buf[:])
 		p = p[chunkSize*2:]
-		s.chunk += 2
+		cvs = cvs[cv*2:]
 	}
-
-	return p
 }
 
 func (s *State) Read(p []byte) (int, error) {
@@ -358,24 +569,24 @@ func (s *State) Read(p []byte) (int, error) {
 		// We need to write the chunk number if we're past the first chunk.
 		if s.buf != nil {
 			// Write last remaining chunk(s)
-			var cv [32]byte
-			if s.lanes == 1 {
+			var cv [64]byte
+			if s.leaf != nil {
 				if s.offset != 0 {
-					_, _ = s.leaf.Read(cv[:])
-					_, _ = s.stalk.Write(cv[:])
+					_, _ = s.leaf.Read(cv[:s.cvSize])
+					_, _ = s.stalk.Write(cv[:s.cvSize])
 					s.chunk++
 				}
 			} else {
-				remainingBuf := s.buf[:s.offset]
+				remainingBuf := s.writeX(s.buf[:s.offset])
 				for len(remainingBuf) > 0 {
-					h := sha3.NewTurboShake128(0x0B)
+					h := s.newLeaf()
 					to := chunkSize
 					if len(remainingBuf) < to {
 						to = len(remainingBuf)
 					}
 					_, _ = h.Write(remainingBuf[:to])
-					_, _ = h.Read(cv[:])
-					_, _ = s.stalk.Write(cv[:])
+					_, _ = h.Read(cv[:s.cvSize])
+					_, _ = s.stalk.Write(cv[:s.cvSize])
 					s.chunk++
 					remainingBuf = remainingBuf[to:]
 				}
@@ -1,7 +1,9 @@
 package k12
 
 import (
+	"bytes"
 	"encoding/hex"
+	"fmt"
 	"testing"
 )
 
@@ -16,8 +18,13 @@ func ptn(n int) []byte {
 }
 
 func testK12(t *testing.T, msg []byte, c []byte, l int, want string) {
-	do := func(lanes byte, writeSize int) {
-		h := newDraft10(c, lanes)
+	testKT(t, newKT128, msg, c, l, want)
+}
+
+func testKT(t *testing.T, newKT func([]byte, byte) State, msg []byte, c []byte, l int, want string) {
+	do := func(lanes byte, workers, writeSize int, restore bool) {
+		h := newKT(c, lanes)
+		h.workers = workers
 		msg2 := msg
 		for len(msg2) > 0 {
 			to := writeSize
@@ -26,18 +33,34 @@ func testK12(t *testing.T, msg []byte, c []byte, l int, want string) {
 			}
 			_, _ = h.Write(msg2[:to])
 			msg2 = msg2[to:]
+
+			// Restore the state from its encoding between the writes.
+			if restore {
+				b, err := h.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+				h = State{}
+				if err = h.UnmarshalBinary(b); err != nil {
+					t.Fatal(err)
+				}
+			}
 		}
 		buf := make([]byte, l)
 		_, _ = h.Read(buf)
 		got := hex.EncodeToString(buf)
 		if want != got {
-			t.Fatalf("%s != %s (lanes=%d, writeSize=%d )", want, got, lanes, writeSize)
+			t.Fatalf("%s != %s (lanes=%d, workers=%d, writeSize=%d, restore=%v)",
+				want, got, lanes, workers, writeSize, restore)
 		}
 	}
 
-	for _, lanes := range []byte{1, 2, 4} {
+	for _, lanes := range []byte{1, 2, 4, 8} {
 		for _, writeSize := range []int{7919, 1024, 8 * 1024} {
-			do(lanes, writeSize)
+			do(lanes, 1, writeSize, false)
+			do(lanes, 1, writeSize, true)
+			do(lanes, 3, writeSize, false)
+			do(lanes, 3, writeSize, true)
 		}
 	}
 }
@@ -71,6 +94,52 @@ func TestK12(t *testing.T) {
 	testK12(t, ptn(3*chunkSize+1), []byte{}, 16, "38cb940999aca742d69dd79298c6051c")
 }
 
+func TestKT256(t *testing.T) {
+	// RFC 9861 §5
+	testKT(t, newKT256, []byte{}, []byte{}, 64, "b23d2e9cea9f4904e02bec06817fc10ce38ce8e93ef4c89e6537076af8646404e3e8b68107b8833a5d30490aa33482353fd4adc7148ecb782855003aaebde4a9")
+	testKT(t, newKT256, ptn(1), []byte{}, 64, "0d005a194085360217128cf17f91e1f71314efa5564539d444912e3437efa17f82db6f6ffe76e781eaa068bce01f2bbf81eacb983d7230f2fb02834a21b1ddd0")
+	testKT(t, newKT256, ptn(17), []byte{}, 64, "1ba3c02b1fc514474f06c8979978a9056c8483f4a1b63d0dccefe3a28a2f323e1cdcca40ebf006ac76ef0397152346837b1277d3e7faa9c9653b19075098527b")
+
+	// Multi-chunk messages, which exercise the parallel leaf hashing.
+	testKT(t, newKT256, ptn(17*17*17*17), []byte{}, 64, "b06275d284cd1cf205bcbe57dccd3ec1ff6686e3ed15776383e1f2fa3c6ac8f08bf8a162829db1a44b2a43ff83dd89c3cf1ceb61ede659766d5ccf817a62ba8d")
+
+	// Self-generated regression vector, not from RFC 9861: a message just
+	// over three chunks with a customization string.
+	testKT(t, newKT256, ptn(3*chunkSize+1), ptn(41), 16, "44a06031067ea6c49c252d9e01ed4ca3")
+}
+
+func TestParallel(t *testing.T) {
+	msg := ptn(100*chunkSize + 1234)
+	for _, v := range []struct {
+		new func([]byte, int) State
+		sum func([]byte, []byte, []byte)
+	}{
+		{NewKT128Parallel, KT128Sum},
+		{NewKT256Parallel, KT256Sum},
+	} {
+		want := make([]byte, 64)
+		v.sum(want, msg, ptn(3))
+		for _, workers := range []int{0, 1, 2, 7, 64} {
+			for _, writeSize := range []int{len(msg), 100000, 4096} {
+				h := v.new(ptn(3), workers)
+				for p := msg; len(p) > 0; {
+					n := writeSize
+					if n > len(p) {
+						n = len(p)
+					}
+					_, _ = h.Write(p[:n])
+					p = p[n:]
+				}
+				got := make([]byte, len(want))
+				_, _ = h.Read(got)
+				if !bytes.Equal(got, want) {
+					t.Fatalf("%x != %x (workers=%d, writeSize=%d)", got, want, workers, writeSize)
+				}
+			}
+		}
+	}
+}
+
 func BenchmarkK12_100B(b *testing.B) { benchmarkK12(b, 100, 1) }
 func BenchmarkK12_10K(b *testing.B)  { benchmarkK12(b, 10000, 1) }
 func BenchmarkK12_100K(b *testing.B) { benchmarkK12(b, 10000, 10) }
@@ -94,3 +163,24 @@ func benchmarkK12(b *testing.B, size, num int) {
 		_, _ = h.Read(d)
 	}
 }
+
+func BenchmarkParallel(b *testing.B) {
+	data := make([]byte, 64<<20)
+	d := make([]byte, 32)
+	b.Run("Draft10Sum", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			Draft10Sum(d, data, nil)
+		}
+	})
+	for _, workers := range []int{2, 4, 8, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				h := NewKT128Parallel(nil, workers)
+				_, _ = h.Write(data)
+				_, _ = h.Read(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package k12
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/karalef/circl/internal/sha3"
+)
+
+// The marshalled state is
+//
+//	magic ‖ cvSize ‖ lanes ‖ mode ‖ initialTodo ‖ offset ‖ chunk ‖
+//	len(context) ‖ context ‖ stalk ‖ leaf or buffered data
+//
+// where cvSize, lanes and mode are single bytes, initialTodo, offset and
+// len(context) are encoded in little-endian order on 4 bytes, and chunk on
+// 8 bytes. The mode tells whether the first chunk is still being absorbed
+// (modeFirst), or which of the leaf (modeLeaf) or the offset bytes of
+// buffered data (modeBuffered) follow the marshalled stalk.
+//
+// The buffered chunks are kept as they are, so the state is restored with
+// the number of lanes it was saved with. It is supported on every platform,
+// albeit more slowly without the parallel permutations. The number of
+// workers is not part of the state: it is kept from the receiver of
+// UnmarshalBinary.
+const (
+	magic = "k12\x01"
+
+	modeFirst    = 0
+	modeLeaf     = 1
+	modeBuffered = 2
+
+	headerSize = len(magic) + 3 + 4 + 4 + 8 + 4
+)
+
+var (
+	errInvalidState  = errors.New("k12: invalid hash state")
+	errStateIdentity = errors.New("k12: invalid hash state identifier")
+)
+
+// MarshalBinary encodes the state, including the buffered chunks and the
+// state of the leaf.
+func (s *State) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// AppendBinary appends the encoding of the state to b.
+func (s *State) AppendBinary(b []byte) ([]byte, error) {
+	mode := byte(modeFirst)
+	if s.buf != nil {
+		mode = modeBuffered
+		if s.leaf != nil {
+			mode = modeLeaf
+		}
+	}
+
+	b = append(b, magic...)
+	b = append(b, byte(s.cvSize), s.lanes, mode)
+	b = binary.LittleEndian.AppendUint32(b, uint32(s.initialTodo))
+	b = binary.LittleEndian.AppendUint32(b, uint32(s.offset))
+	b = binary.LittleEndian.AppendUint64(b, uint64(s.chunk))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s.context)))
+	b = append(b, s.context...)
+	b, _ = s.stalk.AppendBinary(b)
+	switch mode {
+	case modeLeaf:
+		b, _ = s.leaf.AppendBinary(b)
+	case modeBuffered:
+		b = append(b, s.buf[:s.offset]...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary restores the state from the encoding produced by
+// MarshalBinary.
+func (s *State) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic) || string(b[:len(magic)]) != magic {
+		return errStateIdentity
+	}
+	if len(b) < headerSize {
+		return errInvalidState
+	}
+	b = b[len(magic):]
+
+	cvSize, lanes, mode := int(b[0]), b[1], b[2]
+	initialTodo := int(binary.LittleEndian.Uint32(b[3:]))
+	offset := int(binary.LittleEndian.Uint32(b[7:]))
+	chunk := uint(binary.LittleEndian.Uint64(b[11:]))
+	contextLen := int(binary.LittleEndian.Uint32(b[19:]))
+	b = b[23:]
+
+	var t State
+	switch cvSize {
+	case 32:
+		t = newKT128(nil, lanes)
+	case 64:
+		t = newKT256(nil, lanes)
+	default:
+		return errInvalidState
+	}
+	if lanes != 1 && lanes != 2 && lanes != 4 && lanes != 8 {
+		return errInvalidState
+	}
+	if initialTodo > chunkSize || (initialTodo > 0 && mode != modeFirst) {
+		return errInvalidState
+	}
+
+	if len(b) < contextLen {
+		return errInvalidState
+	}
+	t.context = append([]byte{}, b[:contextLen]...)
+	b = b[contextLen:]
+
+	if len(b) < sha3.MarshaledSize {
+		return errInvalidState
+	}
+	// The stalk switches its domain separation byte past the first chunk.
+	if mode != modeFirst {
+		t.stalk.SwitchDS(0x06)
+	}
+	if err := t.stalk.UnmarshalBinary(b[:sha3.MarshaledSize]); err != nil {
+		return err
+	}
+	b = b[sha3.MarshaledSize:]
+
+	switch mode {
+	case modeFirst:
+		if offset != 0 || chunk != 0 || len(b) != 0 {
+			return errInvalidState
+		}
+	case modeLeaf:
+		if lanes != 1 || offset >= chunkSize || len(b) != sha3.MarshaledSize {
+			return errInvalidState
+		}
+		leaf := t.newLeaf()
+		if err := leaf.UnmarshalBinary(b); err != nil {
+			return err
+		}
+		t.buf = make([]byte, 0)
+		t.leaf = &leaf
+	case modeBuffered:
+		if len(b) != offset {
+			return errInvalidState
+		}
+		// The buffer of a parallel state may hold more than lanes chunks.
+		t.workers = s.workers
+		size := t.bufSize()
+		for size <= offset {
+			size += int(lanes) * chunkSize
+		}
+		t.buf = make([]byte, size)
+		copy(t.buf, b)
+	default:
+		return errInvalidState
+	}
+
+	t.workers = s.workers
+	t.initialTodo = initialTodo
+	t.offset = offset
+	t.chunk = chunk
+	*s = t
+	return nil
+}
@@ -4,16 +4,31 @@
 //
 // SHAKE functions are defined in FIPS-202, see https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.202.pdf.
 // BLAKE2Xb and BLAKE2Xs are defined in https://www.blake2.net/blake2x.pdf.
+// TurboSHAKE, KT128 and KT256 are defined in RFC 9861, see https://www.rfc-editor.org/rfc/rfc9861.
+// K12D10 is KangarooTwelve as of draft 10 of the same document, which is
+// equivalent to KT128.
+// Ascon-XOF and Ascon-XOFa are defined in ASCON v1.2, see https://ascon.iaik.tugraz.at.
+// Ascon-XOF128 is defined in NIST SP 800-232, see https://doi.org/10.6028/NIST.SP.800-232.
+// Xoodyak is defined in its submission to the NIST lightweight cryptography
+// project, see https://keccak.team/xoodyak.html.
+//
+// # Serialization
+//
+// The XOFs returned by ID.New implement encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler, so that their state can be saved, for
+// instance between two runs of a program hashing a long log, and restored
+// into an instance of the same ID. The encodings start with an identifier
+// of the function and of the version of the format, and include the input
+// or output buffered by the XOF.
 package xof
 
 import (
 	"io"
 
+	"github.com/karalef/circl/cipher/ascon"
+	"github.com/karalef/circl/cipher/xoodyak"
 	"github.com/karalef/circl/internal/sha3"
 	"github.com/karalef/circl/xof/k12"
-
-	"golang.org/x/crypto/blake2b"
-	"golang.org/x/crypto/blake2s"
 )
 
 // XOF defines the interface to hash functions that support arbitrary-length output.
@@ -41,8 +56,20 @@ const (
 	BLAKE2XB
 	BLAKE2XS
 	K12D10
+	TURBOSHAKE128
+	TURBOSHAKE256
+	KT128
+	KT256
+	ASCONXOF
+	ASCONXOFA
+	ASCONXOF128
+	XOODYAK
 )
 
+// DefaultTurboShakeDomain is the domain separation byte used by the
+// TurboSHAKE instances returned by ID.New.
+const DefaultTurboShakeDomain = 0x1f
+
 func (x ID) New() XOF {
 	switch x {
 	case SHAKE128:
@@ -52,34 +79,64 @@ func (x ID) New() XOF {
 		s := sha3.NewShake256()
 		return shakeBody{&s}
 	case BLAKE2XB:
-		x, _ := blake2b.NewXOF(blake2b.OutputLengthUnknown, nil)
-		return blake2xb{x}
+		return newBlake2x(&blake2xbParams)
 	case BLAKE2XS:
-		x, _ := blake2s.NewXOF(blake2s.OutputLengthUnknown, nil)
-		return blake2xs{x}
+		return newBlake2x(&blake2xsParams)
 	case K12D10:
 		x := k12.NewDraft10([]byte{})
-		return k12d10{&x}
+		return k12State{&x}
+	case TURBOSHAKE128:
+		return NewTurboShake128(DefaultTurboShakeDomain)
+	case TURBOSHAKE256:
+		return NewTurboShake256(DefaultTurboShakeDomain)
+	case KT128:
+		x := k12.NewKT128([]byte{})
+		return k12State{&x}
+	case KT256:
+		x := k12.NewKT256([]byte{})
+		return k12State{&x}
+	case ASCONXOF:
+		return asconXof{ascon.NewXOF()}
+	case ASCONXOFA:
+		return asconXof{ascon.NewXOFa()}
+	case ASCONXOF128:
+		return asconXof{ascon.NewXOF128()}
+	case XOODYAK:
+		return xoodyakXof{xoodyak.NewXOF()}
 	default:
 		panic("crypto: requested unavailable XOF function")
 	}
 }
 
-type shakeBody struct{ sha3.ShakeHash }
-
-func (s shakeBody) Clone() XOF { return shakeBody{s.ShakeHash.Clone()} }
-
-type blake2xb struct{ blake2b.XOF }
+// NewTurboShake128 returns a TurboSHAKE128 instance with domain separation
+// byte D, which must be between 0x01 and 0x7f inclusive, otherwise it panics.
+func NewTurboShake128(D byte) XOF {
+	s := sha3.NewTurboShake128(D)
+	return shakeBody{&s}
+}
 
-func (s blake2xb) Clone() XOF { return blake2xb{s.XOF.Clone()} }
+// NewTurboShake256 returns a TurboSHAKE256 instance with domain separation
+// byte D, which must be between 0x01 and 0x7f inclusive, otherwise it panics.
+func NewTurboShake256(D byte) XOF {
+	s := sha3.NewTurboShake256(D)
+	return shakeBody{&s}
+}
 
-type blake2xs struct{ blake2s.XOF }
+type shakeBody struct{ *sha3.State }
 
-func (s blake2xs) Clone() XOF { return blake2xs{s.XOF.Clone()} }
+func (s shakeBody) Clone() XOF { return shakeBody{s.State.Clone().(*sha3.State)} }
 
-type k12d10 struct{ *k12.State }
+type k12State struct{ *k12.State }
 
-func (s k12d10) Clone() XOF {
+func (s k12State) Clone() XOF {
 	x := s.State.Clone()
-	return k12d10{&x}
+	return k12State{&x}
 }
+
+type asconXof struct{ *ascon.XOF }
+
+func (s asconXof) Clone() XOF { return asconXof{s.XOF.Clone()} }
+
+type xoodyakXof struct{ *xoodyak.XOF }
+
+func (s xoodyakXof) Clone() XOF { return xoodyakXof{s.XOF.Clone()} }
@@ -2,12 +2,15 @@ package xof_test
 
 import (
 	"bytes"
+	"encoding"
 	"encoding/hex"
 	"io"
 	"testing"
 
 	"github.com/karalef/circl/internal/test"
 	"github.com/karalef/circl/xof"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
 )
 
 type vector struct {
@@ -59,6 +62,54 @@ var allVectors = []vector{
 		out:    "b4f249b4f77c58df170aa4d1723db1127d82f1d98d25ddda561ada459cd11a48",
 		outLen: 32,
 	},
+	{
+		id:     xof.TURBOSHAKE128,
+		in:     "",
+		out:    "1e415f1c5983aff2169217277d17bb538cd945a397ddec541f1ce41af2c1b74c",
+		outLen: 32,
+	},
+	{
+		id:     xof.TURBOSHAKE256,
+		in:     "",
+		out:    "367a329dafea871c7802ec67f905ae13c57695dc2c6663c61035f59a18f8e7db11edc0e12e91ea60eb6b32df06dd7f002fbafabb6e13ec1cc20d995547600db0",
+		outLen: 64,
+	},
+	{
+		id:     xof.KT128,
+		in:     "The quick brown fox jumps over the lazy dog",
+		out:    "b4f249b4f77c58df170aa4d1723db1127d82f1d98d25ddda561ada459cd11a48",
+		outLen: 32,
+	},
+	{
+		id:     xof.KT256,
+		in:     "",
+		out:    "b23d2e9cea9f4904e02bec06817fc10ce38ce8e93ef4c89e6537076af8646404e3e8b68107b8833a5d30490aa33482353fd4adc7148ecb782855003aaebde4a9",
+		outLen: 64,
+	},
+	{
+		id:     xof.ASCONXOF,
+		in:     "",
+		out:    "5d4cbde6350ea4c174bd65b5b332f8408f99740b81aa02735eaefbcf0ba0339e",
+		outLen: 32,
+	},
+	{
+		id:     xof.ASCONXOFA,
+		in:     "",
+		out:    "7c10dffd6bb03be262d72fbe1b0f530013c6c4eadaabde278d6f29d579e3908d",
+		outLen: 32,
+	},
+	{
+		id:     xof.ASCONXOF128,
+		in:     "",
+		out:    "473d5e6164f58b39dfd84aacdb8ae42ec2d91fed33388ee0d960d9b3993295c6",
+		outLen: 32,
+	},
+	{
+		id:     xof.XOODYAK,
+		in:     "",
+		out:    "ea152f2b47bce24efb66c479d4adf17bd324d806e85ff75ee369ee50dc8f8bd1",
+		outLen: 32,
+	},
 }
 
 func TestXof(t *testing.T) {
@@ -85,3 +136,204 @@ func TestXof(t *testing.T) {
 	})
 	test.CheckNoErr(t, err, "must panic")
 }
+
+func TestTurboShakeDomain(t *testing.T) {
+	// RFC 9861 §5
+	for i, v := range []struct {
+		new  func(D byte) xof.XOF
+		in   string
+		D    byte
+		want string
+	}{
+		{xof.NewTurboShake128, "ff", 0x06, "8ec9c66465ed0d4a6c35d13506718d687a25cb05c74cca1e42501abd83874a67"},
+		{xof.NewTurboShake128, "ffffff", 0x07, "b658576001cad9b1e5f399a9f77723bba05458042d68206f7252682dba3663ed"},
+		{xof.NewTurboShake256, "ff", 0x06, "738d7b4e37d18b7f22ad1b5313e357e3dd7d07056a26a303c433fa3533455280f4f5a7d4f700efb437fe6d281405e07be32a0a972e22e63adc1b090daefe004b"},
+	} {
+		in, _ := hex.DecodeString(v.in)
+		want, _ := hex.DecodeString(v.want)
+		x := v.new(v.D)
+		_, _ = x.Write(in)
+		got := make([]byte, len(want))
+		_, _ = x.Read(got)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, i)
+		}
+	}
+
+	err := test.CheckPanic(func() { xof.NewTurboShake128(0x80) })
+	test.CheckNoErr(t, err, "must panic")
+}
+
+var allIDs = []xof.ID{
+	xof.SHAKE128, xof.SHAKE256, xof.BLAKE2XB, xof.BLAKE2XS, xof.K12D10,
+	xof.TURBOSHAKE128, xof.TURBOSHAKE256, xof.KT128, xof.KT256,
+	xof.ASCONXOF, xof.ASCONXOFA, xof.ASCONXOF128, xof.XOODYAK,
+}
+
+// restore returns a new instance of id with the state of x.
+func restore(t *testing.T, id xof.ID, x xof.XOF) xof.XOF {
+	b, err := x.(encoding.BinaryMarshaler).MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	y := id.New()
+	err = y.(encoding.BinaryUnmarshaler).UnmarshalBinary(b)
+	test.CheckNoErr(t, err, "failed to unmarshal")
+	return y
+}
+
+func TestMarshal(t *testing.T) {
+	msg := make([]byte, 5*8192+100)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for _, id := range allIDs {
+		for _, n := range []int{0, 1, 100, 8192, 8193, 3*8192 + 17} {
+			want := make([]byte, 300)
+			x := id.New()
+			_, _ = x.Write(msg[:n])
+			_, _ = x.Write(msg[n:])
+			_, _ = x.Read(want)
+
+			got := make([]byte, len(want))
+			x = id.New()
+			_, _ = x.Write(msg[:n])
+			x = restore(t, id, x)
+			_, _ = x.Write(msg[n:])
+			_, _ = x.Read(got[:n%len(got)])
+			x = restore(t, id, x)
+			_, _ = x.Read(got[n%len(got):])
+			if !bytes.Equal(got, want) {
+				test.ReportError(t, got, want, id, n)
+			}
+		}
+
+		// The encodings of other functions are rejected.
+		for _, other := range allIDs {
+			b, _ := other.New().(encoding.BinaryMarshaler).MarshalBinary()
+			err := id.New().(encoding.BinaryUnmarshaler).UnmarshalBinary(b)
+			switch {
+			case other == id:
+				test.CheckNoErr(t, err, "failed to unmarshal")
+			case isSameFamily(id, other):
+			default:
+				test.CheckIsErr(t, err, "should fail due to other function")
+			}
+			err = id.New().(encoding.BinaryUnmarshaler).UnmarshalBinary(b[:len(b)-1])
+			test.CheckIsErr(t, err, "should fail due to short encoding")
+		}
+	}
+}
+
+// isSameFamily tells whether the encodings of x and y share the format,
+// which restores the function along with the state.
+func isSameFamily(x, y xof.ID) bool {
+	family := func(id xof.ID) int {
+		switch id {
+		case xof.SHAKE128, xof.SHAKE256, xof.TURBOSHAKE128, xof.TURBOSHAKE256:
+			return 1
+		case xof.K12D10, xof.KT128, xof.KT256:
+			return 2
+		case xof.ASCONXOF, xof.ASCONXOFA, xof.ASCONXOF128:
+			return 3
+		default:
+			return int(id) << 8
+		}
+	}
+	return family(x) == family(y)
+}
+
+func TestBlake2x(t *testing.T) {
+	// The BLAKE2X instances must match the ones of golang.org/x/crypto.
+	msg := make([]byte, 300)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	for _, v := range []struct {
+		id  xof.ID
+		new func() (io.ReadWriter, error)
+	}{
+		{xof.BLAKE2XB, func() (io.ReadWriter, error) { return blake2b.NewXOF(blake2b.OutputLengthUnknown, nil) }},
+		{xof.BLAKE2XS, func() (io.ReadWriter, error) { return blake2s.NewXOF(blake2s.OutputLengthUnknown, nil) }},
+	} {
+		for _, n := range []int{0, 1, 64, 65, 128, 129, 300} {
+			want := make([]byte, 1000)
+			x, _ := v.new()
+			_, _ = x.Write(msg[:n])
+			_, _ = x.Read(want)
+
+			got := make([]byte, len(want))
+			y := v.id.New()
+			_, _ = y.Write(msg[:n])
+			for i := 0; i < len(got); i += 7 + n {
+				j := i + 7 + n
+				if j > len(got) {
+					j = len(got)
+				}
+				_, _ = y.Read(got[i:j])
+			}
+			if !bytes.Equal(got, want) {
+				test.ReportError(t, got, want, v.id, n)
+			}
+		}
+	}
+}
+
+func TestBlake2xUnmarshal(t *testing.T) {
+	// The number of bytes buffered by the BLAKE2 hash of the input and the
+	// offset in the output block are bounded by the block sizes.
+	for _, v := range []struct {
+		id             xof.ID
+		n, blockSize   int // position of the BLAKE2 buffered length, block size
+		offset, digest int // position of the output offset, digest size
+	}{
+		{xof.BLAKE2XB, 214, 128, 343, 64},
+		{xof.BLAKE2XS, 110, 64, 175, 32},
+	} {
+		x := v.id.New()
+		_, _ = x.Write(make([]byte, 10))
+		b, _ := x.(encoding.BinaryMarshaler).MarshalBinary()
+		test.CheckOk(int(b[v.n]) == 10, "bad position of the buffered length", t)
+
+		for _, c := range []struct {
+			pos int
+			val int
+			ok  bool
+		}{
+			{v.n, v.blockSize, true},
+			{v.n, v.blockSize + 1, false},
+			{v.offset, v.digest, true},
+			{v.offset, v.digest + 1, false},
+		} {
+			s := append([]byte(nil), b...)
+			s[c.pos] = byte(c.val)
+			err := v.id.New().(encoding.BinaryUnmarshaler).UnmarshalBinary(s)
+			if (err == nil) != c.ok {
+				test.ReportError(t, err, c.ok, v.id, c.pos, c.val)
+			}
+		}
+	}
+}
+
+func BenchmarkBlake2x(b *testing.B) {
+	// The BLAKE2 of this package has no assembly, unlike that of
+	// golang.org/x/crypto on amd64, which is benchmarked for comparison.
+	msg := make([]byte, 1<<20)
+	out := make([]byte, 64)
+	for _, v := range []struct {
+		name string
+		new  func() (io.ReadWriter, error)
+	}{
+		{"BLAKE2XB", func() (io.ReadWriter, error) { return xof.BLAKE2XB.New(), nil }},
+		{"BLAKE2XB/x-crypto", func() (io.ReadWriter, error) { return blake2b.NewXOF(blake2b.OutputLengthUnknown, nil) }},
+		{"BLAKE2XS", func() (io.ReadWriter, error) { return xof.BLAKE2XS.New(), nil }},
+		{"BLAKE2XS/x-crypto", func() (io.ReadWriter, error) { return blake2s.NewXOF(blake2s.OutputLengthUnknown, nil) }},
+	} {
+		b.Run(v.name, func(b *testing.B) {
+			b.SetBytes(int64(len(msg)))
+			for i := 0; i < b.N; i++ {
+				x, _ := v.new()
+				_, _ = x.Write(msg)
+				_, _ = x.Read(out)
+			}
+		})
+	}
+}
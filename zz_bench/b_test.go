@@ -0,0 +1,5 @@
+package bench
+import ("testing"; "github.com/karalef/circl/xof"; "golang.org/x/crypto/blake2b")
+var data = make([]byte, 1<<20)
+func BenchmarkLocal(b *testing.B){ b.SetBytes(1<<20); for i:=0;i<b.N;i++{ x:=xof.BLAKE2XB.New(); x.Write(data); var o [64]byte; x.Read(o[:]) } }
+func BenchmarkXCrypto(b *testing.B){ b.SetBytes(1<<20); for i:=0;i<b.N;i++{ x,_:=blake2b.NewXOF(blake2b.OutputLengthUnknown,nil); x.Write(data); var o [64]byte; x.Read(o[:]) } }